@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TensorBeat/Datalake/internal/repository"
+	"github.com/TensorBeat/Datalake/internal/util"
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+const usage = `Usage: admin <command>
+
+Commands:
+  indexes check   reports how the indexes differ from the declared ones,
+                  exits with 1 when they do
+  indexes sync    builds the missing indexes, like the server does
+  indexes rebuild builds the missing indexes and drops and builds again
+                  the changed ones
+
+  revisions backfill
+                  gives revision 1 to songs stored before revisions
+                  existed, run it once after upgrading
+
+  tags [flags] rename <key> <new key>
+                  renames a tag key, songs already having the new key are
+                  left alone
+  tags [flags] remap <key> <old>=<new>...
+                  remaps string values of a tag key
+  tags [flags] resume <migration id>
+                  resumes a migration that stopped before completing
+  tags [flags] rollback <migration id>
+                  restores the tags a migration changed
+  tags status <migration id>
+                  prints the progress of a migration
+
+Flags of tags:
+  -dry-run        only counts the songs rename and remap would match
+  -batch-size n   songs changed per batch, defaults to 500
+
+Uses the MONGO_URI, ENVIRONMENT and SEARCH_TAGS variables of the server.
+`
+
+func main() {
+
+	logger := util.MakeLogger()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		logger.Warnf("No .env loaded: %v", err)
+	}
+
+	if len(os.Args) < 3 {
+		exitUsage()
+	}
+
+	ctx := context.Background()
+	switch os.Args[1] {
+	case "indexes":
+		indexes(ctx, logger, os.Args[2])
+	case "revisions":
+		revisions(ctx, logger, os.Args[2])
+	case "tags":
+		tags(ctx, logger, os.Args[2:])
+	default:
+		exitUsage()
+	}
+}
+
+func exitUsage() {
+	fmt.Fprint(os.Stderr, usage)
+	os.Exit(2)
+}
+
+func indexes(ctx context.Context, logger *zap.SugaredLogger, command string) {
+	if command != "check" && command != "sync" && command != "rebuild" {
+		exitUsage()
+	}
+
+	repo, disconnect := connect(ctx, logger)
+	defer disconnect()
+
+	switch command {
+	case "check":
+		drift, err := repo.CheckIndexes(ctx)
+		if err != nil {
+			logger.Fatalf("Couldn't check indexes: %v", err)
+		}
+		printDrift(drift)
+		if len(drift) > 0 {
+			disconnect()
+			os.Exit(1)
+		}
+	case "sync":
+		drift, err := repo.SyncIndexes(ctx)
+		printDrift(drift)
+		if err != nil {
+			logger.Fatalf("Couldn't sync indexes: %v", err)
+		}
+	case "rebuild":
+		drift, err := repo.RebuildIndexes(ctx)
+		printDrift(drift)
+		if err != nil {
+			logger.Fatalf("Couldn't rebuild indexes: %v", err)
+		}
+	}
+}
+
+func revisions(ctx context.Context, logger *zap.SugaredLogger, command string) {
+	if command != "backfill" {
+		exitUsage()
+	}
+
+	repo, disconnect := connect(ctx, logger)
+	defer disconnect()
+
+	backfilled, err := repo.BackfillRevisions(ctx)
+	if err != nil {
+		logger.Fatalf("Couldn't backfill revisions: %v", err)
+	}
+	fmt.Printf("backfilled %v songs\n", backfilled)
+}
+
+func tags(ctx context.Context, logger *zap.SugaredLogger, args []string) {
+	flags := flag.NewFlagSet("tags", flag.ExitOnError)
+	flags.Usage = exitUsage
+	dryRun := flags.Bool("dry-run", false, "")
+	batchSize := flags.Int64("batch-size", repository.DefaultMigrationBatchSize, "")
+	flags.Parse(args)
+	args = flags.Args()
+
+	if len(args) < 2 {
+		exitUsage()
+	}
+	if err := repository.ValidateMigrationBatchSize(*batchSize); err != nil {
+		logger.Fatal(err)
+	}
+
+	var spec *repository.TagMigrationSpec
+	switch args[0] {
+	case "rename":
+		if len(args) != 3 {
+			exitUsage()
+		}
+		spec = &repository.TagMigrationSpec{Key: args[1], NewKey: args[2]}
+	case "remap":
+		if len(args) < 3 {
+			exitUsage()
+		}
+		spec = &repository.TagMigrationSpec{Key: args[1], Values: make(map[string]string)}
+		for _, arg := range args[2:] {
+			parts := strings.SplitN(arg, "=", 2)
+			if len(parts) != 2 {
+				exitUsage()
+			}
+			spec.Values[parts[0]] = parts[1]
+		}
+	case "resume", "rollback", "status":
+		if len(args) != 2 {
+			exitUsage()
+		}
+	default:
+		exitUsage()
+	}
+	if spec != nil {
+		if err := repository.ValidateTagMigration(spec); err != nil {
+			logger.Fatal(err)
+		}
+	}
+
+	repo, disconnect := connect(ctx, logger)
+	defer disconnect()
+
+	var err error
+	switch {
+	case spec != nil && *dryRun:
+		var plan *repository.TagMigration
+		plan, err = repo.PlanTagMigration(ctx, spec)
+		if err == nil {
+			printMigration(plan)
+		}
+	case spec != nil:
+		var migration *repository.TagMigration
+		migration, err = repo.CreateTagMigration(ctx, spec)
+		if err == nil {
+			_, err = repo.RunTagMigration(ctx, migration.ID, *batchSize, printMigration)
+		}
+	case args[0] == "resume":
+		_, err = repo.RunTagMigration(ctx, args[1], *batchSize, printMigration)
+	case args[0] == "rollback":
+		_, err = repo.RollBackTagMigration(ctx, args[1], *batchSize, printMigration)
+	case args[0] == "status":
+		var migration *repository.TagMigration
+		migration, err = repo.GetTagMigration(ctx, args[1])
+		if err == nil {
+			printMigration(migration)
+		}
+	}
+	if err != nil {
+		logger.Fatalf("Couldn't %v tags: %v", args[0], err)
+	}
+}
+
+func connect(ctx context.Context, logger *zap.SugaredLogger) (*repository.MongoRepository, func()) {
+	MongoURI := os.Getenv("MONGO_URI")
+	IsProduction := os.Getenv("ENVIRONMENT") == "prod"
+
+	mongoCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	mongoClient, err := mongo.Connect(mongoCtx, options.Client().ApplyURI(MongoURI))
+	if err != nil {
+		logger.Fatalf("Couldn't connect to mongo: %v", err)
+	}
+
+	err = mongoClient.Ping(ctx, readpref.Primary())
+	if err != nil {
+		logger.Fatalf("Couldn't ping mongo: %v", err)
+	}
+
+	var dbName string
+	if IsProduction {
+		dbName = "prod"
+	} else {
+		dbName = "test"
+	}
+	disconnect := func() {
+		mongoClient.Disconnect(ctx)
+	}
+	repo := repository.NewMongoRepository(mongoClient, logger, dbName)
+	if err := repo.SetSearchTags(util.SearchTags()); err != nil {
+		logger.Fatalf("Invalid SEARCH_TAGS: %v", err)
+	}
+	return repo, disconnect
+}
+
+func printDrift(drift []*repository.IndexDrift) {
+	if len(drift) == 0 {
+		fmt.Println("indexes are in sync")
+		return
+	}
+	for _, d := range drift {
+		fmt.Println(d)
+	}
+}
+
+func printMigration(migration *repository.TagMigration) error {
+	id := migration.ID
+	if id == "" {
+		id = "dry run"
+	}
+	fmt.Printf("%v %v: %v matched, %v modified, %v conflicts",
+		id, migration.State, migration.MatchedCount, migration.ModifiedCount, migration.ConflictCount)
+	if migration.State == repository.MigrationRollingBack || migration.State == repository.MigrationRolledBack {
+		fmt.Printf(", %v rolled back, %v rollback conflicts", migration.RolledBackCount, migration.RollbackConflictCount)
+	}
+	fmt.Println()
+	return nil
+}
@@ -20,6 +20,15 @@ import (
 	"google.golang.org/grpc/reflection"
 )
 
+const (
+	mongoBackend  = "mongo"
+	memoryBackend = "memory"
+
+	backgroundIndexBuild = "background"
+	blockingIndexBuild   = "blocking"
+	skipIndexBuild       = "off"
+)
+
 func main() {
 
 	logger := util.MakeLogger()
@@ -33,29 +42,68 @@ func main() {
 	ListenAddress := ":" + os.Getenv("PORT")
 	MongoURI := os.Getenv("MONGO_URI")
 	IsProduction := os.Getenv("ENVIRONMENT") == "prod"
+	Backend := os.Getenv("BACKEND")        // mongo (default) or memory
+	IndexBuild := os.Getenv("INDEX_BUILD") // background (default), blocking or off
 
 	ctx := context.Background()
 
-	mongoCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	mongoClient, err := mongo.Connect(mongoCtx, options.Client().ApplyURI(MongoURI))
-	if err != nil {
-		logger.Fatalf("Couldn't connect to mongo: %v", err)
+	var repo repository.Repository
+	switch Backend {
+	case memoryBackend:
+		logger.Warnf("Using in-memory backend, songs will not be persisted")
+		repo = repository.NewMemoryRepository(logger)
+	case mongoBackend, "":
+		mongoCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		mongoClient, err := mongo.Connect(mongoCtx, options.Client().ApplyURI(MongoURI))
+		if err != nil {
+			logger.Fatalf("Couldn't connect to mongo: %v", err)
+		}
+		defer mongoClient.Disconnect(ctx)
+
+		err = mongoClient.Ping(ctx, readpref.Primary())
+		if err != nil {
+			logger.Fatalf("Couldn't ping mongo: %v", err)
+		}
+
+		var dbName string
+		if IsProduction {
+			dbName = "prod"
+		} else {
+			dbName = "test"
+		}
+		repo = repository.NewMongoRepository(mongoClient, logger, dbName)
+	default:
+		logger.Fatalf("Unknown backend %q, expected %q or %q", Backend, mongoBackend, memoryBackend)
 	}
-	defer mongoClient.Disconnect(ctx)
 
-	err = mongoClient.Ping(ctx, readpref.Primary())
-	if err != nil {
-		logger.Fatalf("Couldn't ping mongo: %v", err)
+	if err := repo.SetSearchTags(util.SearchTags()); err != nil {
+		logger.Fatalf("Invalid SEARCH_TAGS: %v", err)
 	}
 
-	var dbName string
-	if IsProduction {
-		dbName = "prod"
-	} else {
-		dbName = "test"
+	switch IndexBuild {
+	case backgroundIndexBuild, "":
+		// Queries work without the indexes, only slower, so serve meanwhile
+		go func() {
+			if _, err := repo.SyncIndexes(ctx); err != nil {
+				logger.Errorf("Couldn't sync indexes: %v", err)
+			}
+		}()
+	case blockingIndexBuild:
+		if _, err := repo.SyncIndexes(ctx); err != nil {
+			logger.Fatalf("Couldn't sync indexes: %v", err)
+		}
+	case skipIndexBuild:
+		drift, err := repo.CheckIndexes(ctx)
+		if err != nil {
+			logger.Fatalf("Couldn't check indexes: %v", err)
+		}
+		for _, d := range drift {
+			logger.Warnf("Index drift: %v", d)
+		}
+	default:
+		logger.Fatalf("Unknown index build %q, expected %q, %q or %q", IndexBuild, backgroundIndexBuild, blockingIndexBuild, skipIndexBuild)
 	}
-	repository := repository.NewMongoRepository(mongoClient, logger, dbName)
 
 	listener, err := net.Listen("tcp", ListenAddress)
 	if err != nil {
@@ -66,7 +114,7 @@ func main() {
 	grpcServer := grpc.NewServer()
 	defer grpcServer.Stop()
 
-	datalakeService := controller.NewDatalakeServiceServer(repository, logger)
+	datalakeService := controller.NewDatalakeServiceServer(repo, logger)
 	proto.RegisterDatalakeServiceServer(grpcServer, datalakeService)
 	reflection.Register(grpcServer)
 
@@ -78,7 +126,7 @@ func main() {
 
 	logger.Infof("Server succesfully started on %v", ListenAddress)
 
-	c := make(chan os.Signal)
+	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
 	<-c
 
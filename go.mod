@@ -4,12 +4,14 @@ go 1.15
 
 require (
 	github.com/benweissmann/memongo v0.1.1
+	github.com/golang/protobuf v1.4.2
 	github.com/joho/godotenv v1.3.0
 	go.mongodb.org/mongo-driver v1.4.6
 	go.uber.org/zap v1.16.0
 	golang.org/x/mod v0.4.1 // indirect
 	golang.org/x/sys v0.0.0-20210124154548-22da62e12c0c // indirect
 	golang.org/x/tools v0.1.0 // indirect
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013
 	google.golang.org/grpc v1.35.0
 	google.golang.org/protobuf v1.25.0
 )
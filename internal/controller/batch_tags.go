@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/TensorBeat/Datalake/internal/query"
+	"github.com/TensorBeat/Datalake/internal/repository"
+	"github.com/TensorBeat/Datalake/pkg/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *DatalakeServiceServer) BatchAddTags(ctx context.Context, req *proto.BatchAddTagsRequest) (*proto.BatchAddTagsResponse, error) {
+
+	selector, err := songSelector(req.Songs)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, typedTags, err := ProtoTagsToRepoTags(req.Tags, req.TypedTags)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(tags) == 0 && len(typedTags) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one tag is required")
+	}
+
+	schemas, err := s.tagSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var violations fieldViolations
+	violations.add("", schemas.ValidateTags(tags, typedTags, false))
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.BatchAddTags(ctx, selector, tags, typedTags, req.DryRun)
+
+	if err != nil {
+		s.logger.Errorf("Failed to add tags: %v", err)
+		return nil, statusError(err)
+	}
+
+	res := &proto.BatchAddTagsResponse{
+		MatchedCount:  result.MatchedCount,
+		ModifiedCount: result.ModifiedCount,
+	}
+	return res, nil
+}
+
+func (s *DatalakeServiceServer) BatchRemoveTags(ctx context.Context, req *proto.BatchRemoveTagsRequest) (*proto.BatchRemoveTagsResponse, error) {
+
+	selector, err := songSelector(req.Songs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repository.ValidateChangedTagKeys(req.Keys); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	schemas, err := s.tagSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var violations fieldViolations
+	violations.add("", schemas.ValidateRemoval(req.Keys))
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.BatchRemoveTags(ctx, selector, req.Keys, req.DryRun)
+
+	if err != nil {
+		s.logger.Errorf("Failed to remove tags: %v", err)
+		return nil, statusError(err)
+	}
+
+	res := &proto.BatchRemoveTagsResponse{
+		MatchedCount:  result.MatchedCount,
+		ModifiedCount: result.ModifiedCount,
+	}
+	return res, nil
+}
+
+func songSelector(songs *proto.SongSelector) (repository.SongSelector, error) {
+	var selector repository.SongSelector
+	if songs == nil {
+		return selector, status.Error(codes.InvalidArgument, "songs is required")
+	}
+
+	selector.IDs = songs.Ids
+	if songs.Query != "" {
+		q, err := query.Parse(songs.Query)
+		if err != nil {
+			return selector, status.Error(codes.InvalidArgument, err.Error())
+		}
+		selector.Query = q
+	}
+
+	if err := repository.ValidateSongSelector(selector); err != nil {
+		return selector, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return selector, nil
+}
@@ -2,12 +2,39 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
 
+	"github.com/TensorBeat/Datalake/internal/query"
 	"github.com/TensorBeat/Datalake/internal/repository"
 	"github.com/TensorBeat/Datalake/pkg/proto"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	gproto "google.golang.org/protobuf/proto"
 )
 
+const (
+	// ingestBatchSize is how many songs IngestSongs writes at once
+	ingestBatchSize = 500
+	// defaultRetention is how long deleted songs are kept when
+	// PurgeDeletedSongs isn't given a retention
+	defaultRetention = 30 * 24 * time.Hour
+	// maxSearchLength bounds the text of SearchSongs
+	maxSearchLength = 1024
+)
+
+var songEventTypes = map[repository.SongEventType]proto.SongEventType{
+	repository.SongInserted:    proto.SongEventType_INSERTED,
+	repository.SongUpdated:     proto.SongEventType_UPDATED,
+	repository.SongTagsChanged: proto.SongEventType_TAGS_CHANGED,
+	repository.SongDeleted:     proto.SongEventType_DELETED,
+}
+
 type DatalakeServiceServer struct {
 	repo   repository.Repository
 	logger *zap.SugaredLogger
@@ -24,64 +51,101 @@ func NewDatalakeServiceServer(repo repository.Repository, logger *zap.SugaredLog
 func (s *DatalakeServiceServer) RepoFilesToProtoFiles(repoFiles []*repository.File) []*proto.File {
 	files := make([]*proto.File, len(repoFiles))
 	for i, repoFile := range repoFiles {
-		files[i] = &proto.File{
-			Id:       repoFile.ID,
-			Name:     repoFile.Name,
-			Uri:      repoFile.Uri,
-			MimeType: repoFile.MimeType,
-			Tags:     repoFile.Tags,
-		}
+		files[i] = s.RepoFileToProtoFile(repoFile)
 	}
 	return files
 }
 
-func (s *DatalakeServiceServer) ProtoFilesToRepoFiles(protoFiles []*proto.File) []*repository.File {
+func (s *DatalakeServiceServer) RepoFileToProtoFile(repoFile *repository.File) *proto.File {
+	return &proto.File{
+		Id:        repoFile.ID,
+		Name:      repoFile.Name,
+		Uri:       repoFile.Uri,
+		MimeType:  repoFile.MimeType,
+		Tags:      repoFile.Tags,
+		TypedTags: RepoTagsToProtoTags(repoFile.TypedTags),
+		Revision:  repoFile.Revision,
+	}
+}
+
+// ProtoFilesToRepoFiles and ProtoAddFilesToRepoFiles fail with the index of
+// the first song that has invalid tags.
+func (s *DatalakeServiceServer) ProtoFilesToRepoFiles(protoFiles []*proto.File) ([]*repository.File, error) {
 	files := make([]*repository.File, len(protoFiles))
 	for i, protoFile := range protoFiles {
+		tags, typedTags, err := ProtoTagsToRepoTags(protoFile.Tags, protoFile.TypedTags)
+		if err != nil {
+			return nil, fmt.Errorf("songs[%v]: %v", i, err)
+		}
 		files[i] = &repository.File{
-			ID:       protoFile.Id,
-			Name:     protoFile.Name,
-			Uri:      protoFile.Uri,
-			MimeType: protoFile.MimeType,
-			Tags:     protoFile.Tags,
+			ID:        protoFile.Id,
+			Name:      protoFile.Name,
+			Uri:       protoFile.Uri,
+			MimeType:  protoFile.MimeType,
+			Tags:      tags,
+			TypedTags: typedTags,
+			Revision:  protoFile.Revision,
 		}
 	}
-	return files
+	return files, nil
 }
 
-func (s *DatalakeServiceServer) ProtoAddFilesToRepoFiles(protoFiles []*proto.AddFile) []*repository.File {
+func (s *DatalakeServiceServer) ProtoAddFilesToRepoFiles(protoFiles []*proto.AddFile) ([]*repository.File, error) {
 	files := make([]*repository.File, len(protoFiles))
 	for i, protoFile := range protoFiles {
+		tags, typedTags, err := ProtoTagsToRepoTags(protoFile.Tags, protoFile.TypedTags)
+		if err != nil {
+			return nil, fmt.Errorf("songs[%v]: %v", i, err)
+		}
 		files[i] = &repository.File{
-			Name:     protoFile.Name,
-			Uri:      protoFile.Uri,
-			MimeType: protoFile.MimeType,
-			Tags:     protoFile.Tags,
+			Name:      protoFile.Name,
+			Uri:       protoFile.Uri,
+			MimeType:  protoFile.MimeType,
+			Tags:      tags,
+			TypedTags: typedTags,
 		}
 	}
-	return files
+	return files, nil
+}
+
+// listOptions converts the paging, sorting and read mask fields of a list
+// request.
+func listOptions(pageToken string, pageSize int64, sort *proto.SortOrder, fields []string) (repository.ListOptions, error) {
+	opts := repository.ListOptions{
+		PageToken: pageToken,
+		PageSize:  pageSize,
+		Sort: repository.SortOrder{
+			Field:      sort.GetField(),
+			Descending: sort.GetDescending(),
+		},
+		Fields: fields,
+	}
+	if err := repository.ValidateSortOrder(opts.Sort); err != nil {
+		return opts, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := repository.ValidateReadPaths(opts.Fields); err != nil {
+		return opts, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return opts, nil
 }
 
 func (s *DatalakeServiceServer) GetAllSongs(ctx context.Context, req *proto.GetAllSongsRequest) (*proto.GetAllSongsResponse, error) {
 
 	var songs []*repository.File
-	var nextToken int64
+	var nextToken string
 	var totalSize int64
 	var err error
 
-	// TODO refactor this
-	if req.PageToken == nil {
-		req.PageToken = new(int64)
-	}
-	if req.PageSize == nil {
-		req.PageSize = new(int64)
+	opts, err := listOptions(req.PageToken, req.GetPageSize(), req.Sort, req.GetReadMask().GetPaths())
+	if err != nil {
+		return nil, err
 	}
 
-	songs, nextToken, totalSize, err = s.repo.GetAllSongs(ctx, *req.PageToken, *req.PageSize)
+	songs, nextToken, totalSize, err = s.repo.GetAllSongs(ctx, opts)
 
 	if err != nil {
 		s.logger.Errorf("Failed to get songs: %v", err)
-		return nil, err
+		return nil, statusError(err)
 	}
 
 	res := &proto.GetAllSongsResponse{
@@ -94,23 +158,20 @@ func (s *DatalakeServiceServer) GetAllSongs(ctx context.Context, req *proto.GetA
 
 func (s *DatalakeServiceServer) GetSongsByIDs(ctx context.Context, req *proto.GetSongsByIDsRequest) (*proto.GetSongsByIDsResponse, error) {
 	var songs []*repository.File
-	var nextToken int64
+	var nextToken string
 	var totalSize int64
 	var err error
 
-	// TODO refactor this
-	if req.PageToken == nil {
-		req.PageToken = new(int64)
-	}
-	if req.PageSize == nil {
-		req.PageSize = new(int64)
+	opts, err := listOptions(req.PageToken, req.GetPageSize(), req.Sort, req.GetReadMask().GetPaths())
+	if err != nil {
+		return nil, err
 	}
 
-	songs, nextToken, totalSize, err = s.repo.GetSongsByIDs(ctx, req.Ids, *req.PageToken, *req.PageSize)
+	songs, nextToken, totalSize, err = s.repo.GetSongsByIDs(ctx, req.Ids, opts)
 
 	if err != nil {
 		s.logger.Errorf("Failed to get songs: %v", err)
-		return nil, err
+		return nil, statusError(err)
 	}
 
 	res := &proto.GetSongsByIDsResponse{
@@ -124,23 +185,35 @@ func (s *DatalakeServiceServer) GetSongsByIDs(ctx context.Context, req *proto.Ge
 func (s *DatalakeServiceServer) GetSongsByTags(ctx context.Context, req *proto.GetSongsByTagsRequest) (*proto.GetSongsByTagsResponse, error) {
 
 	var songs []*repository.File
-	var nextToken int64
+	var nextToken string
 	var totalSize int64
 	var err error
 
-	// TODO refactor this
-	if req.PageToken == nil {
-		req.PageToken = new(int64)
+	matches := repository.ExactMatches(req.Tags)
+	for tagName, match := range req.Matches {
+		if _, ok := matches[tagName]; ok {
+			return nil, status.Errorf(codes.InvalidArgument, "tag %v is in both tags and matches", tagName)
+		}
+		matches[tagName] = &repository.TagMatch{
+			Mode:   match.Mode,
+			Value:  match.Value,
+			Values: match.Values,
+		}
+		if err := repository.ValidateTagMatch(matches[tagName]); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid match for tag %v: %v", tagName, err)
+		}
 	}
-	if req.PageSize == nil {
-		req.PageSize = new(int64)
+
+	opts, err := listOptions(req.PageToken, req.GetPageSize(), req.Sort, req.GetReadMask().GetPaths())
+	if err != nil {
+		return nil, err
 	}
 
-	songs, nextToken, totalSize, err = s.repo.GetSongsByTags(ctx, req.Tags, req.Filter, *req.PageToken, *req.PageSize)
+	songs, nextToken, totalSize, err = s.repo.GetSongsByTags(ctx, matches, req.Filter, opts)
 
 	if err != nil {
 		s.logger.Errorf("Failed to get songs: %v", err)
-		return nil, err
+		return nil, statusError(err)
 	}
 
 	res := &proto.GetSongsByTagsResponse{
@@ -151,57 +224,623 @@ func (s *DatalakeServiceServer) GetSongsByTags(ctx context.Context, req *proto.G
 	return res, nil
 }
 
+func (s *DatalakeServiceServer) StreamSongs(req *proto.StreamSongsRequest, stream proto.DatalakeService_StreamSongsServer) error {
+
+	err := s.repo.StreamSongsByTags(stream.Context(), req.Tags, req.Filter, req.ResumeToken, func(song *repository.File, resumeToken string) error {
+		return stream.Send(&proto.StreamSongsResponse{
+			Song:        s.RepoFileToProtoFile(song),
+			ResumeToken: resumeToken,
+		})
+	})
+
+	if err != nil {
+		s.logger.Errorf("Failed to stream songs: %v", err)
+		return statusError(err)
+	}
+
+	return nil
+}
+
+func (s *DatalakeServiceServer) QuerySongs(ctx context.Context, req *proto.QuerySongsRequest) (*proto.QuerySongsResponse, error) {
+
+	q, err := query.Parse(req.Query)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	opts, err := listOptions(req.PageToken, req.GetPageSize(), req.Sort, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	songs, nextToken, totalSize, err := s.repo.QuerySongs(ctx, q, opts)
+
+	if err != nil {
+		s.logger.Errorf("Failed to query songs: %v", err)
+		return nil, statusError(err)
+	}
+
+	res := &proto.QuerySongsResponse{
+		Songs:         s.RepoFilesToProtoFiles(songs),
+		NextPageToken: nextToken,
+		TotalSize:     totalSize,
+	}
+	return res, nil
+}
+
+func (s *DatalakeServiceServer) SearchSongs(ctx context.Context, req *proto.SearchSongsRequest) (*proto.SearchSongsResponse, error) {
+
+	if strings.TrimSpace(req.Text) == "" {
+		return nil, status.Error(codes.InvalidArgument, "text is required")
+	}
+	if len(req.Text) > maxSearchLength {
+		return nil, status.Errorf(codes.InvalidArgument, "text is longer than %v characters", maxSearchLength)
+	}
+
+	results, nextToken, totalSize, err := s.repo.SearchSongs(ctx, req.Text, req.PageToken, req.GetPageSize())
+
+	if err != nil {
+		s.logger.Errorf("Failed to search songs: %v", err)
+		return nil, statusError(err)
+	}
+
+	res := &proto.SearchSongsResponse{
+		Results:       make([]*proto.SearchResult, len(results)),
+		NextPageToken: nextToken,
+		TotalSize:     totalSize,
+	}
+	for i, result := range results {
+		res.Results[i] = &proto.SearchResult{
+			Song:  s.RepoFileToProtoFile(result.Song),
+			Score: result.Score,
+		}
+	}
+	return res, nil
+}
+
+func (s *DatalakeServiceServer) GetTagFacets(ctx context.Context, req *proto.GetTagFacetsRequest) (*proto.GetTagFacetsResponse, error) {
+
+	if err := repository.ValidateFacets(req.Keys, req.Limit); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	facets, err := s.repo.GetTagFacets(ctx, req.Keys, req.Tags, req.Filter, req.Limit)
+
+	if err != nil {
+		s.logger.Errorf("Failed to get tag facets: %v", err)
+		return nil, statusError(err)
+	}
+
+	res := &proto.GetTagFacetsResponse{
+		Facets: make([]*proto.TagFacet, len(facets)),
+	}
+	for i, facet := range facets {
+		res.Facets[i] = &proto.TagFacet{
+			Key:          facet.Key,
+			Values:       make([]*proto.FacetValue, 0, len(facet.Values)),
+			MissingCount: facet.MissingCount,
+			OtherCount:   facet.OtherCount,
+		}
+		for _, val := range facet.Values {
+			res.Facets[i].Values = append(res.Facets[i].Values, &proto.FacetValue{
+				Value: RepoTagValueToProtoTagValue(val.Value),
+				Count: val.Count,
+			})
+		}
+	}
+	return res, nil
+}
+
+func (s *DatalakeServiceServer) ListTagKeys(ctx context.Context, req *proto.ListTagKeysRequest) (*proto.ListTagKeysResponse, error) {
+
+	keys, nextToken, err := s.repo.ListTagKeys(ctx, req.PageToken, req.GetPageSize())
+
+	if err != nil {
+		s.logger.Errorf("Failed to list tag keys: %v", err)
+		return nil, statusError(err)
+	}
+
+	res := &proto.ListTagKeysResponse{
+		Keys:          make([]*proto.TagKeyCount, len(keys)),
+		NextPageToken: nextToken,
+	}
+	for i, key := range keys {
+		res.Keys[i] = &proto.TagKeyCount{Key: key.Key, Count: key.Count}
+	}
+	return res, nil
+}
+
+func (s *DatalakeServiceServer) ListTagValues(ctx context.Context, req *proto.ListTagValuesRequest) (*proto.ListTagValuesResponse, error) {
+
+	if err := repository.ValidateTagKey(req.Key); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	values, nextToken, err := s.repo.ListTagValues(ctx, req.Key, req.PageToken, req.GetPageSize())
+
+	if err != nil {
+		s.logger.Errorf("Failed to list tag values: %v", err)
+		return nil, statusError(err)
+	}
+
+	res := &proto.ListTagValuesResponse{
+		Values:        make([]*proto.FacetValue, len(values)),
+		NextPageToken: nextToken,
+	}
+	for i, val := range values {
+		res.Values[i] = &proto.FacetValue{
+			Value: RepoTagValueToProtoTagValue(val.Value),
+			Count: val.Count,
+		}
+	}
+	return res, nil
+}
+
 func (s *DatalakeServiceServer) AddSongs(ctx context.Context, req *proto.AddSongsRequest) (*proto.AddSongsResponse, error) {
 
-	songs := s.ProtoAddFilesToRepoFiles(req.Songs)
+	res, err := s.idempotent(ctx, "AddSongs", req, &proto.AddSongsResponse{}, func() (gproto.Message, error) {
+		return s.addSongs(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*proto.AddSongsResponse), nil
+}
+
+func (s *DatalakeServiceServer) addSongs(ctx context.Context, req *proto.AddSongsRequest) (*proto.AddSongsResponse, error) {
 
-	err := s.repo.AddSongs(ctx, songs)
+	songs, err := s.ProtoAddFilesToRepoFiles(req.Songs)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	schemas, err := s.tagSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var violations fieldViolations
+	for i, song := range songs {
+		violations.add(fmt.Sprintf("songs[%v].", i), schemas.ValidateTags(song.Tags, song.TypedTags, true))
+	}
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	addSongs := s.repo.AddSongs
+	if req.Upsert {
+		addSongs = s.repo.UpsertSongs
+	}
+	results, err := addSongs(ctx, songs)
 
 	if err != nil {
 		s.logger.Errorf("Failed to add songs: %v", err)
-		res := &proto.AddSongsResponse{
-			Successful: false,
-		}
-		return res, err
+		return nil, statusError(err)
 	}
 
+	// A partial failure is reported in the response rather than as an error
+	// so the caller still gets the IDs of the songs that were added
 	res := &proto.AddSongsResponse{
 		Successful: true,
+		Ids:        make([]string, len(results)),
+		Failures:   make([]*proto.AddSongsFailure, 0),
+	}
+	for i, result := range results {
+		res.Ids[i] = result.ID
+		if result.Err != nil {
+			res.Successful = false
+			res.Failures = append(res.Failures, &proto.AddSongsFailure{
+				Index: int64(i),
+				Error: result.Err.Error(),
+				Code:  failureCode(result.Err),
+			})
+		}
+	}
+
+	if !res.Successful {
+		s.logger.Warnf("Failed to add %v of %v songs", len(res.Failures), len(songs))
 	}
+
 	return res, nil
 }
 
+// failureCode is the code of a song that failed to be written, OK when err
+// is nil. The codes of google.rpc match the gRPC ones.
+func failureCode(err error) code.Code {
+	if errors.Is(err, repository.ErrSongSkipped) {
+		return code.Code_ABORTED
+	}
+	return code.Code(status.Code(statusError(err)))
+}
+
+func (s *DatalakeServiceServer) IngestSongs(stream proto.DatalakeService_IngestSongsServer) error {
+
+	schemas, err := s.tagSchemas(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	res := &proto.IngestSongsResponse{
+		Results: make([]*proto.IngestResult, 0),
+	}
+	// Songs that don't follow the tag schemas are reported as failed
+	// without being written
+	type ingestItem struct {
+		song *repository.File
+		err  error
+	}
+	batch := make([]ingestItem, 0, ingestBatchSize)
+
+	flush := func(items []ingestItem) error {
+		songs := make([]*repository.File, 0, len(items))
+		for _, item := range items {
+			if item.err == nil {
+				songs = append(songs, item.song)
+			}
+		}
+
+		results, err := s.repo.IngestSongs(stream.Context(), songs)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			result := &repository.SongResult{Err: item.err}
+			resultCode := code.Code_INVALID_ARGUMENT
+			if item.err == nil {
+				result, results = results[0], results[1:]
+				resultCode = failureCode(result.Err)
+			}
+
+			ingestResult := &proto.IngestResult{
+				Index: int64(len(res.Results)),
+				Id:    result.ID,
+				Code:  resultCode,
+			}
+			if result.Err != nil {
+				ingestResult.Error = result.Err.Error()
+				res.FailedCount++
+			} else {
+				res.InsertedCount++
+			}
+			res.Results = append(res.Results, ingestResult)
+		}
+		return nil
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			s.logger.Errorf("Failed to receive songs: %v", err)
+			return statusError(err)
+		}
+
+		songs, err := s.ProtoAddFilesToRepoFiles(req.Songs)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		for _, song := range songs {
+			item := ingestItem{song: song}
+			if violations := schemas.ValidateTags(song.Tags, song.TypedTags, true); len(violations) > 0 {
+				item.err = fmt.Errorf("%v: %v", violations[0].Field, violations[0].Description)
+			}
+			batch = append(batch, item)
+		}
+		for len(batch) >= ingestBatchSize {
+			if err := flush(batch[:ingestBatchSize]); err != nil {
+				s.logger.Errorf("Failed to ingest songs: %v", err)
+				return statusError(err)
+			}
+			batch = batch[ingestBatchSize:]
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := flush(batch); err != nil {
+			s.logger.Errorf("Failed to ingest songs: %v", err)
+			return statusError(err)
+		}
+	}
+
+	s.logger.Infof("Ingested %v songs, %v failed", res.InsertedCount, res.FailedCount)
+
+	return stream.SendAndClose(res)
+}
+
 func (s *DatalakeServiceServer) AddTags(ctx context.Context, req *proto.AddTagsRequest) (*proto.AddTagsResponse, error) {
 
-	err := s.repo.AddTags(ctx, req.Id, req.Tags)
+	res, err := s.idempotent(ctx, "AddTags", req, &proto.AddTagsResponse{}, func() (gproto.Message, error) {
+		return s.addTags(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*proto.AddTagsResponse), nil
+}
+
+func (s *DatalakeServiceServer) addTags(ctx context.Context, req *proto.AddTagsRequest) (*proto.AddTagsResponse, error) {
+
+	tags, typedTags, err := ProtoTagsToRepoTags(req.Tags, req.TypedTags)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	schemas, err := s.tagSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var violations fieldViolations
+	violations.add("", schemas.ValidateTags(tags, typedTags, false))
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	revision, err := s.repo.AddTags(ctx, req.Id, tags, typedTags, req.ExpectedRevision)
 
 	if err != nil {
 		s.logger.Errorf("Failed to add tags: %v", err)
-		res := &proto.AddTagsResponse{
-			Successful: false,
-		}
-		return res, err
+		return nil, statusError(err)
 	}
 
 	res := &proto.AddTagsResponse{
 		Successful: true,
+		Revision:   revision,
 	}
 	return res, nil
 }
 
 func (s *DatalakeServiceServer) RemoveTags(ctx context.Context, req *proto.RemoveTagsRequest) (*proto.RemoveTagsResponse, error) {
-	err := s.repo.RemoveTags(ctx, req.Id, req.Tags)
+
+	res, err := s.idempotent(ctx, "RemoveTags", req, &proto.RemoveTagsResponse{}, func() (gproto.Message, error) {
+		return s.removeTags(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*proto.RemoveTagsResponse), nil
+}
+
+func (s *DatalakeServiceServer) removeTags(ctx context.Context, req *proto.RemoveTagsRequest) (*proto.RemoveTagsResponse, error) {
+	if err := repository.ValidateTagKeys(req.Tags, nil); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	schemas, err := s.tagSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(req.Tags))
+	for key := range req.Tags {
+		keys = append(keys, key)
+	}
+	var violations fieldViolations
+	violations.add("", schemas.ValidateRemoval(keys))
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	revision, err := s.repo.RemoveTags(ctx, req.Id, req.Tags, req.ExpectedRevision)
 
 	if err != nil {
 		s.logger.Errorf("Failed to remove tags: %v", err)
-		res := &proto.RemoveTagsResponse{
-			Successful: false,
-		}
-		return res, err
+		return nil, statusError(err)
 	}
 
 	res := &proto.RemoveTagsResponse{
 		Successful: true,
+		Revision:   revision,
 	}
 	return res, nil
 }
+
+func (s *DatalakeServiceServer) UpdateSongs(ctx context.Context, req *proto.UpdateSongsRequest) (*proto.UpdateSongsResponse, error) {
+
+	paths := req.GetUpdateMask().GetPaths()
+	if err := repository.ValidateUpdatePaths(paths); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid update_mask: %v", err)
+	}
+
+	// Check every song before updating any of them
+	seen := make(map[string]bool, len(req.Songs))
+	for i, song := range req.Songs {
+		if song.Id == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "songs[%v] has no id", i)
+		}
+		if seen[song.Id] {
+			return nil, status.Errorf(codes.InvalidArgument, "songs[%v] has a duplicate id: %v", i, song.Id)
+		}
+		seen[song.Id] = true
+	}
+
+	songs, err := s.ProtoFilesToRepoFiles(req.Songs)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	schemas, err := s.tagSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var violations fieldViolations
+	for i, song := range songs {
+		violations.add(fmt.Sprintf("songs[%v].", i), updateTagViolations(schemas, song, paths))
+	}
+	if err := violations.err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkSongs(ctx, songs); err != nil {
+		return nil, err
+	}
+
+	res := &proto.UpdateSongsResponse{
+		Songs: make([]*proto.File, len(req.Songs)),
+	}
+	for i, song := range songs {
+		updated, err := s.repo.UpdateSong(ctx, song, paths)
+
+		if errors.Is(err, repository.ErrSongNotFound) {
+			return nil, statusError(fmt.Errorf("%w: %v", err, song.ID))
+		} else if err != nil {
+			s.logger.Errorf("Failed to update song %v: %v", song.ID, err)
+			return nil, statusError(err)
+		}
+
+		res.Songs[i] = s.RepoFileToProtoFile(updated)
+	}
+
+	return res, nil
+}
+
+// checkSongs fails like UpdateSong would for songs that are missing or have
+// another revision than expected, so that a request failing on one of its
+// songs usually doesn't update the songs before it.
+func (s *DatalakeServiceServer) checkSongs(ctx context.Context, songs []*repository.File) error {
+	ids := make([]string, len(songs))
+	for i, song := range songs {
+		ids[i] = song.ID
+	}
+
+	revisions := make(map[string]int64, len(songs))
+	opts := repository.ListOptions{Fields: []string{"id", "revision"}}
+	for {
+		found, nextToken, _, err := s.repo.GetSongsByIDs(ctx, ids, opts)
+		if err != nil {
+			s.logger.Errorf("Failed to get songs: %v", err)
+			return statusError(err)
+		}
+		for _, song := range found {
+			revisions[song.ID] = song.Revision
+		}
+		if nextToken == "" {
+			break
+		}
+		opts.PageToken = nextToken
+	}
+
+	for _, song := range songs {
+		revision, ok := revisions[strings.ToLower(song.ID)]
+		if !ok {
+			return statusError(fmt.Errorf("%w: %v", repository.ErrSongNotFound, song.ID))
+		}
+		if song.Revision != 0 && song.Revision != revision {
+			return revisionMismatchStatus(&repository.RevisionMismatchError{ID: song.ID, Expected: song.Revision, Current: revision})
+		}
+	}
+	return nil
+}
+
+func (s *DatalakeServiceServer) WatchSongs(req *proto.WatchSongsRequest, stream proto.DatalakeService_WatchSongsServer) error {
+
+	ctx := stream.Context()
+
+	watcher, err := s.repo.WatchSongs(ctx, req.ResumeToken)
+	if err != nil {
+		s.logger.Errorf("Failed to watch songs: %v", err)
+		return statusError(err)
+	}
+	defer watcher.Close(context.Background())
+
+	for {
+		event, err := watcher.Next(ctx)
+		if ctx.Err() != nil {
+			// The client went away
+			return nil
+		}
+		if err != nil {
+			s.logger.Errorf("Failed to watch songs: %v", err)
+			return statusError(err)
+		}
+
+		res := &proto.SongEvent{
+			Type:        songEventTypes[event.Type],
+			Id:          event.ID,
+			ResumeToken: event.ResumeToken,
+		}
+		if event.Song != nil {
+			res.Song = s.RepoFileToProtoFile(event.Song)
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *DatalakeServiceServer) DeleteSongs(ctx context.Context, req *proto.DeleteSongsRequest) (*proto.DeleteSongsResponse, error) {
+
+	var deleted int64
+	var err error
+
+	switch {
+	case len(req.Ids) > 0 && len(req.Tags) > 0:
+		return nil, status.Error(codes.InvalidArgument, "ids and tags can't be used together")
+	case len(req.Ids) > 0:
+		deleted, err = s.repo.DeleteSongsByIDs(ctx, req.Ids)
+	case len(req.Tags) > 0:
+		deleted, err = s.repo.DeleteSongsByTags(ctx, req.Tags, req.Filter)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "ids or tags are required")
+	}
+
+	if err != nil {
+		s.logger.Errorf("Failed to delete songs: %v", err)
+		return nil, statusError(err)
+	}
+
+	res := &proto.DeleteSongsResponse{
+		DeletedCount: deleted,
+	}
+	return res, nil
+}
+
+func (s *DatalakeServiceServer) PurgeDeletedSongs(ctx context.Context, req *proto.PurgeDeletedSongsRequest) (*proto.PurgeDeletedSongsResponse, error) {
+
+	retention := defaultRetention
+	if req.Retention != nil {
+		if err := req.Retention.CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid retention: %v", err)
+		}
+		retention = req.Retention.AsDuration()
+	}
+	if retention < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "retention must be non-negative: %v", retention)
+	}
+
+	purged, err := s.repo.PurgeDeletedSongs(ctx, time.Now().Add(-retention))
+
+	if err != nil {
+		s.logger.Errorf("Failed to purge songs: %v", err)
+		return nil, statusError(err)
+	}
+
+	res := &proto.PurgeDeletedSongsResponse{
+		PurgedCount: purged,
+	}
+	return res, nil
+}
+
+// updateTagViolations checks the tags an update with paths would write.
+func updateTagViolations(schemas *repository.TagSchemas, song *repository.File, paths []string) []*repository.TagViolation {
+	violations := make([]*repository.TagViolation, 0)
+	for _, path := range paths {
+		if path == repository.TagsPath {
+			violations = append(violations, schemas.ValidateTags(song.Tags, song.TypedTags, true)...)
+			continue
+		}
+		if !strings.HasPrefix(path, repository.TagsPrefix) {
+			continue
+		}
+
+		key := strings.TrimPrefix(path, repository.TagsPrefix)
+		if val, ok := song.Tags[key]; ok {
+			violations = append(violations, schemas.ValidateTags(map[string]string{key: val}, nil, false)...)
+		} else if val, ok := song.TypedTags[key]; ok {
+			violations = append(violations, schemas.ValidateTags(nil, map[string]interface{}{key: val}, false)...)
+		} else {
+			violations = append(violations, schemas.ValidateRemoval([]string{key})...)
+		}
+	}
+	return violations
+}
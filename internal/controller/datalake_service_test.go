@@ -2,7 +2,11 @@ package controller_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,8 +14,17 @@ import (
 	"github.com/TensorBeat/Datalake/internal/repository"
 	"github.com/TensorBeat/Datalake/internal/util"
 	"github.com/TensorBeat/Datalake/pkg/proto"
+	"github.com/benweissmann/memongo"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap/zaptest"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -35,21 +48,32 @@ func TestMain(m *testing.M) {
 
 	ctx = context.Background()
 
+	// Without a MONGO_URI the tests run against the in-memory backend
+	if MongoURI == "" {
+		logger.Warnf("No MONGO_URI set, using the in-memory backend")
+		datalakeService = controller.NewDatalakeServiceServer(repository.NewMemoryRepository(logger), logger)
+		os.Exit(m.Run())
+	}
+
 	mongoCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	mongoClient, err := mongo.Connect(mongoCtx, options.Client().ApplyURI(MongoURI))
 	if err != nil {
 		logger.Fatalf("Couldn't connect to mongo: %v", err)
 	}
-	defer mongoClient.Disconnect(ctx)
 
 	err = mongoClient.Ping(ctx, readpref.Primary())
 	if err != nil {
 		logger.Fatalf("Couldn't ping mongo: %v", err)
 	}
 
-	dbName := "test"
+	// Each run gets its own database so songs left by an earlier run can't
+	// break the unique URI index
+	dbName := memongo.RandomDatabase()
 	repository := repository.NewMongoRepository(mongoClient, logger, dbName)
+	if _, err := repository.SyncIndexes(ctx); err != nil {
+		logger.Fatalf("Couldn't sync indexes: %v", err)
+	}
 	datalakeService = controller.NewDatalakeServiceServer(repository, logger)
 
 	// TODO: Example to seed data - should be a unit-test at somepoint
@@ -66,7 +90,12 @@ func TestMain(m *testing.M) {
 
 	// TODO: Example get data - should be a unit-test at somepoint
 
-	os.Exit(m.Run())
+	code := m.Run()
+	if err := mongoClient.Database(dbName).Drop(ctx); err != nil {
+		logger.Warnf("Couldn't drop %v: %v", dbName, err)
+	}
+	mongoClient.Disconnect(ctx)
+	os.Exit(code)
 
 }
 
@@ -93,6 +122,24 @@ func TestGetSongsByTags(t *testing.T) {
 	res, _ := datalakeService.GetSongsByTags(ctx, req)
 	logger.Infof("%v", res)
 
+	req.Matches = map[string]*proto.TagMatch{
+		"mood": {Mode: proto.MatchMode_REGEX, Value: "^(happy|sad)"},
+	}
+	if _, err := datalakeService.GetSongsByTags(ctx, req); err != nil {
+		t.Errorf("GetSongsByTags with matches: %v", err)
+	}
+
+	req.Matches["mood"].Value = "(a*)*b"
+	_, err := datalakeService.GetSongsByTags(ctx, req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetSongsByTags with a nested repetition = %v, want InvalidArgument", err)
+	}
+
+	req.Matches = map[string]*proto.TagMatch{"genre": {Mode: proto.MatchMode_PREFIX, Value: "Hip"}}
+	_, err = datalakeService.GetSongsByTags(ctx, req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetSongsByTags with a tag in both maps = %v, want InvalidArgument", err)
+	}
 }
 
 func TestAddTags(t *testing.T) {
@@ -147,4 +194,1099 @@ func TestAddSongs(t *testing.T) {
 	res, _ := datalakeService.AddSongs(ctx, req)
 	logger.Infof("%v", res)
 
+	if !res.Successful || len(res.Ids) != 1 || res.Ids[0] == "" {
+		t.Errorf("unexpected response: %v", res)
+	}
+
+}
+
+type ingestSongsStream struct {
+	grpc.ServerStream
+	requests []*proto.IngestSongsRequest
+	response *proto.IngestSongsResponse
+}
+
+func (s *ingestSongsStream) Context() context.Context {
+	return ctx
+}
+
+func (s *ingestSongsStream) Recv() (*proto.IngestSongsRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *ingestSongsStream) SendAndClose(res *proto.IngestSongsResponse) error {
+	s.response = res
+	return nil
+}
+
+func TestIngestSongs(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	stream := &ingestSongsStream{
+		requests: []*proto.IngestSongsRequest{
+			{
+				Songs: []*proto.AddFile{
+					{Name: "Ingested Song 1", Uri: "gs://test-tensorbeat-songs/ingest1.mp3"},
+					{Name: "Ingested Song 2", Uri: "gs://test-tensorbeat-songs/ingest2.mp3"},
+				},
+			},
+			{
+				Songs: []*proto.AddFile{
+					{Name: "Ingested Song 3", Uri: "gs://test-tensorbeat-songs/ingest3.mp3"},
+				},
+			},
+		},
+	}
+
+	if err := datalakeService.IngestSongs(stream); err != nil {
+		t.Fatalf("IngestSongs: %v", err)
+	}
+	logger.Infof("%v", stream.response)
+
+	if len(stream.response.Results) != 3 || stream.response.InsertedCount != 3 {
+		t.Errorf("unexpected response: %v", stream.response)
+	}
+	for i, result := range stream.response.Results {
+		if result.Index != int64(i) || result.Id == "" {
+			t.Errorf("unexpected result %v: %v", i, result)
+		}
+	}
+}
+
+func TestDeleteSongs(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	added, _ := datalakeService.AddSongs(ctx, &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{
+			{Name: "Doomed Song", Uri: "gs://test-tensorbeat-songs/doomed.mp3"},
+		},
+	})
+
+	res, err := datalakeService.DeleteSongs(ctx, &proto.DeleteSongsRequest{Ids: added.Ids})
+	logger.Infof("%v", res)
+
+	if err != nil || res.DeletedCount != 1 {
+		t.Errorf("DeleteSongs = %v, %v", res, err)
+	}
+
+	invalid := []*proto.DeleteSongsRequest{
+		{},
+		{Ids: added.Ids, Tags: map[string]string{"genre": "rock"}},
+	}
+	for _, req := range invalid {
+		if _, err := datalakeService.DeleteSongs(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("DeleteSongs(%v) = %v, want InvalidArgument", req, err)
+		}
+	}
+}
+
+func TestUpdateSongs(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	added, _ := datalakeService.AddSongs(ctx, &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{
+			{Name: "Misnamed Song", Uri: "gs://test-tensorbeat-songs/misnamed.mp3", MimeType: "audio/mpeg"},
+		},
+	})
+
+	req := &proto.UpdateSongsRequest{
+		Songs: []*proto.File{
+			{Id: added.Ids[0], Name: "Renamed Song", MimeType: "ignored"},
+		},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+	}
+
+	res, err := datalakeService.UpdateSongs(ctx, req)
+	logger.Infof("%v", res)
+
+	if err != nil || len(res.Songs) != 1 || res.Songs[0].Name != "Renamed Song" || res.Songs[0].MimeType != "audio/mpeg" {
+		t.Errorf("UpdateSongs = %v, %v", res, err)
+	}
+
+	req.UpdateMask.Paths = []string{"id"}
+	if _, err := datalakeService.UpdateSongs(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("UpdateSongs with a bad mask = %v, want InvalidArgument", err)
+	}
+
+	req.UpdateMask.Paths = []string{"name"}
+	req.Songs[0].Id = "602b29014accf1b3f3d462d0"
+	if _, err := datalakeService.UpdateSongs(ctx, req); status.Code(err) != codes.NotFound {
+		t.Errorf("UpdateSongs of a missing song = %v, want NotFound", err)
+	}
+
+	// A missing song fails the request before the songs before it change
+	req.Songs = []*proto.File{
+		{Id: added.Ids[0], Name: "Partly Renamed Song"},
+		{Id: "602b29014accf1b3f3d462d0", Name: "Missing Song"},
+	}
+	if _, err := datalakeService.UpdateSongs(ctx, req); status.Code(err) != codes.NotFound {
+		t.Errorf("UpdateSongs with a missing song = %v, want NotFound", err)
+	}
+	got, err := datalakeService.GetSongsByIDs(ctx, &proto.GetSongsByIDsRequest{Ids: added.Ids[:1]})
+	if err != nil || len(got.Songs) != 1 || got.Songs[0].Name != "Renamed Song" {
+		t.Errorf("GetSongsByIDs = %v, %v, want the song unchanged", got, err)
+	}
+
+	req.Songs = []*proto.File{{Id: added.Ids[0], Tags: map[string]string{"a.b": "c"}}}
+	req.UpdateMask.Paths = []string{"tags.a.b"}
+	if _, err := datalakeService.UpdateSongs(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("UpdateSongs of a dotted tag key = %v, want InvalidArgument", err)
+	}
+}
+
+func TestQuerySongs(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	req := &proto.QuerySongsRequest{
+		Query: "genre=rock AND (mood=happy OR mood=energetic) AND NOT explicit=*",
+	}
+
+	res, err := datalakeService.QuerySongs(ctx, req)
+	logger.Infof("%v", res)
+
+	if err != nil {
+		t.Errorf("QuerySongs: %v", err)
+	}
+
+	req.Query = "genre=rock AND (mood=happy"
+	_, err = datalakeService.QuerySongs(ctx, req)
+	logger.Infof("%v", err)
+
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("QuerySongs with a bad query = %v, want InvalidArgument", err)
+	}
+}
+
+func TestTypedTags(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	released := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	req := &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{
+			{
+				Name: "Typed Song",
+				Tags: map[string]string{"genre": "typed"},
+				TypedTags: map[string]*proto.TagValue{
+					"bpm":      {Value: &proto.TagValue_IntValue{IntValue: 128}},
+					"live":     {Value: &proto.TagValue_BoolValue{BoolValue: true}},
+					"released": {Value: &proto.TagValue_TimestampValue{TimestampValue: &timestamp.Timestamp{Seconds: released.Unix()}}},
+					"mood":     {Value: &proto.TagValue_StringValue{StringValue: "calm"}},
+				},
+			},
+		},
+	}
+
+	res, err := datalakeService.AddSongs(ctx, req)
+	logger.Infof("%v", res)
+
+	if err != nil || !res.Successful {
+		t.Fatalf("AddSongs = %v, %v", res, err)
+	}
+
+	songs, err := datalakeService.QuerySongs(ctx, &proto.QuerySongsRequest{
+		Query: "genre=typed AND bpm BETWEEN 120 AND 130 AND released<2021-01-01T00:00:00Z",
+	})
+	if err != nil {
+		t.Fatalf("QuerySongs: %v", err)
+	}
+	if len(songs.Songs) != 1 {
+		t.Fatalf("got %v songs, want 1", len(songs.Songs))
+	}
+
+	song := songs.Songs[0]
+	if song.Tags["mood"] != "calm" || song.TypedTags["bpm"].GetIntValue() != 128 || !song.TypedTags["live"].GetBoolValue() ||
+		song.TypedTags["released"].GetTimestampValue().GetSeconds() != released.Unix() {
+		t.Errorf("unexpected song: %v", song)
+	}
+
+	req.Songs[0].Tags["bpm"] = "128"
+	_, err = datalakeService.AddSongs(ctx, req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("AddSongs with a tag in both maps = %v, want InvalidArgument", err)
+	}
+
+	_, err = datalakeService.AddTags(ctx, &proto.AddTagsRequest{
+		Id:        res.Ids[0],
+		TypedTags: map[string]*proto.TagValue{"bpm": {}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("AddTags with an empty value = %v, want InvalidArgument", err)
+	}
+
+	_, err = datalakeService.AddSongs(ctx, &proto.AddSongsRequest{Songs: []*proto.AddFile{{
+		Name:      "Dotted Song",
+		TypedTags: map[string]*proto.TagValue{"bpm.max": {Value: &proto.TagValue_IntValue{IntValue: 128}}},
+	}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("AddSongs with a dotted key = %v, want InvalidArgument", err)
+	}
+	_, err = datalakeService.RemoveTags(ctx, &proto.RemoveTagsRequest{Id: res.Ids[0], Tags: map[string]string{"$bpm": ""}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("RemoveTags of a key starting with $ = %v, want InvalidArgument", err)
+	}
+}
+
+func TestRepoTagsToProtoTags(t *testing.T) {
+
+	far := time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)
+	tags := controller.RepoTagsToProtoTags(map[string]interface{}{
+		"count": int32(3),
+		"list":  []string{"a", "b"},
+		"far":   far,
+	})
+
+	if got := tags["count"].GetIntValue(); got != 3 {
+		t.Errorf("count = %v, want 3", tags["count"])
+	}
+	if got := tags["list"].GetStringValue(); got != "[a b]" {
+		t.Errorf("list = %v, want its string form", tags["list"])
+	}
+	if got := tags["far"].GetStringValue(); got != far.Format(time.RFC3339Nano) {
+		t.Errorf("far = %v, want its string form", tags["far"])
+	}
+}
+
+func TestTagSchemas(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+	// Required tags would break the other tests sharing datalakeService
+	service := controller.NewDatalakeServiceServer(repository.NewMemoryRepository(logger), logger)
+
+	schemas := []*proto.TagSchema{
+		{Key: "genre", AllowedValues: []string{"rock", "pop"}, Required: true},
+		{Key: "bpm", Type: proto.TagType_INT},
+	}
+	for _, schema := range schemas {
+		if _, err := service.PutTagSchema(ctx, &proto.PutTagSchemaRequest{Schema: schema}); err != nil {
+			t.Fatalf("PutTagSchema: %v", err)
+		}
+	}
+	_, err := service.PutTagSchema(ctx, &proto.PutTagSchemaRequest{Schema: &proto.TagSchema{Key: "GENRE"}})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("PutTagSchema with a key in another case = %v, want AlreadyExists", err)
+	}
+
+	req := &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{
+			{Name: "Valid Song", Tags: map[string]string{"genre": "rock"}},
+			{Name: "Invalid Song", Tags: map[string]string{"Genre": "rock", "bpm": "fast"}},
+		},
+	}
+	_, err = service.AddSongs(ctx, req)
+	logger.Infof("%v", err)
+
+	want := map[string]bool{
+		"songs[1].tags.Genre": true,
+		"songs[1].tags.bpm":   true,
+		"songs[1].tags.genre": true,
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("AddSongs with invalid tags = %v, want InvalidArgument", err)
+	}
+	details := status.Convert(err).Details()
+	if len(details) != 1 {
+		t.Fatalf("got details %v, want a BadRequest", details)
+	}
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	if !ok || len(badRequest.FieldViolations) != len(want) {
+		t.Fatalf("got details %v, want %v field violations", details, len(want))
+	}
+	for _, violation := range badRequest.FieldViolations {
+		if !want[violation.Field] {
+			t.Errorf("unexpected field violation %v", violation)
+		}
+	}
+
+	req.Songs = req.Songs[:1]
+	res, err := service.AddSongs(ctx, req)
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	_, err = service.AddTags(ctx, &proto.AddTagsRequest{Id: res.Ids[0], Tags: map[string]string{"genre": "metal"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("AddTags with a value that isn't allowed = %v, want InvalidArgument", err)
+	}
+	_, err = service.AddTags(ctx, &proto.AddTagsRequest{
+		Id:        res.Ids[0],
+		TypedTags: map[string]*proto.TagValue{"bpm": {Value: &proto.TagValue_IntValue{IntValue: 120}}},
+	})
+	if err != nil {
+		t.Errorf("AddTags: %v", err)
+	}
+	_, err = service.RemoveTags(ctx, &proto.RemoveTagsRequest{Id: res.Ids[0], Tags: map[string]string{"genre": ""}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("RemoveTags of a required tag = %v, want InvalidArgument", err)
+	}
+}
+
+func TestSearchSongs(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	_, err := datalakeService.AddSongs(ctx, &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{{Name: "Searchable Song"}},
+	})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	res, err := datalakeService.SearchSongs(ctx, &proto.SearchSongsRequest{Text: "searchable"})
+	logger.Infof("%v", res)
+
+	if err != nil {
+		t.Fatalf("SearchSongs: %v", err)
+	}
+	if len(res.Results) == 0 || res.Results[0].Song.Name != "Searchable Song" || res.Results[0].Score <= 0 {
+		t.Errorf("unexpected response: %v", res)
+	}
+
+	_, err = datalakeService.SearchSongs(ctx, &proto.SearchSongsRequest{Text: "  "})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SearchSongs without text = %v, want InvalidArgument", err)
+	}
+}
+
+func TestSortSongs(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	_, err := datalakeService.AddSongs(ctx, &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{
+			{Name: "Sorted B", Tags: map[string]string{"sortTest": "b"}},
+			{Name: "Sorted A", Tags: map[string]string{"sortTest": "a"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	res, err := datalakeService.GetSongsByTags(ctx, &proto.GetSongsByTagsRequest{
+		Tags: map[string]string{"sortTest": "*"},
+		Sort: &proto.SortOrder{Field: "tags.sortTest", Descending: true},
+	})
+	logger.Infof("%v", res)
+
+	if err != nil {
+		t.Fatalf("GetSongsByTags: %v", err)
+	}
+	if len(res.Songs) != 2 || res.Songs[0].Name != "Sorted B" || res.Songs[1].Name != "Sorted A" {
+		t.Errorf("unexpected order: %v", res.Songs)
+	}
+
+	_, err = datalakeService.GetAllSongs(ctx, &proto.GetAllSongsRequest{
+		Sort: &proto.SortOrder{Field: "uri"},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetAllSongs sorted by uri = %v, want InvalidArgument", err)
+	}
+}
+
+func TestReadMask(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	_, err := datalakeService.AddSongs(ctx, &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{
+			{Name: "Masked", Uri: "gs://masked", Tags: map[string]string{"maskTest": "yes", "mood": "calm"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	res, err := datalakeService.GetSongsByTags(ctx, &proto.GetSongsByTagsRequest{
+		Tags:     map[string]string{"maskTest": "yes"},
+		ReadMask: &field_mask.FieldMask{Paths: []string{"name", "tags.maskTest"}},
+	})
+	logger.Infof("%v", res)
+
+	if err != nil {
+		t.Fatalf("GetSongsByTags: %v", err)
+	}
+	if len(res.Songs) != 1 {
+		t.Fatalf("got %v songs, want 1", len(res.Songs))
+	}
+	song := res.Songs[0]
+	if song.Id == "" || song.Name != "Masked" || song.Uri != "" || len(song.Tags) != 1 || song.Tags["maskTest"] != "yes" {
+		t.Errorf("unexpected song: %v", song)
+	}
+
+	_, err = datalakeService.GetAllSongs(ctx, &proto.GetAllSongsRequest{
+		ReadMask: &field_mask.FieldMask{Paths: []string{"size"}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetAllSongs reading size = %v, want InvalidArgument", err)
+	}
+}
+
+func TestGetTagFacets(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	_, err := datalakeService.AddSongs(ctx, &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{
+			{Name: "Faceted", Tags: map[string]string{"facetTest": "yes", "facetGenre": "rock"}},
+			{Name: "Faceted", Tags: map[string]string{"facetTest": "yes"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	res, err := datalakeService.GetTagFacets(ctx, &proto.GetTagFacetsRequest{
+		Keys: []string{"facetGenre"},
+		Tags: map[string]string{"facetTest": "yes"},
+	})
+	logger.Infof("%v", res)
+
+	if err != nil {
+		t.Fatalf("GetTagFacets: %v", err)
+	}
+	if len(res.Facets) != 1 || len(res.Facets[0].Values) != 1 {
+		t.Fatalf("unexpected response: %v", res)
+	}
+	facet := res.Facets[0]
+	if facet.Values[0].Value.GetStringValue() != "rock" || facet.Values[0].Count != 1 || facet.MissingCount != 1 {
+		t.Errorf("unexpected facet: %v", facet)
+	}
+
+	_, err = datalakeService.GetTagFacets(ctx, &proto.GetTagFacetsRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetTagFacets without keys = %v, want InvalidArgument", err)
+	}
+}
+
+func TestListTags(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	_, err := datalakeService.AddSongs(ctx, &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{{Name: "Listed", Tags: map[string]string{"listTest": "yes"}}},
+	})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	keys, err := datalakeService.ListTagKeys(ctx, &proto.ListTagKeysRequest{})
+	logger.Infof("%v", keys)
+
+	if err != nil {
+		t.Fatalf("ListTagKeys: %v", err)
+	}
+	found := false
+	for _, key := range keys.Keys {
+		found = found || key.Key == "listTest" && key.Count == 1
+	}
+	if !found {
+		t.Errorf("listTest missing from %v", keys.Keys)
+	}
+
+	values, err := datalakeService.ListTagValues(ctx, &proto.ListTagValuesRequest{Key: "listTest"})
+	logger.Infof("%v", values)
+
+	if err != nil {
+		t.Fatalf("ListTagValues: %v", err)
+	}
+	if len(values.Values) != 1 || values.Values[0].Value.GetStringValue() != "yes" || values.Values[0].Count != 1 {
+		t.Errorf("unexpected values: %v", values.Values)
+	}
+
+	_, err = datalakeService.ListTagValues(ctx, &proto.ListTagValuesRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListTagValues without a key = %v, want InvalidArgument", err)
+	}
+}
+
+func TestUpsertSongs(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	uri := fmt.Sprintf("gs://test-tensorbeat-songs/upsert-%v.mp3", time.Now().UnixNano())
+	first, err := datalakeService.AddSongs(ctx, &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{{Name: "Upserted", Uri: uri, Tags: map[string]string{"genre": "rock"}}},
+	})
+	if err != nil || !first.Successful {
+		t.Fatalf("AddSongs: %v, %v", first, err)
+	}
+
+	req := &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{{Name: "Upserted again", Uri: uri, Tags: map[string]string{"mood": "happy"}}},
+	}
+	res, err := datalakeService.AddSongs(ctx, req)
+	logger.Infof("%v", res)
+
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+	if res.Successful || len(res.Failures) != 1 || res.Failures[0].Code != code.Code_ALREADY_EXISTS {
+		t.Errorf("AddSongs of a taken URI: %v", res)
+	}
+
+	req.Upsert = true
+	res, err = datalakeService.AddSongs(ctx, req)
+	logger.Infof("%v", res)
+
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+	if !res.Successful || res.Ids[0] != first.Ids[0] {
+		t.Errorf("upsert: got %v, want ID %v", res, first.Ids[0])
+	}
+
+	songs, err := datalakeService.GetSongsByIDs(ctx, &proto.GetSongsByIDsRequest{Ids: first.Ids})
+	if err != nil {
+		t.Fatalf("GetSongsByIDs: %v", err)
+	}
+	if song := songs.Songs[0]; song.Name != "Upserted" || song.Tags["genre"] != "rock" || song.Tags["mood"] != "happy" {
+		t.Errorf("unexpected song: %v", song)
+	}
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	key := fmt.Sprintf("add-songs-%v", time.Now().UnixNano())
+	req := &proto.AddSongsRequest{
+		Songs:          []*proto.AddFile{{Name: "Retried Song"}},
+		IdempotencyKey: key,
+	}
+	first, err := datalakeService.AddSongs(ctx, req)
+	logger.Infof("%v", first)
+
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	// The same key sent as metadata replays the first response
+	retryCtx := metadata.NewIncomingContext(ctx, metadata.Pairs("idempotency-key", key))
+	retry, err := datalakeService.AddSongs(retryCtx, &proto.AddSongsRequest{Songs: req.Songs})
+	logger.Infof("%v", retry)
+
+	if err != nil {
+		t.Fatalf("AddSongs retry: %v", err)
+	}
+	if len(retry.Ids) != 1 || retry.Ids[0] != first.Ids[0] {
+		t.Errorf("retry got %v, want the IDs %v of the first request", retry.Ids, first.Ids)
+	}
+
+	req.Songs = []*proto.AddFile{{Name: "Another Song"}}
+	_, err = datalakeService.AddSongs(ctx, req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("AddSongs reusing the key = %v, want InvalidArgument", err)
+	}
+
+	_, err = datalakeService.AddTags(retryCtx, &proto.AddTagsRequest{Id: first.Ids[0], Tags: map[string]string{"mood": "happy"}, IdempotencyKey: "other"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("AddTags with different keys = %v, want InvalidArgument", err)
+	}
+}
+
+type completeErrorRepository struct {
+	repository.Repository
+}
+
+func (r completeErrorRepository) CompleteIdempotencyKey(ctx context.Context, key string, owner string, response []byte) error {
+	return errors.New("connection reset")
+}
+
+func TestIdempotencyKeyNotStored(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+	service := controller.NewDatalakeServiceServer(completeErrorRepository{repository.NewMemoryRepository(logger)}, logger)
+
+	req := &proto.AddSongsRequest{
+		Songs:          []*proto.AddFile{{Name: "Unstored Song"}},
+		IdempotencyKey: "unstored",
+	}
+	first, err := service.AddSongs(ctx, req)
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	// The key was released, so a retry runs again instead of waiting for
+	// the key to time out
+	retry, err := service.AddSongs(ctx, req)
+	if err != nil {
+		t.Fatalf("AddSongs retry: %v", err)
+	}
+	if retry.Ids[0] == first.Ids[0] {
+		t.Errorf("retry replayed %v, want it to run again", retry.Ids)
+	}
+}
+
+func TestBatchTags(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	_, err := datalakeService.AddSongs(ctx, &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{
+			{Name: "Batch A", Tags: map[string]string{"batchTest": "yes"}},
+			{Name: "Batch B", Tags: map[string]string{"batchTest": "yes"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	selector := &proto.SongSelector{Query: "batchTest=yes"}
+	dryRun, err := datalakeService.BatchAddTags(ctx, &proto.BatchAddTagsRequest{
+		Songs:  selector,
+		Tags:   map[string]string{"batchMood": "calm"},
+		DryRun: true,
+	})
+	logger.Infof("%v", dryRun)
+
+	if err != nil {
+		t.Fatalf("BatchAddTags: %v", err)
+	}
+	if dryRun.MatchedCount != 2 || dryRun.ModifiedCount != 0 {
+		t.Errorf("dry run: got %v, want 2 matched", dryRun)
+	}
+
+	added, err := datalakeService.BatchAddTags(ctx, &proto.BatchAddTagsRequest{
+		Songs: selector,
+		Tags:  map[string]string{"batchMood": "calm"},
+	})
+	if err != nil {
+		t.Fatalf("BatchAddTags: %v", err)
+	}
+	if added.MatchedCount != 2 || added.ModifiedCount != 2 {
+		t.Errorf("got %v, want 2 matched and modified", added)
+	}
+
+	removed, err := datalakeService.BatchRemoveTags(ctx, &proto.BatchRemoveTagsRequest{
+		Songs: selector,
+		Keys:  []string{"batchMood"},
+	})
+	if err != nil {
+		t.Fatalf("BatchRemoveTags: %v", err)
+	}
+	if removed.MatchedCount != 2 || removed.ModifiedCount != 2 {
+		t.Errorf("got %v, want 2 matched and modified", removed)
+	}
+
+	invalid := []*proto.BatchAddTagsRequest{
+		{Tags: map[string]string{"batchMood": "calm"}},
+		{Songs: &proto.SongSelector{Query: "batchTest="}, Tags: map[string]string{"batchMood": "calm"}},
+		{Songs: &proto.SongSelector{Ids: []string{"not-an-id"}}, Tags: map[string]string{"batchMood": "calm"}},
+		{Songs: selector},
+	}
+	for _, req := range invalid {
+		if _, err := datalakeService.BatchAddTags(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("BatchAddTags(%v) = %v, want InvalidArgument", req, err)
+		}
+	}
+}
+
+type songStream struct {
+	grpc.ServerStream
+	songs []*proto.StreamSongsResponse
+	// failAfter makes Send fail once it has sent that many songs
+	failAfter int
+}
+
+func (s *songStream) Context() context.Context {
+	return ctx
+}
+
+func (s *songStream) Send(res *proto.StreamSongsResponse) error {
+	if s.failAfter > 0 && len(s.songs) == s.failAfter {
+		return io.ErrClosedPipe
+	}
+	s.songs = append(s.songs, res)
+	return nil
+}
+
+func TestStreamSongs(t *testing.T) {
+
+	key := fmt.Sprintf("streamTest%v", time.Now().UnixNano())
+	songs := make([]*proto.AddFile, 3)
+	for i := range songs {
+		songs[i] = &proto.AddFile{Name: fmt.Sprintf("Streamed %v", i), Tags: map[string]string{key: "yes"}}
+	}
+	added, err := datalakeService.AddSongs(ctx, &proto.AddSongsRequest{Songs: songs})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	stream := &songStream{}
+	if err := datalakeService.StreamSongs(&proto.StreamSongsRequest{Tags: map[string]string{key: "yes"}}, stream); err != nil {
+		t.Fatalf("StreamSongs: %v", err)
+	}
+	if len(stream.songs) != len(songs) {
+		t.Fatalf("streamed %v songs, want %v", len(stream.songs), len(songs))
+	}
+	for i, res := range stream.songs {
+		if res.Song.Id != added.Ids[i] || res.ResumeToken == "" {
+			t.Errorf("songs[%v] = %v, want song %v with a resume token", i, res, added.Ids[i])
+		}
+	}
+
+	// A client that went away after the first song resumes after it
+	broken := &songStream{failAfter: 1}
+	if err := datalakeService.StreamSongs(&proto.StreamSongsRequest{Tags: map[string]string{key: "yes"}}, broken); err == nil {
+		t.Fatal("StreamSongs to a broken stream: expected an error")
+	}
+	resumed := &songStream{}
+	req := &proto.StreamSongsRequest{Tags: map[string]string{key: "yes"}, ResumeToken: broken.songs[0].ResumeToken}
+	if err := datalakeService.StreamSongs(req, resumed); err != nil {
+		t.Fatalf("StreamSongs: %v", err)
+	}
+	if len(resumed.songs) != len(songs)-1 || resumed.songs[0].Song.Id != added.Ids[1] {
+		t.Errorf("resumed %v, want the songs after %v", resumed.songs, added.Ids[0])
+	}
+
+	err = datalakeService.StreamSongs(&proto.StreamSongsRequest{ResumeToken: "not a token"}, &songStream{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("StreamSongs with a bad resume token = %v, want InvalidArgument", err)
+	}
+}
+
+type tagMigrationStream struct {
+	grpc.ServerStream
+	progress []*proto.TagMigration
+}
+
+func (s *tagMigrationStream) Context() context.Context {
+	return ctx
+}
+
+func (s *tagMigrationStream) Send(migration *proto.TagMigration) error {
+	s.progress = append(s.progress, migration)
+	return nil
+}
+
+func TestMigrateTagsSchema(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+	service := controller.NewDatalakeServiceServer(repository.NewMemoryRepository(logger), logger)
+
+	for _, schema := range []*proto.TagSchema{
+		{Key: "genre", AllowedValues: []string{"rock", "pop"}},
+		{Key: "bpm", Type: proto.TagType_INT},
+	} {
+		if _, err := service.PutTagSchema(ctx, &proto.PutTagSchemaRequest{Schema: schema}); err != nil {
+			t.Fatalf("PutTagSchema: %v", err)
+		}
+	}
+	_, err := service.AddSongs(ctx, &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{
+			{Name: "Rock Song", Tags: map[string]string{"style": "rock", "tempo": "120"}},
+			{Name: "Jazz Song", Tags: map[string]string{"style": "jazz"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	// jazz isn't an allowed genre and tempo holds strings, bpm integers
+	for _, req := range []*proto.MigrateTagsRequest{
+		{Key: "style", NewKey: "genre", DryRun: true},
+		{Key: "tempo", NewKey: "bpm", DryRun: true},
+	} {
+		err = service.MigrateTags(req, &tagMigrationStream{})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("MigrateTags renaming %v to %v = %v, want InvalidArgument", req.Key, req.NewKey, err)
+		}
+		details := status.Convert(err).Details()
+		badRequest, ok := details[0].(*errdetails.BadRequest)
+		if !ok || len(badRequest.FieldViolations) != 1 || !strings.HasSuffix(badRequest.FieldViolations[0].Field, "."+req.NewKey) {
+			t.Errorf("got details %v, want a violation of %v", details, req.NewKey)
+		}
+	}
+
+	// Keys without a schema take any value
+	stream := &tagMigrationStream{}
+	if err := service.MigrateTags(&proto.MigrateTagsRequest{Key: "style", NewKey: "kind", DryRun: true}, stream); err != nil {
+		t.Fatalf("MigrateTags: %v", err)
+	}
+	if len(stream.progress) != 1 || stream.progress[0].MatchedCount != 2 {
+		t.Errorf("unexpected dry run: %v", stream.progress)
+	}
+}
+
+func TestMigrateTags(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	key := fmt.Sprintf("migrateTest%v", time.Now().UnixNano())
+	_, err := datalakeService.AddSongs(ctx, &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{
+			{Name: "Migrated A", Tags: map[string]string{key: "hiphop"}},
+			{Name: "Migrated B", Tags: map[string]string{key: "rock"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	dryRun := &tagMigrationStream{}
+	err = datalakeService.MigrateTags(&proto.MigrateTagsRequest{Key: key, Values: map[string]string{"hiphop": "hip-hop"}, DryRun: true}, dryRun)
+	if err != nil {
+		t.Fatalf("MigrateTags: %v", err)
+	}
+	if len(dryRun.progress) != 1 || dryRun.progress[0].State != proto.TagMigrationState_PLANNED || dryRun.progress[0].MatchedCount != 1 {
+		t.Errorf("unexpected dry run: %v", dryRun.progress)
+	}
+
+	batchSize := int64(1)
+	stream := &tagMigrationStream{}
+	err = datalakeService.MigrateTags(&proto.MigrateTagsRequest{Key: key, Values: map[string]string{"hiphop": "hip-hop"}, BatchSize: &batchSize}, stream)
+	logger.Infof("%v", stream.progress)
+
+	if err != nil {
+		t.Fatalf("MigrateTags: %v", err)
+	}
+	last := stream.progress[len(stream.progress)-1]
+	if last.State != proto.TagMigrationState_COMPLETED || last.MatchedCount != 1 || last.ModifiedCount != 1 {
+		t.Errorf("unexpected migration: %v", last)
+	}
+
+	rollback := &tagMigrationStream{}
+	if err := datalakeService.RollBackTagMigration(&proto.RollBackTagMigrationRequest{MigrationId: last.Id}, rollback); err != nil {
+		t.Fatalf("RollBackTagMigration: %v", err)
+	}
+	migration, err := datalakeService.GetTagMigration(ctx, &proto.GetTagMigrationRequest{MigrationId: last.Id})
+	if err != nil {
+		t.Fatalf("GetTagMigration: %v", err)
+	}
+	if migration.State != proto.TagMigrationState_ROLLED_BACK || migration.RolledBackCount != 1 {
+		t.Errorf("unexpected rolled back migration: %v", migration)
+	}
+
+	err = datalakeService.MigrateTags(&proto.MigrateTagsRequest{MigrationId: last.Id}, &tagMigrationStream{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("resuming a rolled back migration = %v, want FailedPrecondition", err)
+	}
+	_, err = datalakeService.GetTagMigration(ctx, &proto.GetTagMigrationRequest{MigrationId: "602b29014accf1b3f3d462d0"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetTagMigration of an unknown ID = %v, want NotFound", err)
+	}
+	err = datalakeService.MigrateTags(&proto.MigrateTagsRequest{Key: key, NewKey: key}, &tagMigrationStream{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("MigrateTags renaming a key to itself = %v, want InvalidArgument", err)
+	}
+}
+
+func TestRevisions(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	added, err := datalakeService.AddSongs(ctx, &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{{Name: "Revised Song"}},
+	})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+	id := added.Ids[0]
+
+	songs, err := datalakeService.GetSongsByIDs(ctx, &proto.GetSongsByIDsRequest{Ids: []string{id}})
+	if err != nil || len(songs.Songs) != 1 || songs.Songs[0].Revision != 1 {
+		t.Fatalf("GetSongsByIDs = %v, %v, want revision 1", songs, err)
+	}
+
+	res, err := datalakeService.AddTags(ctx, &proto.AddTagsRequest{Id: id, Tags: map[string]string{"mood": "calm"}, ExpectedRevision: 1})
+	logger.Infof("%v", res)
+
+	if err != nil || res.Revision != 2 {
+		t.Fatalf("AddTags = %v, %v, want revision 2", res, err)
+	}
+
+	_, err = datalakeService.AddTags(ctx, &proto.AddTagsRequest{Id: id, Tags: map[string]string{"mood": "sad"}, ExpectedRevision: 1})
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("AddTags with a stale revision = %v, want Aborted", err)
+	}
+	_, err = datalakeService.RemoveTags(ctx, &proto.RemoveTagsRequest{Id: id, Tags: map[string]string{"mood": ""}, ExpectedRevision: 10})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("RemoveTags with a future revision = %v, want FailedPrecondition", err)
+	}
+
+	_, err = datalakeService.UpdateSongs(ctx, &proto.UpdateSongsRequest{
+		Songs:      []*proto.File{{Id: id, Name: "Stale Song", Revision: 1}},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+	})
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("UpdateSongs with a stale revision = %v, want Aborted", err)
+	}
+}
+
+// unavailableRepository fails to list songs like a backend that can't be
+// reached.
+type unavailableRepository struct {
+	repository.Repository
+}
+
+func (r unavailableRepository) GetAllSongs(ctx context.Context, opts repository.ListOptions) ([]*repository.File, string, int64, error) {
+	return nil, "", 0, &repository.Error{Kind: repository.ErrUnavailable, Err: fmt.Errorf("connection refused")}
+}
+
+// errorInfo returns the ErrorInfo detail of err.
+func errorInfo(t *testing.T, err error) *errdetails.ErrorInfo {
+	t.Helper()
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	t.Fatalf("%v has no ErrorInfo", err)
+	return nil
+}
+
+func TestErrorStatuses(t *testing.T) {
+
+	missing := "5f0000000000000000000000"
+
+	res, err := datalakeService.AddTags(ctx, &proto.AddTagsRequest{Id: missing, Tags: map[string]string{"mood": "calm"}})
+	if res != nil || status.Code(err) != codes.NotFound {
+		t.Fatalf("AddTags on a missing song = %v, %v, want NotFound", res, err)
+	}
+	if info := errorInfo(t, err); info.Reason != "NOT_FOUND" || info.Metadata["resource"] != "song" {
+		t.Errorf("got ErrorInfo %v, want a song that isn't found", info)
+	}
+	var resource *errdetails.ResourceInfo
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ResourceInfo); ok {
+			resource = info
+		}
+	}
+	if resource.GetResourceType() != "song" {
+		t.Errorf("got ResourceInfo %v, want a song", resource)
+	}
+
+	if _, err := datalakeService.RemoveTags(ctx, &proto.RemoveTagsRequest{Id: missing, Tags: map[string]string{"mood": ""}}); status.Code(err) != codes.NotFound {
+		t.Errorf("RemoveTags on a missing song = %v, want NotFound", err)
+	}
+	if _, err := datalakeService.GetTagMigration(ctx, &proto.GetTagMigrationRequest{MigrationId: missing}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetTagMigration of a missing migration = %v, want NotFound", err)
+	}
+
+	_, err = datalakeService.AddTags(ctx, &proto.AddTagsRequest{Id: "not-an-id", Tags: map[string]string{"mood": "calm"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("AddTags with a malformed ID = %v, want InvalidArgument", err)
+	}
+	if info := errorInfo(t, err); info.Reason != "INVALID_ID" {
+		t.Errorf("got ErrorInfo %v, want INVALID_ID", info)
+	}
+
+	if _, err := datalakeService.GetAllSongs(ctx, &proto.GetAllSongsRequest{PageToken: "not a token"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetAllSongs with a bad page token = %v, want InvalidArgument", err)
+	}
+
+	added, err := datalakeService.AddSongs(ctx, &proto.AddSongsRequest{
+		Songs: []*proto.AddFile{{Name: "Error Song"}},
+	})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+	_, err = datalakeService.AddTags(ctx, &proto.AddTagsRequest{Id: added.Ids[0], Tags: map[string]string{"mood": "calm"}, ExpectedRevision: 5})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("AddTags with a future revision = %v, want FailedPrecondition", err)
+	}
+	if info := errorInfo(t, err); info.Reason != "REVISION_MISMATCH" || info.Metadata["current_revision"] != "1" {
+		t.Errorf("got ErrorInfo %v, want a mismatch with revision 1", info)
+	}
+
+	logger := zaptest.NewLogger(t).Sugar()
+	service := controller.NewDatalakeServiceServer(unavailableRepository{repository.NewMemoryRepository(logger)}, logger)
+	_, err = service.GetAllSongs(ctx, &proto.GetAllSongsRequest{})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("GetAllSongs without a backend = %v, want Unavailable", err)
+	}
+	retry := false
+	for _, detail := range status.Convert(err).Details() {
+		_, ok := detail.(*errdetails.RetryInfo)
+		retry = retry || ok
+	}
+	if !retry {
+		t.Errorf("%v has no RetryInfo", err)
+	}
+}
+
+type songEventStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+	events []*proto.SongEvent
+}
+
+func (s *songEventStream) Context() context.Context {
+	return s.ctx
+}
+
+// Send stops the watch after the first event.
+func (s *songEventStream) Send(event *proto.SongEvent) error {
+	s.events = append(s.events, event)
+	s.cancel()
+	return nil
+}
+
+// watchErrorRepository fails to watch songs with err.
+type watchErrorRepository struct {
+	repository.Repository
+	err error
+}
+
+func (r watchErrorRepository) WatchSongs(ctx context.Context, resumeToken string) (repository.SongWatcher, error) {
+	return nil, r.err
+}
+
+func TestWatchSongs(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+	service := controller.NewDatalakeServiceServer(repository.NewMemoryRepository(logger), logger)
+
+	watchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	stream := &songEventStream{ctx: watchCtx, cancel: cancel}
+	done := make(chan error, 1)
+	go func() {
+		done <- service.WatchSongs(&proto.WatchSongsRequest{}, stream)
+	}()
+
+	// Add songs until the watch has started and reported one
+	for len(done) == 0 && watchCtx.Err() == nil {
+		if _, err := service.AddSongs(ctx, &proto.AddSongsRequest{Songs: []*proto.AddFile{{Name: "Watched Song"}}}); err != nil {
+			t.Fatalf("AddSongs: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("WatchSongs: %v", err)
+	}
+	if len(stream.events) != 1 || stream.events[0].Type != proto.SongEventType_INSERTED || stream.events[0].ResumeToken == "" {
+		t.Errorf("got events %v, want an insert with a resume token", stream.events)
+	}
+
+	errs := []struct {
+		err  error
+		code codes.Code
+	}{
+		{repository.ErrInvalidResumeToken, codes.InvalidArgument},
+		{repository.ErrResumeTokenExpired, codes.OutOfRange},
+		{repository.ErrWatchUnsupported, codes.Unimplemented},
+	}
+	for _, tc := range errs {
+		service := controller.NewDatalakeServiceServer(watchErrorRepository{repository.NewMemoryRepository(logger), tc.err}, logger)
+		err := service.WatchSongs(&proto.WatchSongsRequest{}, &songEventStream{ctx: ctx})
+		if status.Code(err) != tc.code {
+			t.Errorf("WatchSongs failing with %v = %v, want %v", tc.err, err, tc.code)
+		}
+	}
+
+	err := datalakeService.WatchSongs(&proto.WatchSongsRequest{ResumeToken: "not a token"}, &songEventStream{ctx: ctx})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("WatchSongs with a bad resume token = %v, want InvalidArgument", err)
+	}
 }
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/TensorBeat/Datalake/internal/repository"
+	protov1 "github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	// errorDomain is the domain of the ErrorInfo details of errors
+	errorDomain = "datalake.tensorbeat"
+	// retryDelay is how long clients are asked to wait before retrying
+	// while the backend is unavailable
+	retryDelay = time.Second
+)
+
+// errorKinds maps the kinds of repository errors to their code and the
+// reason of their ErrorInfo detail.
+var errorKinds = []struct {
+	kind   error
+	code   codes.Code
+	reason string
+}{
+	{repository.ErrNotFound, codes.NotFound, "NOT_FOUND"},
+	{repository.ErrInvalidID, codes.InvalidArgument, "INVALID_ID"},
+	{repository.ErrInvalidArgument, codes.InvalidArgument, "INVALID_ARGUMENT"},
+	{repository.ErrConflict, codes.AlreadyExists, "CONFLICT"},
+	{repository.ErrUnavailable, codes.Unavailable, "UNAVAILABLE"},
+	{repository.ErrOutOfRange, codes.OutOfRange, "OUT_OF_RANGE"},
+	{repository.ErrUnimplemented, codes.Unimplemented, "UNIMPLEMENTED"},
+}
+
+// statusError converts an error of the repository to a status with the
+// code of its kind and details describing it. Statuses are returned as
+// they are, errors of no kind are INTERNAL.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var mismatch *repository.RevisionMismatchError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.As(err, &mismatch):
+		return revisionMismatchStatus(mismatch)
+	case errors.Is(err, repository.ErrTagMigrationState):
+		return withDetails(status.New(codes.FailedPrecondition, err.Error()), errorInfo("TAG_MIGRATION_STATE", "tag migration"))
+	}
+
+	var resource string
+	var repoErr *repository.Error
+	if errors.As(err, &repoErr) {
+		resource = repoErr.Resource
+	}
+	for _, kind := range errorKinds {
+		if !errors.Is(err, kind.kind) {
+			continue
+		}
+
+		details := []protov1.Message{errorInfo(kind.reason, resource)}
+		switch kind.kind {
+		case repository.ErrNotFound:
+			details = append(details, &errdetails.ResourceInfo{
+				ResourceType: resource,
+				Description:  err.Error(),
+			})
+		case repository.ErrUnavailable:
+			details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)})
+		}
+		return withDetails(status.New(kind.code, err.Error()), details...)
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+// revisionMismatchStatus is ABORTED when the song changed since the
+// expected revision, so the client should read it again and retry, and
+// FAILED_PRECONDITION when the expected revision never existed.
+func revisionMismatchStatus(mismatch *repository.RevisionMismatchError) error {
+	code := codes.FailedPrecondition
+	if mismatch.Stale() {
+		code = codes.Aborted
+	}
+
+	info := errorInfo("REVISION_MISMATCH", "song")
+	info.Metadata["id"] = mismatch.ID
+	info.Metadata["expected_revision"] = strconv.FormatInt(mismatch.Expected, 10)
+	info.Metadata["current_revision"] = strconv.FormatInt(mismatch.Current, 10)
+	return withDetails(status.New(code, mismatch.Error()), info)
+}
+
+func errorInfo(reason string, resource string) *errdetails.ErrorInfo {
+	info := &errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: map[string]string{},
+	}
+	if resource != "" {
+		info.Metadata["resource"] = resource
+	}
+	return info
+}
+
+// withDetails returns st with details, or without them if they can't be
+// attached.
+func withDetails(st *status.Status, details ...protov1.Message) error {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
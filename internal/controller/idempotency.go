@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/TensorBeat/Datalake/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	gproto "google.golang.org/protobuf/proto"
+)
+
+const (
+	idempotencyKeyHeader = "idempotency-key"
+	idempotencyKeyField  = "idempotency_key"
+	// Storing the outcome of a request must not fail because its client
+	// went away, so it gets its own timeout
+	idempotencyStoreTimeout = 10 * time.Second
+	// idempotencyRenewInterval leaves room for two renewals to fail before
+	// the key is taken over
+	idempotencyRenewInterval = repository.IdempotencyLockTimeout / 3
+)
+
+// idempotent runs call once per idempotency key and replays its response
+// into res for retries. Requests without a key always run. The key is
+// renewed while call runs, calls failing with an error and responses that
+// can't be stored release the key so they can be retried.
+func (s *DatalakeServiceServer) idempotent(ctx context.Context, method string, req gproto.Message, res gproto.Message, call func() (gproto.Message, error)) (gproto.Message, error) {
+
+	key, err := idempotencyKey(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return call()
+	}
+
+	hash, err := requestHash(req)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := newIdempotencyOwner()
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	existing, err := s.repo.ReserveIdempotencyKey(ctx, &repository.IdempotencyRecord{
+		Key:         key,
+		Method:      method,
+		RequestHash: hash,
+		Owner:       owner,
+	})
+	if err != nil {
+		s.logger.Errorf("Failed to reserve idempotency key %v: %v", key, err)
+		return nil, statusError(err)
+	}
+	if existing != nil {
+		switch {
+		case existing.Method != method || existing.RequestHash != hash:
+			return nil, status.Errorf(codes.InvalidArgument, "idempotency key %v was used for another request", key)
+		case existing.Response == nil:
+			return nil, status.Errorf(codes.Aborted, "a request with idempotency key %v is still running", key)
+		}
+		if err := gproto.Unmarshal(existing.Response, res); err != nil {
+			s.logger.Errorf("Failed to replay response of idempotency key %v: %v", key, err)
+			return nil, statusError(err)
+		}
+		s.logger.Infof("Replayed %v response of idempotency key %v", method, key)
+		return res, nil
+	}
+
+	storeCtx, cancel := context.WithTimeout(context.Background(), idempotencyStoreTimeout)
+	defer cancel()
+
+	stopRenewing := s.renewIdempotencyKey(key, owner)
+	out, err := call()
+	stopRenewing()
+	if err != nil {
+		s.releaseIdempotencyKey(storeCtx, key, owner)
+		return nil, err
+	}
+
+	raw, err := gproto.Marshal(out)
+	if err == nil {
+		err = s.repo.CompleteIdempotencyKey(storeCtx, key, owner, raw)
+	}
+	if err != nil {
+		// Retries would find the key running until it times out
+		s.logger.Errorf("Failed to store response of idempotency key %v: %v", key, err)
+		s.releaseIdempotencyKey(storeCtx, key, owner)
+	}
+
+	return out, nil
+}
+
+// renewIdempotencyKey renews key every idempotencyRenewInterval until the
+// returned function is called.
+func (s *DatalakeServiceServer) renewIdempotencyKey(key string, owner string) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(idempotencyRenewInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), idempotencyStoreTimeout)
+				if err := s.repo.RenewIdempotencyKey(ctx, key, owner); err != nil {
+					s.logger.Errorf("Failed to renew idempotency key %v: %v", key, err)
+				}
+				cancel()
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func (s *DatalakeServiceServer) releaseIdempotencyKey(ctx context.Context, key string, owner string) {
+	if err := s.repo.ReleaseIdempotencyKey(ctx, key, owner); err != nil {
+		s.logger.Errorf("Failed to release idempotency key %v: %v", key, err)
+	}
+}
+
+// newIdempotencyOwner returns a random token telling the reservation of a
+// request apart from the ones of its retries.
+func newIdempotencyOwner() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// idempotencyKey reads the key from the idempotency_key field of req or
+// the idempotency-key metadata, which must agree when both are set.
+func idempotencyKey(ctx context.Context, req gproto.Message) (string, error) {
+	msg := req.ProtoReflect()
+	key := msg.Get(msg.Descriptor().Fields().ByName(idempotencyKeyField)).String()
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, header := range md.Get(idempotencyKeyHeader) {
+			if key != "" && header != key {
+				return "", status.Errorf(codes.InvalidArgument, "%v and the %v metadata differ", idempotencyKeyField, idempotencyKeyHeader)
+			}
+			key = header
+		}
+	}
+
+	if key == "" {
+		return "", nil
+	}
+	if err := repository.ValidateIdempotencyKey(key); err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	return key, nil
+}
+
+// requestHash identifies a request regardless of how its key was sent.
+func requestHash(req gproto.Message) (string, error) {
+	withoutKey := gproto.Clone(req).ProtoReflect()
+	withoutKey.Clear(withoutKey.Descriptor().Fields().ByName(idempotencyKeyField))
+
+	raw, err := gproto.MarshalOptions{Deterministic: true}.Marshal(withoutKey.Interface())
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
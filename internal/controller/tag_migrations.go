@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/TensorBeat/Datalake/internal/repository"
+	"github.com/TensorBeat/Datalake/pkg/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// renamedValuesPageSize is the number of values of a renamed key checked
+// at a time.
+const renamedValuesPageSize = 1000
+
+var tagMigrationStates = map[repository.TagMigrationState]proto.TagMigrationState{
+	repository.MigrationPlanned:     proto.TagMigrationState_PLANNED,
+	repository.MigrationRunning:     proto.TagMigrationState_RUNNING,
+	repository.MigrationCompleted:   proto.TagMigrationState_COMPLETED,
+	repository.MigrationRollingBack: proto.TagMigrationState_ROLLING_BACK,
+	repository.MigrationRolledBack:  proto.TagMigrationState_ROLLED_BACK,
+}
+
+func (s *DatalakeServiceServer) MigrateTags(req *proto.MigrateTagsRequest, stream proto.DatalakeService_MigrateTagsServer) error {
+	ctx := stream.Context()
+
+	if err := repository.ValidateMigrationBatchSize(req.GetBatchSize()); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	id := req.MigrationId
+	if id == "" {
+		spec := &repository.TagMigrationSpec{
+			Key:    req.Key,
+			NewKey: req.NewKey,
+			Values: req.Values,
+		}
+		if err := repository.ValidateTagMigration(spec); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err := s.validateTagMigration(ctx, spec); err != nil {
+			return err
+		}
+
+		if req.DryRun {
+			plan, err := s.repo.PlanTagMigration(ctx, spec)
+			if err != nil {
+				s.logger.Errorf("Failed to plan tag migration: %v", err)
+				return statusError(err)
+			}
+			return stream.Send(RepoTagMigrationToProtoTagMigration(plan))
+		}
+
+		migration, err := s.repo.CreateTagMigration(ctx, spec)
+		if err != nil {
+			s.logger.Errorf("Failed to create tag migration: %v", err)
+			return statusError(err)
+		}
+		id = migration.ID
+	} else if req.DryRun {
+		return status.Error(codes.InvalidArgument, "migrations can't be resumed as a dry run")
+	}
+
+	_, err := s.repo.RunTagMigration(ctx, id, req.GetBatchSize(), func(migration *repository.TagMigration) error {
+		return stream.Send(RepoTagMigrationToProtoTagMigration(migration))
+	})
+
+	if err != nil {
+		s.logger.Errorf("Failed to migrate tags: %v", err)
+		return statusError(err)
+	}
+
+	return nil
+}
+
+func (s *DatalakeServiceServer) RollBackTagMigration(req *proto.RollBackTagMigrationRequest, stream proto.DatalakeService_RollBackTagMigrationServer) error {
+
+	if err := repository.ValidateMigrationBatchSize(req.GetBatchSize()); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	_, err := s.repo.RollBackTagMigration(stream.Context(), req.MigrationId, req.GetBatchSize(), func(migration *repository.TagMigration) error {
+		return stream.Send(RepoTagMigrationToProtoTagMigration(migration))
+	})
+
+	if err != nil {
+		s.logger.Errorf("Failed to roll back tag migration: %v", err)
+		return statusError(err)
+	}
+
+	return nil
+}
+
+func (s *DatalakeServiceServer) GetTagMigration(ctx context.Context, req *proto.GetTagMigrationRequest) (*proto.TagMigration, error) {
+
+	migration, err := s.repo.GetTagMigration(ctx, req.MigrationId)
+
+	if err != nil {
+		s.logger.Errorf("Failed to get tag migration: %v", err)
+		return nil, statusError(err)
+	}
+
+	return RepoTagMigrationToProtoTagMigration(migration), nil
+}
+
+// validateTagMigration checks that the tags the migration writes follow
+// the tag schemas. Renames are checked with every value of the key, the
+// first value NewKey's schema rejects is reported.
+func (s *DatalakeServiceServer) validateTagMigration(ctx context.Context, spec *repository.TagMigrationSpec) error {
+	schemas, err := s.tagSchemas(ctx)
+	if err != nil {
+		return err
+	}
+
+	var violations fieldViolations
+	if spec.NewKey != "" {
+		violations.add("", schemas.ValidateRemoval([]string{spec.Key}))
+		renamed, err := s.renamedValueViolations(ctx, schemas, spec)
+		if err != nil {
+			return err
+		}
+		violations.add("", renamed)
+	}
+	for _, val := range spec.Values {
+		violations.add("", schemas.ValidateTags(map[string]string{spec.Key: val}, nil, false))
+	}
+	return violations.err()
+}
+
+// renamedValueViolations checks the values of Key as values of NewKey.
+func (s *DatalakeServiceServer) renamedValueViolations(ctx context.Context, schemas *repository.TagSchemas, spec *repository.TagMigrationSpec) ([]*repository.TagViolation, error) {
+	var token string
+	for {
+		values, next, err := s.repo.ListTagValues(ctx, spec.Key, token, renamedValuesPageSize)
+		if err != nil {
+			s.logger.Errorf("Failed to list values of tag %v: %v", spec.Key, err)
+			return nil, statusError(err)
+		}
+
+		for _, value := range values {
+			tags, typedTags := map[string]string{}, map[string]interface{}{}
+			if val, ok := value.Value.(string); ok {
+				tags[spec.NewKey] = val
+			} else {
+				typedTags[spec.NewKey] = value.Value
+			}
+			if violations := schemas.ValidateTags(tags, typedTags, false); len(violations) > 0 {
+				return violations, nil
+			}
+		}
+
+		if next == "" {
+			return nil, nil
+		}
+		token = next
+	}
+}
+
+func RepoTagMigrationToProtoTagMigration(migration *repository.TagMigration) *proto.TagMigration {
+	return &proto.TagMigration{
+		Id:                    migration.ID,
+		Key:                   migration.Key,
+		NewKey:                migration.NewKey,
+		Values:                migration.Values,
+		State:                 tagMigrationStates[migration.State],
+		MatchedCount:          migration.MatchedCount,
+		ModifiedCount:         migration.ModifiedCount,
+		ConflictCount:         migration.ConflictCount,
+		RolledBackCount:       migration.RolledBackCount,
+		RollbackConflictCount: migration.RollbackConflictCount,
+	}
+}
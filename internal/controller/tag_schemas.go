@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/TensorBeat/Datalake/internal/repository"
+	"github.com/TensorBeat/Datalake/pkg/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *DatalakeServiceServer) PutTagSchema(ctx context.Context, req *proto.PutTagSchemaRequest) (*proto.PutTagSchemaResponse, error) {
+	if req.Schema == nil {
+		return nil, status.Error(codes.InvalidArgument, "schema is required")
+	}
+
+	schema := ProtoTagSchemaToRepoTagSchema(req.Schema)
+	if err := repository.ValidateTagSchema(schema); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid schema: %v", err)
+	}
+
+	err := s.repo.PutTagSchema(ctx, schema)
+
+	if err != nil {
+		s.logger.Errorf("Failed to put tag schema: %v", err)
+		return nil, statusError(err)
+	}
+
+	res := &proto.PutTagSchemaResponse{
+		Schema: req.Schema,
+	}
+	return res, nil
+}
+
+func (s *DatalakeServiceServer) ListTagSchemas(ctx context.Context, req *proto.ListTagSchemasRequest) (*proto.ListTagSchemasResponse, error) {
+
+	schemas, err := s.repo.GetTagSchemas(ctx)
+
+	if err != nil {
+		s.logger.Errorf("Failed to get tag schemas: %v", err)
+		return nil, statusError(err)
+	}
+
+	res := &proto.ListTagSchemasResponse{
+		Schemas: make([]*proto.TagSchema, len(schemas)),
+	}
+	for i, schema := range schemas {
+		res.Schemas[i] = RepoTagSchemaToProtoTagSchema(schema)
+	}
+	return res, nil
+}
+
+func (s *DatalakeServiceServer) DeleteTagSchema(ctx context.Context, req *proto.DeleteTagSchemaRequest) (*proto.DeleteTagSchemaResponse, error) {
+
+	deleted, err := s.repo.DeleteTagSchema(ctx, req.Key)
+
+	if err != nil {
+		s.logger.Errorf("Failed to delete tag schema: %v", err)
+		return nil, statusError(err)
+	}
+
+	res := &proto.DeleteTagSchemaResponse{
+		Deleted: deleted,
+	}
+	return res, nil
+}
+
+func ProtoTagSchemaToRepoTagSchema(schema *proto.TagSchema) *repository.TagSchema {
+	return &repository.TagSchema{
+		Key:           schema.Key,
+		Type:          schema.Type,
+		AllowedValues: schema.AllowedValues,
+		Required:      schema.Required,
+		Description:   schema.Description,
+	}
+}
+
+func RepoTagSchemaToProtoTagSchema(schema *repository.TagSchema) *proto.TagSchema {
+	return &proto.TagSchema{
+		Key:           schema.Key,
+		Type:          schema.Type,
+		AllowedValues: schema.AllowedValues,
+		Required:      schema.Required,
+		Description:   schema.Description,
+	}
+}
+
+// tagSchemas loads the schemas writes are validated against.
+func (s *DatalakeServiceServer) tagSchemas(ctx context.Context) (*repository.TagSchemas, error) {
+	schemas, err := s.repo.GetTagSchemas(ctx)
+	if err != nil {
+		s.logger.Errorf("Failed to get tag schemas: %v", err)
+		return nil, statusError(err)
+	}
+	return repository.NewTagSchemas(schemas), nil
+}
+
+// fieldViolations collects the tags of a request that don't follow their
+// schema.
+type fieldViolations []*errdetails.BadRequest_FieldViolation
+
+// add records violations, prefix is the path of the song in the request.
+func (v *fieldViolations) add(prefix string, violations []*repository.TagViolation) {
+	for _, violation := range violations {
+		*v = append(*v, &errdetails.BadRequest_FieldViolation{
+			Field:       prefix + violation.Field,
+			Description: violation.Description,
+		})
+	}
+}
+
+// err returns an InvalidArgument status with a BadRequest detail listing the
+// violations, or nil when there are none.
+func (v fieldViolations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("%v: %v", v[0].Field, v[0].Description)
+	if len(v) > 1 {
+		msg += fmt.Sprintf(" (and %v more)", len(v)-1)
+	}
+	st, err := status.New(codes.InvalidArgument, msg).WithDetails(&errdetails.BadRequest{FieldViolations: v})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	return st.Err()
+}
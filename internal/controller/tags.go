@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TensorBeat/Datalake/internal/repository"
+	"github.com/TensorBeat/Datalake/pkg/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// ProtoTagsToRepoTags converts the tags of a request. String values in
+// typedTags are moved to the string tags so they are stored the same way.
+func ProtoTagsToRepoTags(tags map[string]string, typedTags map[string]*proto.TagValue) (map[string]string, map[string]interface{}, error) {
+	if err := repository.ValidateTagKeys(tags, nil); err != nil {
+		return nil, nil, err
+	}
+	for key := range typedTags {
+		if err := repository.ValidateTagKey(key); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var repoTypedTags map[string]interface{}
+	copied := false
+	for key, tagValue := range typedTags {
+		if _, ok := tags[key]; ok {
+			return nil, nil, fmt.Errorf("tag %v is in both tags and typed_tags", key)
+		}
+
+		var val interface{}
+		switch v := tagValue.GetValue().(type) {
+		case *proto.TagValue_StringValue:
+			// Copy once so the request isn't modified
+			if !copied {
+				tags = copyTags(tags)
+				copied = true
+			}
+			tags[key] = v.StringValue
+			continue
+		case *proto.TagValue_IntValue:
+			val = v.IntValue
+		case *proto.TagValue_FloatValue:
+			val = v.FloatValue
+		case *proto.TagValue_BoolValue:
+			val = v.BoolValue
+		case *proto.TagValue_TimestampValue:
+			t, err := ptypes.Timestamp(v.TimestampValue)
+			if err != nil {
+				return nil, nil, fmt.Errorf("tag %v: %v", key, err)
+			}
+			val = t.UTC()
+		default:
+			return nil, nil, fmt.Errorf("tag %v has no value", key)
+		}
+
+		if repoTypedTags == nil {
+			repoTypedTags = make(map[string]interface{})
+		}
+		repoTypedTags[key] = val
+	}
+
+	return tags, repoTypedTags, nil
+}
+
+func RepoTagsToProtoTags(typedTags map[string]interface{}) map[string]*proto.TagValue {
+	if len(typedTags) == 0 {
+		return nil
+	}
+
+	tags := make(map[string]*proto.TagValue, len(typedTags))
+	for key, val := range typedTags {
+		tags[key] = RepoTagValueToProtoTagValue(val)
+	}
+	return tags
+}
+
+// RepoTagValueToProtoTagValue sends values of types tags can't have, which
+// may have been stored by other writers, and times a timestamp can't hold in
+// their string form so they aren't dropped.
+func RepoTagValueToProtoTagValue(val interface{}) *proto.TagValue {
+	switch v := val.(type) {
+	case string:
+		return &proto.TagValue{Value: &proto.TagValue_StringValue{StringValue: v}}
+	case int64:
+		return &proto.TagValue{Value: &proto.TagValue_IntValue{IntValue: v}}
+	case int32:
+		return &proto.TagValue{Value: &proto.TagValue_IntValue{IntValue: int64(v)}}
+	case int:
+		return &proto.TagValue{Value: &proto.TagValue_IntValue{IntValue: int64(v)}}
+	case float64:
+		return &proto.TagValue{Value: &proto.TagValue_FloatValue{FloatValue: v}}
+	case bool:
+		return &proto.TagValue{Value: &proto.TagValue_BoolValue{BoolValue: v}}
+	case time.Time:
+		ts, err := ptypes.TimestampProto(v)
+		if err != nil {
+			return &proto.TagValue{Value: &proto.TagValue_StringValue{StringValue: v.UTC().Format(time.RFC3339Nano)}}
+		}
+		return &proto.TagValue{Value: &proto.TagValue_TimestampValue{TimestampValue: ts}}
+	}
+	return &proto.TagValue{Value: &proto.TagValue_StringValue{StringValue: fmt.Sprint(val)}}
+}
+
+func copyTags(tags map[string]string) map[string]string {
+	copied := make(map[string]string, len(tags))
+	for k, v := range tags {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,366 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxLength and MaxDepth bound the queries Parse accepts.
+	MaxLength = 4096
+	MaxDepth  = 32
+
+	existsValue = "*"
+)
+
+// ParseError describes why a query couldn't be parsed. Pos is the byte
+// offset in the query where the problem was found.
+type ParseError struct {
+	Pos int
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("query error at position %v: %v", e.Pos, e.Msg)
+}
+
+// Parse parses a query. Predicates are key=value, key!=value, key=* for tags
+// that are set, comparisons with <, <=, >, >= and key BETWEEN low AND high,
+// combined with AND, OR, NOT and parentheses. Values with spaces or special
+// characters must be double quoted.
+func Parse(query string) (Node, error) {
+	if len(query) > MaxLength {
+		return nil, &ParseError{Pos: MaxLength, Msg: fmt.Sprintf("query is longer than %v characters", MaxLength)}
+	}
+
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	node, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorf(tok, "expected AND, OR or end of query but found %v", tok)
+	}
+	return node, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLeftParen
+	tokenRightParen
+	tokenEqual
+	tokenNotEqual
+	tokenLess
+	tokenLessOrEqual
+	tokenGreater
+	tokenGreaterOrEqual
+	tokenBetween
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+var keywords = map[string]tokenKind{
+	"AND":     tokenAnd,
+	"OR":      tokenOr,
+	"NOT":     tokenNot,
+	"BETWEEN": tokenBetween,
+}
+
+var comparisons = map[tokenKind]Operator{
+	tokenEqual:          Equal,
+	tokenNotEqual:       NotEqual,
+	tokenLess:           Less,
+	tokenLessOrEqual:    LessOrEqual,
+	tokenGreater:        Greater,
+	tokenGreaterOrEqual: GreaterOrEqual,
+}
+
+func isKeyword(word string) bool {
+	_, ok := keywords[strings.ToUpper(word)]
+	return ok
+}
+
+func isWordRune(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune("()=!<>\"", r)
+}
+
+func lex(query string) ([]token, error) {
+	tokens := make([]token, 0)
+
+	for pos := 0; pos < len(query); {
+		r, size := utf8.DecodeRuneInString(query[pos:])
+		switch {
+		case unicode.IsSpace(r):
+			pos += size
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, text: "(", pos: pos})
+			pos++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, text: ")", pos: pos})
+			pos++
+		case r == '=':
+			tokens = append(tokens, token{kind: tokenEqual, text: "=", pos: pos})
+			pos++
+		case strings.HasPrefix(query[pos:], "!="):
+			tokens = append(tokens, token{kind: tokenNotEqual, text: "!=", pos: pos})
+			pos += 2
+		case strings.HasPrefix(query[pos:], "<="):
+			tokens = append(tokens, token{kind: tokenLessOrEqual, text: "<=", pos: pos})
+			pos += 2
+		case strings.HasPrefix(query[pos:], ">="):
+			tokens = append(tokens, token{kind: tokenGreaterOrEqual, text: ">=", pos: pos})
+			pos += 2
+		case r == '<':
+			tokens = append(tokens, token{kind: tokenLess, text: "<", pos: pos})
+			pos++
+		case r == '>':
+			tokens = append(tokens, token{kind: tokenGreater, text: ">", pos: pos})
+			pos++
+		case r == '"':
+			end := pos + 1
+			for end < len(query) && query[end] != '"' {
+				if query[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(query) {
+				return nil, &ParseError{Pos: pos, Msg: "unterminated quoted value"}
+			}
+			text, err := strconv.Unquote(query[pos : end+1])
+			if err != nil {
+				return nil, &ParseError{Pos: pos, Msg: fmt.Sprintf("invalid quoted value: %v", err)}
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: pos})
+			pos = end + 1
+		default:
+			end := pos
+			for end < len(query) {
+				r, size := utf8.DecodeRuneInString(query[end:])
+				if !isWordRune(r) {
+					break
+				}
+				end += size
+			}
+			if end == pos {
+				return nil, &ParseError{Pos: pos, Msg: fmt.Sprintf("unexpected character %q", r)}
+			}
+			word := query[pos:end]
+			kind, ok := keywords[strings.ToUpper(word)]
+			if !ok {
+				kind = tokenWord
+			}
+			tokens = append(tokens, token{kind: kind, text: word, pos: pos})
+			pos = end
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(query)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) errorf(tok token, format string, args ...interface{}) error {
+	return &ParseError{Pos: tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) parseOr(depth int) (Node, error) {
+	node, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []Node{node}
+	for p.peek().kind == tokenOr {
+		p.next()
+		node, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return &Or{Nodes: nodes}, nil
+}
+
+func (p *parser) parseAnd(depth int) (Node, error) {
+	node, err := p.parseNot(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []Node{node}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		node, err := p.parseNot(depth)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return &And{Nodes: nodes}, nil
+}
+
+func (p *parser) parseNot(depth int) (Node, error) {
+	if depth > MaxDepth {
+		return nil, p.errorf(p.peek(), "query is nested deeper than %v levels", MaxDepth)
+	}
+
+	if p.peek().kind == tokenNot {
+		p.next()
+		node, err := p.parseNot(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Node: node}, nil
+	}
+
+	return p.parsePrimary(depth)
+}
+
+func (p *parser) parsePrimary(depth int) (Node, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenLeftParen:
+		node, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRightParen {
+			return nil, p.errorf(closing, "expected \")\" to close the \"(\" at position %v but found %v", tok.pos, closing)
+		}
+		return node, nil
+	case tokenWord, tokenString:
+		return p.parsePredicate(tok)
+	}
+
+	return nil, p.errorf(tok, "expected a tag or \"(\" but found %v", tok)
+}
+
+func (p *parser) parsePredicate(key token) (Node, error) {
+	if key.text == "" {
+		return nil, p.errorf(key, "tag names can't be empty")
+	}
+	if strings.HasPrefix(key.text, "$") {
+		return nil, p.errorf(key, "tag names can't start with $")
+	}
+	// Mongo would read a dotted name as a path into a nested tag
+	if strings.Contains(key.text, ".") {
+		return nil, p.errorf(key, "tag names can't contain dots")
+	}
+
+	op := p.next()
+	if op.kind == tokenBetween {
+		return p.parseBetween(key)
+	}
+	operator, ok := comparisons[op.kind]
+	if !ok {
+		return nil, p.errorf(op, "expected =, !=, <, <=, >, >= or BETWEEN after tag %v but found %v", key, op)
+	}
+
+	value := p.next()
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, p.errorf(value, "expected a value for tag %v but found %v", key, value)
+	}
+
+	// An unquoted * matches any value, quote it to match a literal *
+	if value.kind == tokenWord && value.text == existsValue {
+		switch operator {
+		case Equal:
+			return &Predicate{Key: key.text, Op: Exists}, nil
+		case NotEqual:
+			return &Not{Node: &Predicate{Key: key.text, Op: Exists}}, nil
+		}
+		return nil, p.errorf(value, "%v can only be compared with = or !=", existsValue)
+	}
+
+	return &Predicate{Key: key.text, Op: operator, Values: []Literal{literal(value)}}, nil
+}
+
+func (p *parser) parseBetween(key token) (Node, error) {
+	bounds := make([]Literal, 0, 2)
+	for i := 0; i < 2; i++ {
+		if i == 1 {
+			if and := p.next(); and.kind != tokenAnd {
+				return nil, p.errorf(and, "expected AND between the bounds of tag %v but found %v", key, and)
+			}
+		}
+
+		value := p.next()
+		if value.kind != tokenWord && value.kind != tokenString {
+			return nil, p.errorf(value, "expected a bound for tag %v but found %v", key, value)
+		}
+		if value.kind == tokenWord && value.text == existsValue {
+			return nil, p.errorf(value, "%v can only be compared with = or !=", existsValue)
+		}
+		bounds = append(bounds, literal(value))
+
+		if i == 1 {
+			if _, ok := Compare(bounds[0].Value, bounds[1].Value); !ok {
+				return nil, p.errorf(value, "bounds of tag %v have different types", key)
+			}
+		}
+	}
+
+	return &Predicate{Key: key.text, Op: Between, Values: bounds}, nil
+}
+
+// literal types unquoted values, quoted values are always strings.
+func literal(tok token) Literal {
+	if tok.kind == tokenString {
+		return Literal{Value: tok.text, Text: tok.text}
+	}
+	return parseLiteral(tok.text)
+}
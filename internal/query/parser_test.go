@@ -0,0 +1,154 @@
+package query
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"genre=rock", "genre=rock"},
+		{"  genre = rock  ", "genre=rock"},
+		{"genre!=rock", "genre!=rock"},
+		{"genre=*", "genre=*"},
+		{"genre!=*", "NOT genre=*"},
+		{`genre="*"`, `genre="*"`},
+		{`genre="Hip Hop"`, `genre="Hip Hop"`},
+		{`"spectrogram id"="a \"b\""`, `"spectrogram id"="a \"b\""`},
+		{"genre=rock and mood=happy", "genre=rock AND mood=happy"},
+		{"a=1 OR b=2 AND c=3", "a=1 OR (b=2 AND c=3)"},
+		{"(a=1 OR b=2) AND c=3", "(a=1 OR b=2) AND c=3"},
+		{"NOT NOT a=1", "NOT NOT a=1"},
+		{"not (a=1 or b=2)", "NOT (a=1 OR b=2)"},
+		{
+			"genre=rock AND (mood=happy OR mood=energetic) AND NOT explicit=*",
+			"genre=rock AND (mood=happy OR mood=energetic) AND NOT explicit=*",
+		},
+		{"artist=Beyoncé", "artist=Beyoncé"},
+		{`mood="and"`, `mood="and"`},
+		{"bpm<120", "bpm<120"},
+		{"bpm <= 120.5", "bpm<=120.5"},
+		{"bpm>-3 AND bpm>=1e3", "bpm>-3 AND bpm>=1e3"},
+		{"bpm between 100 and 140", "bpm BETWEEN 100 AND 140"},
+		{"released BETWEEN 2020-01-01T00:00:00Z AND 2021-01-01T00:00:00Z", "released BETWEEN 2020-01-01T00:00:00Z AND 2021-01-01T00:00:00Z"},
+		{"bpm BETWEEN 1 AND 2 AND genre=rock", "bpm BETWEEN 1 AND 2 AND genre=rock"},
+		{`bpm="120"`, `bpm="120"`},
+		{`explicit="true"`, `explicit="true"`},
+		{"explicit=true", "explicit=true"},
+		{"name>=abc", "name>=abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			node, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got := node.String(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+		msg   string
+	}{
+		{"", 0, "expected a tag"},
+		{"genre", 5, "expected =, !=, <, <=, >, >= or BETWEEN"},
+		{"genre=", 6, "expected a value"},
+		{"genre=rock AND", 14, "expected a tag"},
+		{"genre=rock mood=happy", 11, "expected AND, OR or end of query"},
+		{"(genre=rock", 11, `expected ")"`},
+		{"genre=rock)", 10, "expected AND, OR or end of query"},
+		{`genre="rock`, 6, "unterminated quoted value"},
+		{"genre!rock", 5, "unexpected character"},
+		{"bpm<*", 4, "can only be compared with = or !="},
+		{"bpm BETWEEN 1 2", 14, "expected AND between the bounds"},
+		{"bpm BETWEEN 1 AND", 17, "expected a bound"},
+		{"bpm BETWEEN 1 AND abc", 18, "different types"},
+		{"$where=1", 0, "can't start with $"},
+		{`""=rock`, 0, "can't be empty"},
+		{"a.b=1", 0, "can't contain dots"},
+		{`"a.b"=1`, 0, "can't contain dots"},
+		{strings.Repeat("(", MaxDepth+2) + "a=1", MaxDepth + 1, "nested deeper"},
+		{strings.Repeat("a", MaxLength+1), MaxLength, "longer than"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Parse(tt.query)
+			parseErr, ok := err.(*ParseError)
+			if !ok {
+				t.Fatalf("got error %v, want a *ParseError", err)
+			}
+			if parseErr.Pos != tt.pos || !strings.Contains(parseErr.Msg, tt.msg) {
+				t.Errorf("got %v, want position %v and message containing %q", err, tt.pos, tt.msg)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tags := map[string]interface{}{
+		"genre":    "rock",
+		"mood":     "happy",
+		"bpm":      int64(120),
+		"duration": 215.5,
+		"live":     false,
+		"released": time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+		"year":     "1999",
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"genre=rock", true},
+		{"genre=pop", false},
+		{"genre!=pop", true},
+		{"explicit!=true", true},
+		{"mood=*", true},
+		{"explicit=*", false},
+		{"genre=rock AND mood=sad", false},
+		{"genre=pop OR mood=happy", true},
+		{"genre=rock AND (mood=sad OR mood=happy) AND NOT explicit=*", true},
+		{"NOT genre=rock", false},
+		{"bpm=120", true},
+		{"bpm=120.0", true},
+		{`bpm="120"`, false},
+		{"bpm>100 AND bpm<=120", true},
+		{"bpm<120", false},
+		{"bpm BETWEEN 100 AND 140", true},
+		{"bpm BETWEEN 121 AND 140", false},
+		{"bpm>abc", false},
+		{"duration>215", true},
+		{"live=false", true},
+		{"live!=true", true},
+		{"released>=2020-01-01T00:00:00Z", true},
+		{"released BETWEEN 2021-01-01T00:00:00Z AND 2022-01-01T00:00:00Z", false},
+		{"year=1999", true},
+		{"year>1000", false},
+		{"genre>=rock AND genre<s", true},
+		{"tempo<1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			node, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got := Match(node, tags); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
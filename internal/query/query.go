@@ -0,0 +1,277 @@
+// Package query parses boolean tag queries such as
+//
+//	genre=rock AND (mood=happy OR mood=energetic) AND NOT explicit=*
+//	bpm BETWEEN 100 AND 140 AND duration < 300.5
+//
+// into an AST that the repositories compile into their own filters.
+package query
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Node is a node of a parsed query.
+type Node interface {
+	// String formats the node back into query syntax.
+	String() string
+}
+
+type And struct {
+	Nodes []Node
+}
+
+type Or struct {
+	Nodes []Node
+}
+
+type Not struct {
+	Node Node
+}
+
+type Operator int
+
+const (
+	Equal Operator = iota
+	NotEqual
+	Exists
+	Less
+	LessOrEqual
+	Greater
+	GreaterOrEqual
+	Between
+)
+
+var operatorSymbols = map[Operator]string{
+	Equal:          "=",
+	NotEqual:       "!=",
+	Less:           "<",
+	LessOrEqual:    "<=",
+	Greater:        ">",
+	GreaterOrEqual: ">=",
+}
+
+// Predicate compares the value of the tag Key. Values holds the lower and
+// upper bound for Between, nothing for Exists and a single value otherwise.
+type Predicate struct {
+	Key    string
+	Op     Operator
+	Values []Literal
+}
+
+// Literal is a value in a query. Unquoted integers, floats, booleans and
+// RFC 3339 times are typed, every other value is a string. Text is the value
+// as it was written, so typed literals can still match string tags.
+type Literal struct {
+	Value interface{} // string, int64, float64, bool or time.Time
+	Text  string
+}
+
+func (n *And) String() string {
+	return joinNodes(n.Nodes, " AND ")
+}
+
+func (n *Or) String() string {
+	return joinNodes(n.Nodes, " OR ")
+}
+
+func (n *Not) String() string {
+	return "NOT " + wrap(n.Node)
+}
+
+func (n *Predicate) String() string {
+	key := quote(n.Key)
+	switch n.Op {
+	case Exists:
+		return key + "=" + existsValue
+	case Between:
+		return key + " BETWEEN " + n.Values[0].String() + " AND " + n.Values[1].String()
+	}
+	return key + operatorSymbols[n.Op] + n.Values[0].String()
+}
+
+func (l Literal) String() string {
+	if _, ok := l.Value.(string); ok {
+		return quote(l.Text)
+	}
+	return l.Text
+}
+
+// Match evaluates the query against the tags of a song. Tag values are
+// strings, int64, float64, bool or time.Time.
+func Match(node Node, tags map[string]interface{}) bool {
+	switch n := node.(type) {
+	case *And:
+		for _, child := range n.Nodes {
+			if !Match(child, tags) {
+				return false
+			}
+		}
+		return true
+	case *Or:
+		for _, child := range n.Nodes {
+			if Match(child, tags) {
+				return true
+			}
+		}
+		return false
+	case *Not:
+		return !Match(n.Node, tags)
+	case *Predicate:
+		val, ok := tags[n.Key]
+		switch n.Op {
+		case Exists:
+			return ok
+		case Equal:
+			return ok && equal(val, n.Values[0])
+		case NotEqual:
+			return !ok || !equal(val, n.Values[0])
+		}
+		if !ok {
+			return false
+		}
+
+		c, comparable := Compare(val, n.Values[0].Value)
+		switch n.Op {
+		case Less:
+			return comparable && c < 0
+		case LessOrEqual:
+			return comparable && c <= 0
+		case Greater:
+			return comparable && c > 0
+		case GreaterOrEqual:
+			return comparable && c >= 0
+		case Between:
+			upper, upperComparable := Compare(val, n.Values[1].Value)
+			return comparable && upperComparable && c >= 0 && upper <= 0
+		}
+	}
+	return false
+}
+
+// equal reports whether a tag value equals the literal. String tags are
+// compared to the literal as written so bpm=120 still matches "120".
+func equal(val interface{}, literal Literal) bool {
+	if s, ok := val.(string); ok {
+		return s == literal.Text
+	}
+	c, ok := Compare(val, literal.Value)
+	return ok && c == 0
+}
+
+// Compare orders two tag values the way mongo does within a type: numbers
+// with numbers, strings with strings, booleans with booleans and times with
+// times. ok is false for values that can't be compared.
+func Compare(a, b interface{}) (c int, ok bool) {
+	switch a := a.(type) {
+	case int64:
+		switch b := b.(type) {
+		case int64:
+			return compareInts(a, b), true
+		case float64:
+			return compareFloats(float64(a), b), true
+		}
+	case float64:
+		switch b := b.(type) {
+		case int64:
+			return compareFloats(a, float64(b)), true
+		case float64:
+			return compareFloats(a, b), true
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b), true
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			switch {
+			case a == b:
+				return 0, true
+			case b:
+				return -1, true
+			}
+			return 1, true
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			switch {
+			case a.Before(b):
+				return -1, true
+			case a.After(b):
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	return 0, false
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// parseLiteral types an unquoted value.
+func parseLiteral(text string) Literal {
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return Literal{Value: i, Text: text}
+	}
+	// ParseFloat also accepts words like inf and nan
+	if strings.ContainsAny(text, "0123456789") {
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return Literal{Value: f, Text: text}
+		}
+	}
+	if b, err := strconv.ParseBool(text); err == nil && (text == "true" || text == "false") {
+		return Literal{Value: b, Text: text}
+	}
+	// Tags only keep milliseconds, so values are compared at that precision
+	if t, err := time.Parse(time.RFC3339Nano, text); err == nil {
+		return Literal{Value: t.Truncate(time.Millisecond), Text: text}
+	}
+	return Literal{Value: text, Text: text}
+}
+
+func joinNodes(nodes []Node, sep string) string {
+	parts := make([]string, len(nodes))
+	for i, node := range nodes {
+		parts[i] = wrap(node)
+	}
+	return strings.Join(parts, sep)
+}
+
+// wrap adds parentheses around nodes that combine other nodes.
+func wrap(node Node) string {
+	switch node.(type) {
+	case *And, *Or:
+		return "(" + node.String() + ")"
+	}
+	return node.String()
+}
+
+// quote quotes strings that wouldn't be read back as the same string.
+func quote(value string) string {
+	if value == "" || value == existsValue || strings.ContainsAny(value, " \t\r\n()=!<>\"") || isKeyword(value) {
+		return strconv.Quote(value)
+	}
+	if _, ok := parseLiteral(value).Value.(string); !ok {
+		return strconv.Quote(value)
+	}
+	return value
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/TensorBeat/Datalake/internal/query"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxSelectorIDs = 100000
+
+// SongSelector selects the songs of a batch update, either by IDs or with a
+// query.
+type SongSelector struct {
+	IDs   []string
+	Query query.Node
+}
+
+// BatchResult counts the songs matched by a batch update and the ones it
+// changed, songs already having the tags are matched but not modified.
+type BatchResult struct {
+	MatchedCount  int64
+	ModifiedCount int64
+}
+
+// ValidateSongSelector checks that selector selects songs one way.
+func ValidateSongSelector(selector SongSelector) error {
+	switch {
+	case len(selector.IDs) > 0 && selector.Query != nil:
+		return errors.New("songs can be selected by IDs or with a query, not both")
+	case len(selector.IDs) == 0 && selector.Query == nil:
+		return errors.New("IDs or a query are required to select songs")
+	case len(selector.IDs) > maxSelectorIDs:
+		return fmt.Errorf("at most %v IDs can be selected", maxSelectorIDs)
+	}
+
+	for _, id := range selector.IDs {
+		if _, err := primitive.ObjectIDFromHex(id); err != nil {
+			return fmt.Errorf("bad ID %q: %v", id, err)
+		}
+	}
+	return nil
+}
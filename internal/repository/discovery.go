@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Names of the lists of distinct values in their page tokens.
+const (
+	tagKeysList   = "tagKeys"
+	tagValuesList = "tagValues:"
+)
+
+// TagKeyCount is a tag key and the number of songs with the tag.
+type TagKeyCount struct {
+	Key   string
+	Count int64
+}
+
+// ValidateTagKey checks that key can be looked up in the tags of songs.
+func ValidateTagKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "$") || strings.Contains(key, ".") {
+		return fmt.Errorf("invalid key %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import "errors"
+
+// The kinds of errors the repositories return, errors.Is matches an error
+// against its kind. Errors of none of these kinds are internal failures.
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrInvalidID       = errors.New("invalid ID")
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrConflict        = errors.New("conflict")
+	// ErrUnavailable is the kind of errors of the backend being unreachable,
+	// the call can be retried later.
+	ErrUnavailable = errors.New("unavailable")
+	// ErrOutOfRange is the kind of errors of asking for positions the
+	// backend no longer keeps.
+	ErrOutOfRange = errors.New("out of range")
+	// ErrUnimplemented is the kind of errors of features the backend
+	// doesn't support.
+	ErrUnimplemented = errors.New("unimplemented")
+)
+
+// Error is an error of one of the kinds above. Resource names the kind of
+// record that wasn't found or conflicted, like "song", it may be empty.
+type Error struct {
+	Kind     error
+	Resource string
+	Err      error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func newError(kind error, resource string, text string) error {
+	return &Error{Kind: kind, Resource: resource, Err: errors.New(text)}
+}
+
+func invalidID(err error) error {
+	return &Error{Kind: ErrInvalidID, Err: err}
+}
+
+func invalidArgument(err error) error {
+	return &Error{Kind: ErrInvalidArgument, Err: err}
+}
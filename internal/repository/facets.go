@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	defaultFacetLimit = 10
+	maxFacetLimit     = 1000
+	maxFacetKeys      = 20
+)
+
+// TagFacet counts the songs per value of a tag.
+type TagFacet struct {
+	Key string
+	// Values holds the most frequent values first, values with the same
+	// count are in sort order
+	Values []*FacetValue
+	// MissingCount is the number of songs without the tag
+	MissingCount int64
+	// OtherCount is the number of songs whose value was left out by the limit
+	OtherCount int64
+}
+
+type FacetValue struct {
+	Value interface{}
+	Count int64
+}
+
+// ValidateFacets checks that the values of keys can be counted, a limit of 0
+// uses defaultFacetLimit.
+func ValidateFacets(keys []string, limit int64) error {
+	if len(keys) == 0 || len(keys) > maxFacetKeys {
+		return fmt.Errorf("between 1 and %v keys are required", maxFacetKeys)
+	}
+	if limit < 0 || limit > maxFacetLimit {
+		return fmt.Errorf("limit must be between 0 and %v", maxFacetLimit)
+	}
+
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if err := ValidateTagKey(key); err != nil {
+			return err
+		}
+		if seen[key] {
+			return fmt.Errorf("key %q is listed twice", key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func normalizeFacetLimit(limit int64) int64 {
+	if limit == 0 {
+		return defaultFacetLimit
+	}
+	return limit
+}
+
+// sortFacetValues orders values the same way the mongo pipeline does.
+func sortFacetValues(values []*FacetValue) {
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].Count != values[j].Count {
+			return values[i].Count > values[j].Count
+		}
+		return compareSortValues(values[i].Value, values[j].Value) < 0
+	})
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// IdempotencyTTL is how long the response of a request made with an
+	// idempotency key is replayed
+	IdempotencyTTL = 24 * time.Hour
+	// IdempotencyLockTimeout is how long a key stays reserved by a request
+	// that stopped renewing it, after which a retry runs the request again
+	IdempotencyLockTimeout  = time.Minute
+	maxIdempotencyKeyLength = 256
+	// idempotencySweepInterval is how often the memory backend removes
+	// expired keys
+	idempotencySweepInterval = time.Minute
+)
+
+// IdempotencyRecord is a request made with an idempotency key and its
+// response once it completed.
+type IdempotencyRecord struct {
+	Key    string
+	Method string
+	// RequestHash tells retries apart from another request reusing the key
+	RequestHash string
+	// Owner is a token of the request holding the reservation, a request
+	// that lost the key to another one can't renew, complete or release it
+	Owner string
+	// Response is nil while the request is running
+	Response  []byte
+	CreatedAt time.Time
+	// RenewedAt is when the running request last renewed the key
+	RenewedAt time.Time
+}
+
+// ValidateIdempotencyKey checks that key can be stored.
+func ValidateIdempotencyKey(key string) error {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return errors.New("idempotency key must have between 1 and 256 characters")
+	}
+	return nil
+}
+
+// reservable reports whether a new request may take over the key of record
+// at now, because the record expired or its request was abandoned.
+func (r *IdempotencyRecord) reservable(now time.Time) bool {
+	if now.Sub(r.CreatedAt) >= IdempotencyTTL {
+		return true
+	}
+	// Records reserved before keys were renewed only have CreatedAt
+	renewedAt := r.RenewedAt
+	if renewedAt.IsZero() {
+		renewedAt = r.CreatedAt
+	}
+	return r.Response == nil && now.Sub(renewedAt) >= IdempotencyLockTimeout
+}
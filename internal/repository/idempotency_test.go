@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestIdempotencyRecordReservable(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) time.Time {
+		return now.Add(-d)
+	}
+
+	tests := []struct {
+		name   string
+		record IdempotencyRecord
+		want   bool
+	}{
+		{"running", IdempotencyRecord{CreatedAt: ago(time.Second), RenewedAt: ago(time.Second)}, false},
+		{"renewed", IdempotencyRecord{CreatedAt: ago(time.Hour), RenewedAt: ago(time.Second)}, false},
+		{"abandoned", IdempotencyRecord{CreatedAt: ago(time.Hour), RenewedAt: ago(IdempotencyLockTimeout)}, true},
+		{"abandoned before renewals", IdempotencyRecord{CreatedAt: ago(IdempotencyLockTimeout)}, true},
+		{"completed", IdempotencyRecord{Response: []byte{}, CreatedAt: ago(time.Hour), RenewedAt: ago(time.Hour)}, false},
+		{"expired", IdempotencyRecord{Response: []byte{}, CreatedAt: ago(IdempotencyTTL), RenewedAt: ago(time.Second)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.record.reservable(now); got != tt.want {
+				t.Errorf("reservable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryIdempotencyKeysSweep(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository(zaptest.NewLogger(t).Sugar())
+
+	for _, key := range []string{"old", "new"} {
+		if _, err := repo.ReserveIdempotencyKey(ctx, &IdempotencyRecord{Key: key}); err != nil {
+			t.Fatalf("ReserveIdempotencyKey: %v", err)
+		}
+	}
+	repo.idempotencyKeys["old"].CreatedAt = time.Now().Add(-IdempotencyTTL)
+	repo.idempotencySweptAt = time.Time{}
+
+	if _, err := repo.ReserveIdempotencyKey(ctx, &IdempotencyRecord{Key: "other"}); err != nil {
+		t.Fatalf("ReserveIdempotencyKey: %v", err)
+	}
+	if _, ok := repo.idempotencyKeys["old"]; ok {
+		t.Error("expired key wasn't swept")
+	}
+	if _, ok := repo.idempotencyKeys["new"]; !ok {
+		t.Error("live key was swept")
+	}
+}
+
+func TestMemoryIdempotencyKeyTakenOver(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository(zaptest.NewLogger(t).Sugar())
+
+	if _, err := repo.ReserveIdempotencyKey(ctx, &IdempotencyRecord{Key: "slow", Owner: "first"}); err != nil {
+		t.Fatalf("ReserveIdempotencyKey: %v", err)
+	}
+	// The first request stopped renewing the key for too long
+	repo.idempotencyKeys["slow"].RenewedAt = time.Now().Add(-IdempotencyLockTimeout)
+	existing, err := repo.ReserveIdempotencyKey(ctx, &IdempotencyRecord{Key: "slow", Owner: "second"})
+	if err != nil || existing != nil {
+		t.Fatalf("ReserveIdempotencyKey taking over = %v, %v", existing, err)
+	}
+
+	// The first request finishing doesn't touch the reservation of the second
+	renewedAt := repo.idempotencyKeys["slow"].RenewedAt
+	if err := repo.RenewIdempotencyKey(ctx, "slow", "first"); err != nil {
+		t.Fatalf("RenewIdempotencyKey: %v", err)
+	}
+	if err := repo.CompleteIdempotencyKey(ctx, "slow", "first", []byte("first")); err != nil {
+		t.Fatalf("CompleteIdempotencyKey: %v", err)
+	}
+	if err := repo.ReleaseIdempotencyKey(ctx, "slow", "first"); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey: %v", err)
+	}
+	record, ok := repo.idempotencyKeys["slow"]
+	if !ok || record.Owner != "second" || record.Response != nil || record.RenewedAt != renewedAt {
+		t.Fatalf("got %+v, want the untouched reservation of the second request", record)
+	}
+
+	if err := repo.CompleteIdempotencyKey(ctx, "slow", "second", []byte("second")); err != nil {
+		t.Fatalf("CompleteIdempotencyKey: %v", err)
+	}
+	if string(repo.idempotencyKeys["slow"].Response) != "second" {
+		t.Errorf("got response %q, want the one of the second request", repo.idempotencyKeys["slow"].Response)
+	}
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// uriIndexName is the index keeping URIs unique.
+const uriIndexName = "uri_unique"
+
+type IndexDriftKind string
+
+const (
+	// IndexMissing is a declared index that doesn't exist
+	IndexMissing IndexDriftKind = "missing"
+	// IndexChanged is a declared index that exists with other keys or options
+	IndexChanged IndexDriftKind = "changed"
+	// IndexUnmanaged is an index that isn't declared, it is left alone
+	IndexUnmanaged IndexDriftKind = "unmanaged"
+)
+
+// IndexDrift is a difference between the declared indexes and the indexes
+// of the database.
+type IndexDrift struct {
+	Collection string
+	Name       string
+	Kind       IndexDriftKind
+	Detail     string
+}
+
+func (d *IndexDrift) String() string {
+	return fmt.Sprintf("%v index %v.%v: %v", d.Kind, d.Collection, d.Name, d.Detail)
+}
+
+// indexSpec declares an index of a collection.
+type indexSpec struct {
+	name    string
+	keys    bson.D
+	unique  bool
+	partial bson.D
+	// expireAfter makes a TTL index removing documents that many seconds
+	// after the date in its key
+	expireAfter *int64
+	// weights makes a text index, whose keys mongo lists in another form
+	// so the weights are compared instead
+	weights         bson.D
+	defaultLanguage string
+}
+
+// songIndexes are the indexes the queries of MongoRepository rely on. The
+// _id index and the search index, which depends on the search tags, aren't
+// listed.
+var songIndexes = []indexSpec{
+	{
+		// Serves tag matches, queries and sorts on any single tag
+		name: "tags_wildcard",
+		keys: bson.D{{Key: TagsPrefix + "$**", Value: 1}},
+	},
+	{
+		// Songs without a URI don't conflict. Soft deleted songs keep their
+		// URI until they are purged
+		name:    uriIndexName,
+		keys:    bson.D{{Key: uriPath, Value: 1}},
+		unique:  true,
+		partial: bson.D{{Key: uriPath, Value: bson.D{{Key: "$exists", Value: true}}}},
+	},
+	{
+		name: "name_id",
+		keys: bson.D{{Key: namePath, Value: 1}, {Key: "_id", Value: 1}},
+	},
+	{
+		// Finds the songs to purge
+		name: "deleted_at",
+		keys: bson.D{{Key: deletedAtField, Value: 1}},
+	},
+}
+
+// declaredIndexes are the indexes of every collection by collection name.
+var declaredIndexes = map[string][]indexSpec{
+	songCollectionName:        songIndexes,
+	idempotencyCollectionName: idempotencyIndexes,
+	tagChangeCollectionName:   tagChangeIndexes,
+}
+
+// unmanagedIndexes are never reported as drift.
+var unmanagedIndexes = map[string]bool{
+	"_id_": true,
+}
+
+// searchIndex is the text index SearchSongs uses, over the name and the
+// tags with the given keys. Song names are in many languages, so words
+// aren't stemmed and there are no stop words.
+func searchIndex(tagKeys []string) indexSpec {
+	keys := bson.D{{Key: namePath, Value: "text"}}
+	weights := bson.D{{Key: namePath, Value: nameSearchWeight}}
+	for _, key := range tagKeys {
+		keys = append(keys, bson.E{Key: TagsPrefix + key, Value: "text"})
+		weights = append(weights, bson.E{Key: TagsPrefix + key, Value: 1})
+	}
+	return indexSpec{
+		name:            searchIndexName,
+		keys:            keys,
+		weights:         weights,
+		defaultLanguage: "none",
+	}
+}
+
+// mongoIndex is an index as listed by mongo.
+type mongoIndex struct {
+	Name        string `bson:"name"`
+	Keys        bson.D `bson:"key"`
+	Unique      bool   `bson:"unique"`
+	Partial     bson.D `bson:"partialFilterExpression"`
+	ExpireAfter *int64 `bson:"expireAfterSeconds"`
+	Weights     bson.D `bson:"weights"`
+	Language    string `bson:"default_language"`
+}
+
+// indexDrift compares the declared indexes of a collection with the
+// existing ones.
+func indexDrift(collection string, specs []indexSpec, existing []*mongoIndex) []*IndexDrift {
+	byName := make(map[string]*mongoIndex, len(existing))
+	for _, index := range existing {
+		byName[index.Name] = index
+	}
+
+	drift := make([]*IndexDrift, 0)
+	declared := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		declared[spec.name] = true
+		index, ok := byName[spec.name]
+		var kind IndexDriftKind
+		var detail string
+		switch {
+		case !ok:
+			kind, detail = IndexMissing, fmt.Sprintf("keys %v", spec.keys)
+		case spec.weights == nil && !sameDocument(index.Keys, spec.keys):
+			kind, detail = IndexChanged, fmt.Sprintf("keys are %v, want %v", index.Keys, spec.keys)
+		case spec.weights != nil && !sameWeights(index.Weights, spec.weights):
+			kind, detail = IndexChanged, fmt.Sprintf("weights are %v, want %v", index.Weights, spec.weights)
+		case index.Language != spec.defaultLanguage:
+			kind, detail = IndexChanged, fmt.Sprintf("default language is %q, want %q", index.Language, spec.defaultLanguage)
+		case index.Unique != spec.unique:
+			kind, detail = IndexChanged, fmt.Sprintf("unique is %v, want %v", index.Unique, spec.unique)
+		case !sameDocument(index.Partial, spec.partial):
+			kind, detail = IndexChanged, fmt.Sprintf("partial filter is %v, want %v", index.Partial, spec.partial)
+		case !sameExpiry(index.ExpireAfter, spec.expireAfter):
+			kind, detail = IndexChanged, fmt.Sprintf("expiry is %v, want %v", formatExpiry(index.ExpireAfter), formatExpiry(spec.expireAfter))
+		default:
+			continue
+		}
+		drift = append(drift, &IndexDrift{Collection: collection, Name: spec.name, Kind: kind, Detail: detail})
+	}
+
+	for _, index := range existing {
+		if !declared[index.Name] && !unmanagedIndexes[index.Name] {
+			drift = append(drift, &IndexDrift{Collection: collection, Name: index.Name, Kind: IndexUnmanaged, Detail: fmt.Sprintf("keys %v", index.Keys)})
+		}
+	}
+	return drift
+}
+
+// sameWeights compares the weights of text indexes, which mongo lists
+// sorted by field.
+func sameWeights(a, b bson.D) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	weights := make(map[string]interface{}, len(a))
+	for _, e := range a {
+		weights[e.Key] = e.Value
+	}
+	for _, e := range b {
+		weight, ok := weights[e.Key]
+		if !ok || !sameValue(weight, e.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameExpiry(a, b *int64) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
+
+func formatExpiry(seconds *int64) string {
+	if seconds == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%vs", *seconds)
+}
+
+// sameDocument compares documents field by field in order. Mongo may list
+// numbers with another type than they were declared with.
+func sameDocument(a, b bson.D) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Key != b[i].Key || !sameValue(a[i].Value, b[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameValue(a, b interface{}) bool {
+	if docA, ok := a.(bson.D); ok {
+		docB, ok := b.(bson.D)
+		return ok && sameDocument(docA, docB)
+	}
+	numberA, okA := indexNumber(a)
+	numberB, okB := indexNumber(b)
+	if okA && okB {
+		return numberA == numberB
+	}
+	return a == b
+}
+
+func indexNumber(val interface{}) (float64, bool) {
+	switch n := val.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestSameDocument(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b bson.D
+		want bool
+	}{
+		{"both empty", nil, bson.D{}, true},
+		{"same", bson.D{{Key: "a", Value: 1}}, bson.D{{Key: "a", Value: 1}}, true},
+		{"numbers of other types", bson.D{{Key: "a", Value: int32(1)}}, bson.D{{Key: "a", Value: 1.0}}, true},
+		{"other number", bson.D{{Key: "a", Value: 1}}, bson.D{{Key: "a", Value: -1}}, false},
+		{"other order", bson.D{{Key: "a", Value: 1}, {Key: "b", Value: 1}}, bson.D{{Key: "b", Value: 1}, {Key: "a", Value: 1}}, false},
+		{"missing field", bson.D{{Key: "a", Value: 1}}, bson.D{{Key: "a", Value: 1}, {Key: "b", Value: 1}}, false},
+		{"number and string", bson.D{{Key: "a", Value: 1}}, bson.D{{Key: "a", Value: "1"}}, false},
+		{
+			"nested",
+			bson.D{{Key: "uri", Value: bson.D{{Key: "$exists", Value: true}}}},
+			bson.D{{Key: "uri", Value: bson.D{{Key: "$exists", Value: true}}}},
+			true,
+		},
+		{
+			"nested differs",
+			bson.D{{Key: "uri", Value: bson.D{{Key: "$exists", Value: true}}}},
+			bson.D{{Key: "uri", Value: bson.D{{Key: "$exists", Value: false}}}},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameDocument(tt.a, tt.b); got != tt.want {
+				t.Errorf("sameDocument(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexDrift(t *testing.T) {
+	expiry := int64(60)
+	otherExpiry := int64(120)
+	specs := []indexSpec{
+		{name: "name_id", keys: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}},
+		{name: "uri_unique", keys: bson.D{{Key: "uri", Value: 1}}, unique: true},
+		{name: "expiry", keys: bson.D{{Key: "createdAt", Value: 1}}, expireAfter: &expiry},
+		searchIndex([]string{"artist"}),
+	}
+	// Mongo lists the keys of text indexes in another form and their
+	// weights sorted by field
+	synced := []*mongoIndex{
+		{Name: "_id_", Keys: bson.D{{Key: "_id", Value: int32(1)}}},
+		{Name: "name_id", Keys: bson.D{{Key: "name", Value: int32(1)}, {Key: "_id", Value: int32(1)}}},
+		{Name: "uri_unique", Keys: bson.D{{Key: "uri", Value: int32(1)}}, Unique: true},
+		{Name: "expiry", Keys: bson.D{{Key: "createdAt", Value: int32(1)}}, ExpireAfter: &expiry},
+		{
+			Name:     searchIndexName,
+			Keys:     bson.D{{Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: int32(1)}},
+			Weights:  bson.D{{Key: "name", Value: int32(nameSearchWeight)}, {Key: "tags.artist", Value: int32(1)}},
+			Language: "none",
+		},
+	}
+
+	// changed returns synced with the index of the given name changed
+	changed := func(name string, change func(index *mongoIndex)) []*mongoIndex {
+		indexes := make([]*mongoIndex, len(synced))
+		for i, index := range synced {
+			copied := *index
+			if copied.Name == name {
+				change(&copied)
+			}
+			indexes[i] = &copied
+		}
+		return indexes
+	}
+
+	tests := []struct {
+		name     string
+		existing []*mongoIndex
+		want     []string
+	}{
+		{"in sync", synced, []string{}},
+		{"none", nil, []string{"missing name_id", "missing uri_unique", "missing expiry", "missing search"}},
+		{"other keys", changed("name_id", func(index *mongoIndex) {
+			index.Keys = bson.D{{Key: "name", Value: int32(-1)}, {Key: "_id", Value: int32(1)}}
+		}), []string{"changed name_id"}},
+		{"not unique", changed("uri_unique", func(index *mongoIndex) {
+			index.Unique = false
+		}), []string{"changed uri_unique"}},
+		{"partial", changed("uri_unique", func(index *mongoIndex) {
+			index.Partial = bson.D{{Key: "uri", Value: bson.D{{Key: "$exists", Value: true}}}}
+		}), []string{"changed uri_unique"}},
+		{"other expiry", changed("expiry", func(index *mongoIndex) {
+			index.ExpireAfter = &otherExpiry
+		}), []string{"changed expiry"}},
+		{"no expiry", changed("expiry", func(index *mongoIndex) {
+			index.ExpireAfter = nil
+		}), []string{"changed expiry"}},
+		{"other search tags", changed(searchIndexName, func(index *mongoIndex) {
+			index.Weights = bson.D{{Key: "name", Value: int32(nameSearchWeight)}, {Key: "tags.genre", Value: int32(1)}}
+		}), []string{"changed search"}},
+		{"search language", changed(searchIndexName, func(index *mongoIndex) {
+			index.Language = "english"
+		}), []string{"changed search"}},
+		{"unmanaged", append(synced[:len(synced):len(synced)], &mongoIndex{Name: "extra", Keys: bson.D{{Key: "mimeType", Value: int32(1)}}}), []string{"unmanaged extra"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, d := range indexDrift("songs", specs, tt.existing) {
+				got = append(got, fmt.Sprintf("%v %v", d.Kind, d.Name))
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("got drift %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
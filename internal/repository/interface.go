@@ -2,7 +2,10 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/TensorBeat/Datalake/internal/query"
 	"github.com/TensorBeat/Datalake/pkg/proto"
 )
 
@@ -12,17 +15,172 @@ type File struct {
 	Uri      string
 	MimeType string
 	Tags     map[string]string
+	// TypedTags holds the tags that aren't strings, the values are int64,
+	// float64, bool or time.Time. A tag is only ever in one of the two maps.
+	TypedTags map[string]interface{}
+	// Revision is 1 for new songs and grows with every change, songs
+	// written before revisions existed have 1 once BackfillRevisions ran.
+	Revision int64
 }
 
+// SongResult is the outcome of writing one song of a batch, ID is set when
+// the write succeeded and Err when it failed.
+type SongResult struct {
+	ID  string
+	Err error
+}
+
+// ErrSongSkipped is the SongResult error for songs AddSongs didn't try to
+// write because an earlier song failed.
+var ErrSongSkipped = errors.New("not added because an earlier song failed")
+
+// ErrUriTaken is the error for writes giving a song the URI of another song,
+// soft deleted songs keep their URI until they are purged.
+var ErrUriTaken = newError(ErrConflict, "song", "a song with the same uri already exists")
+
+// Repository is implemented by every backend. Their errors are of the kinds
+// in errors.go when the caller can do something about them.
 type Repository interface {
 	SongRepository
+	TagSchemaRepository
+	IdempotencyRepository
+	TagMigrationRepository
+	IndexManager
+}
+
+// TagMigrationRepository renames tag keys and remaps tag values of every
+// song in batches. Migrations are stored so they can be resumed and rolled
+// back.
+type TagMigrationRepository interface {
+	// PlanTagMigration counts the songs a migration would match without
+	// storing it or changing songs.
+	PlanTagMigration(ctx context.Context, spec *TagMigrationSpec) (*TagMigration, error)
+	// CreateTagMigration stores a running migration for RunTagMigration.
+	CreateTagMigration(ctx context.Context, spec *TagMigrationSpec) (*TagMigration, error)
+	GetTagMigration(ctx context.Context, id string) (*TagMigration, error)
+	// RunTagMigration migrates the songs after the cursor of a running
+	// migration batchSize at a time until it completes, calling progress
+	// after every batch. Runs that stopped early are resumed by calling it
+	// again.
+	RunTagMigration(ctx context.Context, id string, batchSize int64, progress func(migration *TagMigration) error) (*TagMigration, error)
+	// RollBackTagMigration stops a migration and restores the tags it
+	// changed, skipping songs whose tags changed since. It is resumed the
+	// same way.
+	RollBackTagMigration(ctx context.Context, id string, batchSize int64, progress func(migration *TagMigration) error) (*TagMigration, error)
+}
+
+// IdempotencyRepository keeps the responses of requests made with an
+// idempotency key for IdempotencyTTL.
+type IdempotencyRepository interface {
+	// ReserveIdempotencyKey stores record without a response unless a
+	// record with its key exists, which is returned instead. It returns nil
+	// when the key was reserved.
+	ReserveIdempotencyKey(ctx context.Context, record *IdempotencyRecord) (*IdempotencyRecord, error)
+	// RenewIdempotencyKey keeps a key reserved while its request runs, it
+	// is called more often than IdempotencyLockTimeout. Like the methods
+	// below, it does nothing unless owner still holds the reservation.
+	RenewIdempotencyKey(ctx context.Context, key string, owner string) error
+	// CompleteIdempotencyKey stores the response of a reserved key.
+	CompleteIdempotencyKey(ctx context.Context, key string, owner string, response []byte) error
+	// ReleaseIdempotencyKey removes a reserved key whose request failed so
+	// it can be retried.
+	ReleaseIdempotencyKey(ctx context.Context, key string, owner string) error
+}
+
+// IndexManager keeps the indexes of the backend in line with the ones its
+// queries rely on.
+type IndexManager interface {
+	// CheckIndexes reports how the indexes differ from the declared ones.
+	CheckIndexes(ctx context.Context) ([]*IndexDrift, error)
+	// SyncIndexes builds the missing indexes, changed and unmanaged indexes
+	// are left alone. It returns the drift found before syncing.
+	SyncIndexes(ctx context.Context) ([]*IndexDrift, error)
+	// RebuildIndexes also drops and builds again the changed indexes, the
+	// queries relying on them run without them meanwhile.
+	RebuildIndexes(ctx context.Context) ([]*IndexDrift, error)
 }
 
 type SongRepository interface {
-	AddSongs(ctx context.Context, songs []*File) error
-	GetSongsByTags(ctx context.Context, tags map[string]string, filter proto.Filter, pageToken int64, pageSize int64) ([]*File, int64, int64, error)
-	GetSongsByIDs(ctx context.Context, ids []string, pageToken int64, pageSize int64) ([]*File, int64, int64, error)
-	GetAllSongs(ctx context.Context, pageToken int64, pageSize int64) ([]*File, int64, int64, error)
-	AddTags(ctx context.Context, id string, tags map[string]string) error
-	RemoveTags(ctx context.Context, id string, tags map[string]string) error
+	// AddSongs writes songs in order and stops at the first failure. It
+	// reports a result per song in input order, the error is only set when
+	// the batch couldn't be written.
+	AddSongs(ctx context.Context, songs []*File) ([]*SongResult, error)
+	// IngestSongs writes every song it can and reports a result per song in
+	// input order. The error is only set when the batch couldn't be written.
+	IngestSongs(ctx context.Context, songs []*File) ([]*SongResult, error)
+	// UpsertSongs writes songs like AddSongs, but the tags of a song whose
+	// URI another song has are merged into that song, which keeps its other
+	// fields. The result has the ID of the song the tags were merged into.
+	UpsertSongs(ctx context.Context, songs []*File) ([]*SongResult, error)
+	// GetSongsByTags returns the songs matching the tags combined with filter.
+	GetSongsByTags(ctx context.Context, tags map[string]*TagMatch, filter proto.Filter, opts ListOptions) ([]*File, string, int64, error)
+	QuerySongs(ctx context.Context, q query.Node, opts ListOptions) ([]*File, string, int64, error)
+	// SearchSongs returns the songs with words of text in their name or the
+	// tags of the search index, best matches first.
+	SearchSongs(ctx context.Context, text string, pageToken string, pageSize int64) ([]*SearchResult, string, int64, error)
+	// SetSearchTags makes the tags with the given keys searchable next to
+	// the name, replacing the tags searched before. Backends with indexes
+	// only search them once SyncIndexes rebuilt the search index. Keys
+	// rejected by ValidateSearchTags are ErrInvalidArgument.
+	SetSearchTags(tagKeys []string) error
+	// GetTagFacets counts the songs per value of each key among the songs
+	// matching the tags, every song when there are no tags. Only the limit
+	// most frequent values of each key are returned.
+	GetTagFacets(ctx context.Context, keys []string, tags map[string]string, filter proto.Filter, limit int64) ([]*TagFacet, error)
+	// ListTagKeys returns the keys of the tags of every song in order with
+	// the number of songs having them. Backends may list a summary that is
+	// up to a minute old.
+	ListTagKeys(ctx context.Context, pageToken string, pageSize int64) ([]*TagKeyCount, string, error)
+	// ListTagValues returns the distinct values of a tag in sort order with
+	// the number of songs having them.
+	ListTagValues(ctx context.Context, key string, pageToken string, pageSize int64) ([]*FacetValue, string, error)
+	GetSongsByIDs(ctx context.Context, ids []string, opts ListOptions) ([]*File, string, int64, error)
+	GetAllSongs(ctx context.Context, opts ListOptions) ([]*File, string, int64, error)
+	// StreamSongsByTags calls send for every song matching the tags in ID
+	// order, starting after resumeToken. No tags matches every song.
+	StreamSongsByTags(ctx context.Context, tags map[string]string, filter proto.Filter, resumeToken string, send func(song *File, resumeToken string) error) error
+	// AddTags sets string and typed tags on a song, replacing the value of
+	// tags it already has whatever its type. It returns ErrSongNotFound for
+	// missing and deleted songs. Unless expectedRevision is 0 it returns a
+	// RevisionMismatchError when the song has another revision. It returns
+	// the new revision.
+	AddTags(ctx context.Context, id string, tags map[string]string, typedTags map[string]interface{}, expectedRevision int64) (int64, error)
+	// RemoveTags removes the tags with the given keys, the values are
+	// ignored. It checks expectedRevision like AddTags.
+	RemoveTags(ctx context.Context, id string, tags map[string]string, expectedRevision int64) (int64, error)
+	// BatchAddTags sets tags on every selected song like AddTags, a dry run
+	// only counts the selected songs.
+	BatchAddTags(ctx context.Context, selector SongSelector, tags map[string]string, typedTags map[string]interface{}, dryRun bool) (*BatchResult, error)
+	// BatchRemoveTags removes the tags with the given keys from every
+	// selected song, a dry run only counts the selected songs.
+	BatchRemoveTags(ctx context.Context, selector SongSelector, keys []string, dryRun bool) (*BatchResult, error)
+	// UpdateSong sets the fields named by paths on the song with the same ID
+	// and returns the updated song. The revision of song is checked like the
+	// expected revision of AddTags.
+	UpdateSong(ctx context.Context, song *File, paths []string) (*File, error)
+	// BackfillRevisions gives revision 1 to the songs stored before
+	// revisions existed, which read as revision 0 and so couldn't be written
+	// expecting a revision. It scans every song, so it is run once by the
+	// admin command rather than by the server. It returns how many songs it
+	// changed.
+	BackfillRevisions(ctx context.Context) (int64, error)
+	// DeleteSongsByIDs and DeleteSongsByTags soft delete songs, they return
+	// how many songs were deleted.
+	DeleteSongsByIDs(ctx context.Context, ids []string) (int64, error)
+	DeleteSongsByTags(ctx context.Context, tags map[string]string, filter proto.Filter) (int64, error)
+	// WatchSongs opens a feed of changes after resumeToken, or of changes
+	// from now on for the empty token.
+	WatchSongs(ctx context.Context, resumeToken string) (SongWatcher, error)
+	// PurgeDeletedSongs permanently removes songs deleted before deletedBefore.
+	PurgeDeletedSongs(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+type TagSchemaRepository interface {
+	// PutTagSchema creates or replaces the schema with the same key. It
+	// returns ErrTagSchemaConflict if a key only differing in case exists.
+	PutTagSchema(ctx context.Context, schema *TagSchema) error
+	// GetTagSchemas returns every schema ordered by key.
+	GetTagSchemas(ctx context.Context) ([]*TagSchema, error)
+	// DeleteTagSchema reports whether a schema was deleted.
+	DeleteTagSchema(ctx context.Context, key string) (bool, error)
 }
@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/TensorBeat/Datalake/pkg/proto"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// Mongo runs regular expressions with a backtracking engine so their
+	// size and shape are limited
+	maxRegexLength      = 256
+	maxRegexRepeat      = 100
+	maxRegexRepetitions = 4
+	// maxMongoRegexLength bounds patterns once their classes are expanded
+	// for mongo
+	maxMongoRegexLength = 16384
+	maxInValues         = 1000
+)
+
+// TagMatch matches the value of one tag. Value is used by every mode but IN,
+// which uses Values. An EXACT match of existsCharacter matches any value.
+type TagMatch struct {
+	Mode   proto.MatchMode
+	Value  string
+	Values []string
+}
+
+// ExactMatches converts tags to EXACT matches.
+func ExactMatches(tags map[string]string) map[string]*TagMatch {
+	matches := make(map[string]*TagMatch, len(tags))
+	for tagName, val := range tags {
+		matches[tagName] = &TagMatch{Mode: proto.MatchMode_EXACT, Value: val}
+	}
+	return matches
+}
+
+// ValidateTagMatches checks the tags songs are listed by, at least one is
+// required.
+func ValidateTagMatches(tags map[string]*TagMatch) error {
+	if len(tags) == 0 {
+		return errors.New("at least one tag is required")
+	}
+	for tagName, match := range tags {
+		if err := ValidateTagKey(tagName); err != nil {
+			return err
+		}
+		if err := ValidateTagMatch(match); err != nil {
+			return fmt.Errorf("tag %v: %v", tagName, err)
+		}
+	}
+	return nil
+}
+
+// ValidateTagMatch checks that a match can be run.
+func ValidateTagMatch(match *TagMatch) error {
+	switch match.Mode {
+	case proto.MatchMode_EXACT, proto.MatchMode_CASE_INSENSITIVE:
+		return nil
+	case proto.MatchMode_PREFIX:
+		if match.Value == "" {
+			return fmt.Errorf("prefix can't be empty, use %v to match any value", existsCharacter)
+		}
+		return nil
+	case proto.MatchMode_REGEX:
+		return validateRegex(match.Value)
+	case proto.MatchMode_IN:
+		if len(match.Values) == 0 || len(match.Values) > maxInValues {
+			return fmt.Errorf("IN needs between 1 and %v values", maxInValues)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown match mode %v", match.Mode)
+}
+
+// validateRegex accepts the RE2 patterns mongo runs the same way once
+// converted by mongoRegex, which rules out backreferences and lookaround,
+// and that can't make its backtracking explode.
+func validateRegex(pattern string) error {
+	if len(pattern) > maxRegexLength {
+		return fmt.Errorf("regular expression is longer than %v characters", maxRegexLength)
+	}
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return fmt.Errorf("invalid regular expression: %v", err)
+	}
+	repetitions := 0
+	if err := checkRegex(re, false, &repetitions); err != nil {
+		return err
+	}
+	if len(mongoRegex(pattern)) > maxMongoRegexLength {
+		return errors.New("regular expression is too complex")
+	}
+	return nil
+}
+
+// checkRegex rejects the repetitions that make backtracking explode and
+// multi-line mode, keeping patterns to the subset both engines agree on.
+func checkRegex(re *syntax.Regexp, repeated bool, repetitions *int) error {
+	switch re.Op {
+	case syntax.OpStar, syntax.OpPlus, syntax.OpRepeat:
+		if repeated {
+			return errors.New("regular expression has nested repetitions")
+		}
+		if re.Op == syntax.OpRepeat && (re.Min > maxRegexRepeat || re.Max > maxRegexRepeat) {
+			return fmt.Errorf("regular expression repeats more than %v times", maxRegexRepeat)
+		}
+		if *repetitions++; *repetitions > maxRegexRepetitions {
+			return fmt.Errorf("regular expression has more than %v repetitions", maxRegexRepetitions)
+		}
+		repeated = true
+	case syntax.OpAlternate:
+		if repeated {
+			return errors.New("regular expression repeats an alternation")
+		}
+	case syntax.OpBeginLine, syntax.OpEndLine:
+		return errors.New("regular expression uses multi-line mode")
+	}
+	for _, sub := range re.Sub {
+		if err := checkRegex(sub, repeated, repetitions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mongoRegex rewrites a valid pattern so that mongo's PCRE matches what RE2
+// matches. The pattern is printed from its parsed form, which spells out
+// the classes, and $ becomes \z as PCRE's $ also matches before a trailing
+// newline.
+func mongoRegex(pattern string) string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return pattern
+	}
+	clearWasDollar(re)
+	return re.Simplify().String()
+}
+
+func clearWasDollar(re *syntax.Regexp) {
+	re.Flags &^= syntax.WasDollar
+	for _, sub := range re.Sub {
+		clearWasDollar(sub)
+	}
+}
+
+// mongoMatch is the filter value matching a tag. Prefixes and case
+// insensitive matches are anchored regular expressions, mongo can only use
+// an index for the former.
+func mongoMatch(match *TagMatch) interface{} {
+	switch match.Mode {
+	case proto.MatchMode_PREFIX:
+		return primitive.Regex{Pattern: "^" + regexp.QuoteMeta(match.Value)}
+	case proto.MatchMode_CASE_INSENSITIVE:
+		return primitive.Regex{Pattern: "^" + regexp.QuoteMeta(match.Value) + `\z`, Options: "i"}
+	case proto.MatchMode_REGEX:
+		return primitive.Regex{Pattern: mongoRegex(match.Value)}
+	case proto.MatchMode_IN:
+		return bson.M{"$in": match.Values}
+	}
+
+	if match.Value == existsCharacter {
+		return bson.M{"$exists": true}
+	}
+	return match.Value
+}
+
+// tagsMatcher reports whether a song satisfies the tag matches the same way
+// the filter built by MongoRepository.GetSongsByTags would. Matches must be
+// valid.
+func tagsMatcher(matches map[string]*TagMatch, operator proto.Filter) func(file *File) bool {
+	matchers := make(map[string]func(file *File) bool, len(matches))
+	for tagName, match := range matches {
+		matchers[tagName] = valueMatcher(tagName, match)
+	}
+
+	return func(file *File) bool {
+		matched := 0
+		for _, matcher := range matchers {
+			if matcher(file) {
+				matched++
+			}
+		}
+
+		switch operator {
+		case proto.Filter_ALL:
+			return matched == len(matchers)
+		case proto.Filter_NONE:
+			return matched == 0
+		default:
+			return matched > 0
+		}
+	}
+}
+
+// valueMatcher matches string tags, only EXACT matches of existsCharacter
+// also match typed tags.
+func valueMatcher(tagName string, match *TagMatch) func(file *File) bool {
+	var matchValue func(val string) bool
+	switch match.Mode {
+	case proto.MatchMode_PREFIX:
+		matchValue = func(val string) bool {
+			return strings.HasPrefix(val, match.Value)
+		}
+	case proto.MatchMode_CASE_INSENSITIVE:
+		matchValue = func(val string) bool {
+			return strings.EqualFold(val, match.Value)
+		}
+	case proto.MatchMode_REGEX:
+		re := regexp.MustCompile(match.Value)
+		matchValue = re.MatchString
+	case proto.MatchMode_IN:
+		values := make(map[string]bool, len(match.Values))
+		for _, val := range match.Values {
+			values[val] = true
+		}
+		matchValue = func(val string) bool {
+			return values[val]
+		}
+	default:
+		if match.Value == existsCharacter {
+			return func(file *File) bool {
+				_, ok := file.Tags[tagName]
+				_, typed := file.TypedTags[tagName]
+				return ok || typed
+			}
+		}
+		matchValue = func(val string) bool {
+			return val == match.Value
+		}
+	}
+
+	return func(file *File) bool {
+		val, ok := file.Tags[tagName]
+		return ok && matchValue(val)
+	}
+}
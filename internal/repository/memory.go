@@ -0,0 +1,581 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TensorBeat/Datalake/internal/query"
+	"github.com/TensorBeat/Datalake/pkg/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// MemoryRepository is a Repository that keeps every song in process memory.
+// It mirrors the behaviour of MongoRepository and is meant for local
+// development and unit tests, nothing is persisted between runs.
+type MemoryRepository struct {
+	logger *zap.SugaredLogger
+
+	mu    sync.RWMutex
+	songs []*File
+	index map[string]int
+	// uris maps the URIs of songs to their IDs, like the unique index of
+	// mongo it includes soft deleted songs
+	uris map[string]string
+	// deleted holds the tombstones of soft deleted songs by ID
+	deleted map[string]time.Time
+	feed    *songFeed
+	// tagSchemas is keyed by the lower case key
+	tagSchemas map[string]*TagSchema
+	searchTags []string
+	// idempotencyKeys expire when they are reserved again, or are swept
+	// by a reservation of another key
+	idempotencyKeys    map[string]*IdempotencyRecord
+	idempotencySweptAt time.Time
+	tagMigrations      map[string]*memoryTagMigration
+}
+
+func NewMemoryRepository(logger *zap.SugaredLogger) *MemoryRepository {
+	return &MemoryRepository{
+		logger:  logger,
+		songs:   make([]*File, 0),
+		index:   make(map[string]int),
+		uris:    make(map[string]string),
+		deleted: make(map[string]time.Time),
+		feed:    newSongFeed(),
+
+		tagSchemas:      make(map[string]*TagSchema),
+		idempotencyKeys: make(map[string]*IdempotencyRecord),
+		tagMigrations:   make(map[string]*memoryTagMigration),
+	}
+}
+
+func (r *MemoryRepository) AddSongs(ctx context.Context, songs []*File) ([]*SongResult, error) {
+
+	return r.insertSongs(songs, true)
+
+}
+
+func (r *MemoryRepository) IngestSongs(ctx context.Context, songs []*File) ([]*SongResult, error) {
+
+	return r.insertSongs(songs, false)
+
+}
+
+func (r *MemoryRepository) insertSongs(songs []*File, ordered bool) ([]*SongResult, error) {
+	if err := validateSongTagKeys(songs); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make([]*SongResult, len(songs))
+	failed := false
+	for i, song := range songs {
+		if failed && ordered {
+			results[i] = &SongResult{Err: ErrSongSkipped}
+			continue
+		}
+
+		results[i] = r.insertSong(song)
+		failed = results[i].Err != nil
+	}
+
+	r.logger.Infof("Added songs to memory: %v", songs)
+
+	return results, nil
+}
+
+func (r *MemoryRepository) UpsertSongs(ctx context.Context, songs []*File) ([]*SongResult, error) {
+	if err := validateSongTagKeys(songs); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make([]*SongResult, len(songs))
+	failed := false
+	for i, song := range songs {
+		if failed {
+			results[i] = &SongResult{Err: ErrSongSkipped}
+			continue
+		}
+
+		id, ok := r.uris[song.Uri]
+		if song.Uri == "" || !ok || r.isDeleted(r.songs[r.index[id]]) {
+			results[i] = r.insertSong(song)
+			failed = results[i].Err != nil
+			continue
+		}
+
+		existing := r.songs[r.index[id]]
+		for tagName, val := range song.AllTags() {
+			setTag(existing, tagName, val)
+		}
+		results[i] = &SongResult{ID: id}
+		r.publishChange(SongTagsChanged, existing)
+	}
+
+	r.logger.Infof("Upserted songs to memory: %v", songs)
+
+	return results, nil
+}
+
+// insertSong adds a copy of song, callers must hold mu.
+func (r *MemoryRepository) insertSong(song *File) *SongResult {
+	id, err := primitive.ObjectIDFromHex(song.ID)
+	if err != nil {
+		id = primitive.NewObjectID()
+	}
+	if _, ok := r.index[id.Hex()]; ok {
+		return &SongResult{Err: fmt.Errorf("duplicate song ID: %v", id.Hex())}
+	}
+	if _, ok := r.uris[song.Uri]; ok && song.Uri != "" {
+		return &SongResult{Err: fmt.Errorf("%w: %v", ErrUriTaken, song.Uri)}
+	}
+
+	file := copyFile(song)
+	file.ID = id.Hex()
+	file.Revision = 1
+	r.index[file.ID] = len(r.songs)
+	r.songs = append(r.songs, file)
+	if file.Uri != "" {
+		r.uris[file.Uri] = file.ID
+	}
+	r.feed.publish(SongInserted, file)
+
+	return &SongResult{ID: file.ID}
+}
+
+func (r *MemoryRepository) GetSongsByTags(ctx context.Context, tags map[string]*TagMatch, operator proto.Filter, opts ListOptions) ([]*File, string, int64, error) {
+	if err := ValidateTagMatches(tags); err != nil {
+		r.logger.Error(err)
+		return nil, "", 0, invalidArgument(err)
+	}
+
+	return r.getSongs(tagsMatcher(tags, operator), opts)
+}
+
+func (r *MemoryRepository) QuerySongs(ctx context.Context, q query.Node, opts ListOptions) ([]*File, string, int64, error) {
+
+	return r.getSongs(func(file *File) bool {
+		return query.Match(q, file.AllTags())
+	}, opts)
+
+}
+
+func (r *MemoryRepository) GetSongsByIDs(ctx context.Context, ids []string, opts ListOptions) ([]*File, string, int64, error) {
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		mongoID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			r.logger.Errorf("bad ID: %v", err)
+			return nil, "", 0, invalidID(err)
+		}
+		wanted[mongoID.Hex()] = true
+	}
+
+	return r.getSongs(func(file *File) bool {
+		return wanted[file.ID]
+	}, opts)
+}
+
+func (r *MemoryRepository) GetAllSongs(ctx context.Context, opts ListOptions) ([]*File, string, int64, error) {
+
+	return r.getSongs(func(*File) bool { return true }, opts)
+
+}
+
+func (r *MemoryRepository) getSongs(match func(*File) bool, opts ListOptions) ([]*File, string, int64, error) {
+	if err := ValidateSortOrder(opts.Sort); err != nil {
+		r.logger.Error(err)
+		return nil, "", 0, invalidArgument(err)
+	}
+	if err := ValidateReadPaths(opts.Fields); err != nil {
+		r.logger.Error(err)
+		return nil, "", 0, invalidArgument(err)
+	}
+	cursor, err := decodeSortedPageToken(opts.PageToken, opts.Sort)
+	if err != nil {
+		r.logger.Errorf("%v: %v", err, opts.PageToken)
+		return nil, "", 0, err
+	}
+	pageSize, err := normalizePageSize(opts.PageSize)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, "", 0, invalidArgument(err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	files := make([]*File, 0)
+	for _, song := range r.songs {
+		if r.isDeleted(song) || !match(song) {
+			continue
+		}
+		count++
+		if cursor == nil || compareSorted(opts.Sort, sortValue(song, opts.Sort), song.ID, cursor.Value, cursor.ID.Hex()) > 0 {
+			files = append(files, song)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return compareSorted(opts.Sort, sortValue(files[i], opts.Sort), files[i].ID, sortValue(files[j], opts.Sort), files[j].ID) < 0
+	})
+
+	var nextToken string
+	if int64(len(files)) > pageSize {
+		files = files[:pageSize]
+		last := files[pageSize-1]
+		id, _ := primitive.ObjectIDFromHex(last.ID)
+		nextToken = encodePageToken(pageCursor{
+			ID:    id,
+			Sort:  opts.Sort.String(),
+			Value: sortValue(last, opts.Sort),
+		})
+	}
+
+	page := make([]*File, len(files))
+	for i, file := range files {
+		page[i] = copyFile(file)
+		projectFile(page[i], opts.Fields)
+	}
+
+	return page, nextToken, count, nil
+}
+
+func (r *MemoryRepository) StreamSongsByTags(ctx context.Context, tags map[string]string, operator proto.Filter, resumeToken string, send func(song *File, resumeToken string) error) error {
+	cursor, err := decodePageToken(resumeToken)
+	if err != nil {
+		r.logger.Errorf("%v: %v", err, resumeToken)
+		return err
+	}
+
+	match := tagsMatcher(ExactMatches(tags), operator)
+
+	// Take a snapshot so the lock isn't held while waiting on send
+	r.mu.RLock()
+	files := make([]*File, 0)
+	for _, song := range r.songs {
+		if r.isDeleted(song) || len(tags) > 0 && !match(song) {
+			continue
+		}
+		if cursor == nil || song.ID > cursor.ID.Hex() {
+			files = append(files, copyFile(song))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ID < files[j].ID
+	})
+
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		id, _ := primitive.ObjectIDFromHex(file.ID)
+		if err := send(file, encodePageToken(pageCursor{ID: id})); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *MemoryRepository) AddTags(ctx context.Context, id string, tags map[string]string, typedTags map[string]interface{}, expectedRevision int64) (int64, error) {
+	mongoID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		r.logger.Errorf("bad ID: %v", err)
+		return 0, invalidID(err)
+	}
+	if err := ValidateChangedTagKeys(tagKeys(mergeTags(tags, typedTags))); err != nil {
+		r.logger.Error(err)
+		return 0, invalidArgument(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	song, err := r.liveSong(mongoID.Hex(), expectedRevision)
+	if err != nil {
+		return 0, err
+	}
+	for tagName, val := range tags {
+		setTag(song, tagName, val)
+	}
+	for tagName, val := range typedTags {
+		setTag(song, tagName, val)
+	}
+	r.publishChange(SongTagsChanged, song)
+
+	return song.Revision, nil
+}
+
+func (r *MemoryRepository) RemoveTags(ctx context.Context, id string, tags map[string]string, expectedRevision int64) (int64, error) {
+	mongoID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		r.logger.Errorf("bad ID: %v", err)
+		return 0, invalidID(err)
+	}
+	if err := ValidateChangedTagKeys(tagKeys(mergeTags(tags, nil))); err != nil {
+		r.logger.Error(err)
+		return 0, invalidArgument(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	song, err := r.liveSong(mongoID.Hex(), expectedRevision)
+	if err != nil {
+		return 0, err
+	}
+	for tagName := range tags {
+		delete(song.Tags, tagName)
+		delete(song.TypedTags, tagName)
+	}
+	r.publishChange(SongTagsChanged, song)
+
+	return song.Revision, nil
+}
+
+// BackfillRevisions has nothing to do, every song gets a revision when it
+// is added.
+func (r *MemoryRepository) BackfillRevisions(ctx context.Context) (int64, error) {
+
+	return 0, nil
+
+}
+
+func (r *MemoryRepository) UpdateSong(ctx context.Context, song *File, paths []string) (*File, error) {
+	mongoID, err := primitive.ObjectIDFromHex(song.ID)
+	if err != nil {
+		r.logger.Errorf("bad ID: %v", err)
+		return nil, invalidID(err)
+	}
+	if err := ValidateUpdatePaths(paths); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+	if err := ValidateTagKeys(song.Tags, song.TypedTags); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, err := r.liveSong(mongoID.Hex(), song.Revision)
+	if err != nil {
+		return nil, err
+	}
+	i := r.index[current.ID]
+
+	for _, path := range paths {
+		if path != uriPath || song.Uri == "" {
+			continue
+		}
+		if id, ok := r.uris[song.Uri]; ok && id != mongoID.Hex() {
+			return nil, fmt.Errorf("%w: %v", ErrUriTaken, song.Uri)
+		}
+	}
+
+	// Apply the update to a copy so the stored song changes all at once
+	updated := copyFile(r.songs[i])
+	for _, path := range paths {
+		switch path {
+		case namePath:
+			updated.Name = song.Name
+		case uriPath:
+			updated.Uri = song.Uri
+		case mimeTypePath:
+			updated.MimeType = song.MimeType
+		case TagsPath:
+			replacement := copyFile(song)
+			updated.Tags, updated.TypedTags = replacement.Tags, replacement.TypedTags
+		default:
+			tagName := strings.TrimPrefix(path, TagsPrefix)
+			if val, ok := song.AllTags()[tagName]; ok {
+				setTag(updated, tagName, val)
+			} else {
+				delete(updated.Tags, tagName)
+				delete(updated.TypedTags, tagName)
+			}
+		}
+	}
+	if updated.Uri != r.songs[i].Uri {
+		delete(r.uris, r.songs[i].Uri)
+		if updated.Uri != "" {
+			r.uris[updated.Uri] = updated.ID
+		}
+	}
+	r.songs[i] = updated
+	r.publishChange(updateEventType(paths), updated)
+
+	return copyFile(updated), nil
+}
+
+func (r *MemoryRepository) DeleteSongsByIDs(ctx context.Context, ids []string) (int64, error) {
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		mongoID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			r.logger.Errorf("bad ID: %v", err)
+			return 0, invalidID(err)
+		}
+		wanted[mongoID.Hex()] = true
+	}
+
+	return r.deleteSongs(func(file *File) bool {
+		return wanted[file.ID]
+	}), nil
+}
+
+func (r *MemoryRepository) DeleteSongsByTags(ctx context.Context, tags map[string]string, operator proto.Filter) (int64, error) {
+	if len(tags) == 0 {
+		err := errors.New("at least one tag is required to delete songs by tags")
+		r.logger.Error(err)
+		return 0, invalidArgument(err)
+	}
+
+	return r.deleteSongs(tagsMatcher(ExactMatches(tags), operator)), nil
+}
+
+func (r *MemoryRepository) deleteSongs(match func(*File) bool) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC()
+	var count int64
+	for _, song := range r.songs {
+		if !r.isDeleted(song) && match(song) {
+			r.deleted[song.ID] = now
+			r.publishChange(SongDeleted, song)
+			count++
+		}
+	}
+
+	r.logger.Infof("Deleted %v songs in memory", count)
+
+	return count
+}
+
+func (r *MemoryRepository) WatchSongs(ctx context.Context, resumeToken string) (SongWatcher, error) {
+	watcher, err := r.feed.watch(resumeToken)
+	if err != nil {
+		r.logger.Errorf("Failed to watch songs: %v", err)
+		return nil, err
+	}
+
+	return watcher, nil
+}
+
+func (r *MemoryRepository) PurgeDeletedSongs(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	songs := make([]*File, 0, len(r.songs))
+	var count int64
+	for _, song := range r.songs {
+		if deletedAt, ok := r.deleted[song.ID]; ok && deletedAt.Before(deletedBefore) {
+			delete(r.deleted, song.ID)
+			delete(r.uris, song.Uri)
+			count++
+			continue
+		}
+		songs = append(songs, song)
+	}
+
+	r.songs = songs
+	r.index = make(map[string]int, len(songs))
+	for i, song := range songs {
+		r.index[song.ID] = i
+	}
+
+	r.logger.Infof("Purged %v songs deleted before %v", count, deletedBefore)
+
+	return count, nil
+}
+
+// liveSong returns the song with id unless it is deleted. Unless
+// expectedRevision is 0 the song must have that revision. Callers must hold
+// mu.
+func (r *MemoryRepository) liveSong(id string, expectedRevision int64) (*File, error) {
+	i, ok := r.index[id]
+	if !ok || r.isDeleted(r.songs[i]) {
+		return nil, ErrSongNotFound
+	}
+	song := r.songs[i]
+	if expectedRevision != 0 && song.Revision != expectedRevision {
+		return nil, &RevisionMismatchError{ID: id, Expected: expectedRevision, Current: song.Revision}
+	}
+	return song, nil
+}
+
+// publishChange increments the revision of a changed song and publishes the
+// change, callers must hold mu.
+func (r *MemoryRepository) publishChange(eventType SongEventType, song *File) {
+	song.Revision++
+	r.feed.publish(eventType, song)
+}
+
+// isDeleted reports whether song has a tombstone, callers must hold mu.
+func (r *MemoryRepository) isDeleted(song *File) bool {
+	_, ok := r.deleted[song.ID]
+	return ok
+}
+
+// setTag sets a tag in the map matching the type of val and removes it from
+// the other one.
+func setTag(file *File, tagName string, val interface{}) {
+	if s, ok := val.(string); ok {
+		if file.Tags == nil {
+			file.Tags = make(map[string]string)
+		}
+		file.Tags[tagName] = s
+		delete(file.TypedTags, tagName)
+		return
+	}
+	if file.TypedTags == nil {
+		file.TypedTags = make(map[string]interface{})
+	}
+	file.TypedTags[tagName] = storedTagValue(val)
+	delete(file.Tags, tagName)
+}
+
+func copyFile(file *File) *File {
+	var tags map[string]string
+	if len(file.Tags) > 0 {
+		tags = make(map[string]string, len(file.Tags))
+		for k, v := range file.Tags {
+			tags[k] = v
+		}
+	}
+	var typedTags map[string]interface{}
+	if len(file.TypedTags) > 0 {
+		typedTags = make(map[string]interface{}, len(file.TypedTags))
+		for k, v := range file.TypedTags {
+			typedTags[k] = storedTagValue(v)
+		}
+	}
+
+	return &File{
+		ID:        file.ID,
+		Name:      file.Name,
+		Uri:       file.Uri,
+		MimeType:  file.MimeType,
+		Tags:      tags,
+		TypedTags: typedTags,
+		Revision:  file.Revision,
+	}
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/TensorBeat/Datalake/internal/query"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (r *MemoryRepository) BatchAddTags(ctx context.Context, selector SongSelector, tags map[string]string, typedTags map[string]interface{}, dryRun bool) (*BatchResult, error) {
+	all := mergeTags(tags, typedTags)
+	if err := ValidateChangedTagKeys(tagKeys(all)); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	return r.batchUpdate(selector, dryRun, func(song *File) bool {
+		modified := false
+		current := song.AllTags()
+		for tagName, val := range all {
+			// Like mongo's $ne, equal numbers and instants are the same
+			if old, ok := current[tagName]; !ok || groupKey(old) != groupKey(storedTagValue(val)) {
+				setTag(song, tagName, val)
+				modified = true
+			}
+		}
+		return modified
+	})
+}
+
+func (r *MemoryRepository) BatchRemoveTags(ctx context.Context, selector SongSelector, keys []string, dryRun bool) (*BatchResult, error) {
+	if err := ValidateChangedTagKeys(keys); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	return r.batchUpdate(selector, dryRun, func(song *File) bool {
+		modified := false
+		current := song.AllTags()
+		for _, key := range keys {
+			if _, ok := current[key]; ok {
+				delete(song.Tags, key)
+				delete(song.TypedTags, key)
+				modified = true
+			}
+		}
+		return modified
+	})
+}
+
+// batchUpdate calls update on every selected song, update reports whether
+// it changed the song.
+func (r *MemoryRepository) batchUpdate(selector SongSelector, dryRun bool, update func(song *File) bool) (*BatchResult, error) {
+	if err := ValidateSongSelector(selector); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	match := func(song *File) bool {
+		return query.Match(selector.Query, song.AllTags())
+	}
+	if selector.Query == nil {
+		wanted := make(map[string]bool, len(selector.IDs))
+		// Parsed like mongo does so IDs in upper case match too
+		for _, id := range selector.IDs {
+			mongoID, _ := primitive.ObjectIDFromHex(id)
+			wanted[mongoID.Hex()] = true
+		}
+		match = func(song *File) bool {
+			return wanted[song.ID]
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := &BatchResult{}
+	for _, song := range r.songs {
+		if r.isDeleted(song) || !match(song) {
+			continue
+		}
+		result.MatchedCount++
+		if !dryRun && update(song) {
+			result.ModifiedCount++
+			r.publishChange(SongTagsChanged, song)
+		}
+	}
+
+	r.logger.Infof("Updated the tags of %v of %v songs in memory", result.ModifiedCount, result.MatchedCount)
+
+	return result, nil
+}
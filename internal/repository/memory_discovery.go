@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"sort"
+)
+
+func (r *MemoryRepository) ListTagKeys(ctx context.Context, pageToken string, pageSize int64) ([]*TagKeyCount, string, error) {
+	cursor, err := decodeValuePageToken(pageToken, tagKeysList)
+	if err != nil {
+		r.logger.Errorf("%v: %v", err, pageToken)
+		return nil, "", err
+	}
+	pageSize, err = normalizePageSize(pageSize)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, "", invalidArgument(err)
+	}
+
+	r.mu.RLock()
+	counts := make(map[string]int64)
+	for _, song := range r.songs {
+		if r.isDeleted(song) {
+			continue
+		}
+		for key := range song.AllTags() {
+			counts[key]++
+		}
+	}
+	r.mu.RUnlock()
+
+	keys := make([]*TagKeyCount, 0, len(counts))
+	for key, count := range counts {
+		keys = append(keys, &TagKeyCount{Key: key, Count: count})
+	}
+	page, nextToken := pageTagKeys(keys, cursor, pageSize)
+
+	return page, nextToken, nil
+}
+
+func (r *MemoryRepository) ListTagValues(ctx context.Context, key string, pageToken string, pageSize int64) ([]*FacetValue, string, error) {
+	if err := ValidateTagKey(key); err != nil {
+		r.logger.Error(err)
+		return nil, "", invalidArgument(err)
+	}
+	cursor, err := decodeValuePageToken(pageToken, tagValuesList+key)
+	if err != nil {
+		r.logger.Errorf("%v: %v", err, pageToken)
+		return nil, "", err
+	}
+	pageSize, err = normalizePageSize(pageSize)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, "", invalidArgument(err)
+	}
+
+	r.mu.RLock()
+	counts := make(map[interface{}]*FacetValue)
+	for _, song := range r.songs {
+		if r.isDeleted(song) {
+			continue
+		}
+		val, ok := song.AllTags()[key]
+		if !ok {
+			continue
+		}
+		value, ok := counts[groupKey(val)]
+		if !ok {
+			value = &FacetValue{Value: val}
+			counts[groupKey(val)] = value
+		}
+		value.Count++
+	}
+	r.mu.RUnlock()
+
+	values := make([]*FacetValue, 0, len(counts))
+	for _, value := range counts {
+		values = append(values, value)
+	}
+	page, nextToken := pageTagValues(key, values, cursor, pageSize)
+
+	return page, nextToken, nil
+}
+
+// pageTagKeys sorts keys and returns the page after cursor.
+func pageTagKeys(keys []*TagKeyCount, cursor *pageCursor, pageSize int64) ([]*TagKeyCount, string) {
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Key < keys[j].Key
+	})
+	if cursor != nil {
+		after, _ := cursor.Value.(string)
+		keys = keys[sort.Search(len(keys), func(i int) bool {
+			return keys[i].Key > after
+		}):]
+	}
+
+	var nextToken string
+	if int64(len(keys)) > pageSize {
+		keys = keys[:pageSize]
+		nextToken = encodePageToken(pageCursor{Sort: tagKeysList, Value: keys[pageSize-1].Key})
+	}
+	return keys, nextToken
+}
+
+// pageTagValues sorts values in sort order and returns the page after
+// cursor.
+func pageTagValues(key string, values []*FacetValue, cursor *pageCursor, pageSize int64) ([]*FacetValue, string) {
+	sort.Slice(values, func(i, j int) bool {
+		return compareSortValues(values[i].Value, values[j].Value) < 0
+	})
+	if cursor != nil {
+		values = values[sort.Search(len(values), func(i int) bool {
+			return compareSortValues(values[i].Value, cursor.Value) > 0
+		}):]
+	}
+
+	var nextToken string
+	if int64(len(values)) > pageSize {
+		values = values[:pageSize]
+		nextToken = encodePageToken(pageCursor{Sort: tagValuesList + key, Value: values[pageSize-1].Value})
+	}
+	return values, nextToken
+}
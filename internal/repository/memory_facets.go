@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/TensorBeat/Datalake/pkg/proto"
+)
+
+func (r *MemoryRepository) GetTagFacets(ctx context.Context, keys []string, tags map[string]string, operator proto.Filter, limit int64) ([]*TagFacet, error) {
+	if err := ValidateFacets(keys, limit); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+	limit = normalizeFacetLimit(limit)
+
+	match := func(*File) bool { return true }
+	if len(tags) > 0 {
+		match = tagsMatcher(ExactMatches(tags), operator)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var total int64
+	// Values are reported as the first song holding them has them
+	counts := make([]map[interface{}]*FacetValue, len(keys))
+	for i := range counts {
+		counts[i] = make(map[interface{}]*FacetValue)
+	}
+	for _, song := range r.songs {
+		if r.isDeleted(song) || !match(song) {
+			continue
+		}
+		total++
+		all := song.AllTags()
+		for i, key := range keys {
+			val, ok := all[key]
+			if !ok {
+				continue
+			}
+			value, ok := counts[i][groupKey(val)]
+			if !ok {
+				value = &FacetValue{Value: val}
+				counts[i][groupKey(val)] = value
+			}
+			value.Count++
+		}
+	}
+
+	result := make([]*TagFacet, len(keys))
+	for i, key := range keys {
+		facet := &TagFacet{
+			Key:          key,
+			Values:       make([]*FacetValue, 0, len(counts[i])),
+			MissingCount: total,
+		}
+		for _, value := range counts[i] {
+			facet.Values = append(facet.Values, value)
+			facet.MissingCount -= value.Count
+		}
+		sortFacetValues(facet.Values)
+		if int64(len(facet.Values)) > limit {
+			for _, value := range facet.Values[limit:] {
+				facet.OtherCount += value.Count
+			}
+			facet.Values = facet.Values[:limit]
+		}
+		result[i] = facet
+	}
+
+	return result, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+func (r *MemoryRepository) ReserveIdempotencyKey(ctx context.Context, record *IdempotencyRecord) (*IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC()
+	r.sweepIdempotencyKeys(now)
+	if existing, ok := r.idempotencyKeys[record.Key]; ok && !existing.reservable(now) {
+		copied := *existing
+		return &copied, nil
+	}
+
+	r.idempotencyKeys[record.Key] = &IdempotencyRecord{
+		Key:         record.Key,
+		Method:      record.Method,
+		RequestHash: record.RequestHash,
+		Owner:       record.Owner,
+		CreatedAt:   now,
+		RenewedAt:   now,
+	}
+
+	return nil, nil
+}
+
+// sweepIdempotencyKeys removes the expired keys, at most once per
+// idempotencySweepInterval.
+func (r *MemoryRepository) sweepIdempotencyKeys(now time.Time) {
+	if now.Sub(r.idempotencySweptAt) < idempotencySweepInterval {
+		return
+	}
+	r.idempotencySweptAt = now
+
+	for key, record := range r.idempotencyKeys {
+		if now.Sub(record.CreatedAt) >= IdempotencyTTL {
+			delete(r.idempotencyKeys, key)
+		}
+	}
+}
+
+// reservedBy returns the record of key while owner holds its reservation,
+// callers must hold mu.
+func (r *MemoryRepository) reservedBy(key string, owner string) *IdempotencyRecord {
+	record, ok := r.idempotencyKeys[key]
+	if !ok || record.Owner != owner || record.Response != nil {
+		return nil
+	}
+	return record
+}
+
+func (r *MemoryRepository) RenewIdempotencyKey(ctx context.Context, key string, owner string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if record := r.reservedBy(key, owner); record != nil {
+		record.RenewedAt = time.Now().UTC()
+	}
+
+	return nil
+}
+
+func (r *MemoryRepository) CompleteIdempotencyKey(ctx context.Context, key string, owner string, response []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record := r.reservedBy(key, owner)
+	if record == nil {
+		r.logger.Warnf("Idempotency key %v was taken over before its response was stored", key)
+		return nil
+	}
+	record.Response = append([]byte(nil), response...)
+
+	return nil
+}
+
+func (r *MemoryRepository) ReleaseIdempotencyKey(ctx context.Context, key string, owner string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.reservedBy(key, owner) != nil {
+		delete(r.idempotencyKeys, key)
+	}
+
+	return nil
+}
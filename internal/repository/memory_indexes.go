@@ -0,0 +1,22 @@
+package repository
+
+import "context"
+
+// CheckIndexes never reports drift, every read scans the songs.
+func (r *MemoryRepository) CheckIndexes(ctx context.Context) ([]*IndexDrift, error) {
+
+	return make([]*IndexDrift, 0), nil
+
+}
+
+func (r *MemoryRepository) SyncIndexes(ctx context.Context) ([]*IndexDrift, error) {
+
+	return make([]*IndexDrift, 0), nil
+
+}
+
+func (r *MemoryRepository) RebuildIndexes(ctx context.Context) ([]*IndexDrift, error) {
+
+	return make([]*IndexDrift, 0), nil
+
+}
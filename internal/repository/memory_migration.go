@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryTagMigration struct {
+	migration *TagMigration
+	// changes are kept in the order they were made, the first rolledBack
+	// of them were rolled back
+	changes    []*memoryTagChange
+	rolledBack int
+}
+
+type memoryTagChange struct {
+	songID string
+	change *tagChange
+}
+
+func (r *MemoryRepository) PlanTagMigration(ctx context.Context, spec *TagMigrationSpec) (*TagMigration, error) {
+	if err := ValidateTagMigration(spec); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	plan := &TagMigration{TagMigrationSpec: *spec, State: MigrationPlanned}
+	for _, song := range r.songs {
+		if r.isDeleted(song) {
+			continue
+		}
+		change, conflict := spec.change(song.AllTags())
+		if change != nil || conflict {
+			plan.MatchedCount++
+		}
+		if conflict {
+			plan.ConflictCount++
+		}
+	}
+	return plan, nil
+}
+
+func (r *MemoryRepository) CreateTagMigration(ctx context.Context, spec *TagMigrationSpec) (*TagMigration, error) {
+	if err := ValidateTagMigration(spec); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	now := time.Now().UTC()
+	migration := &TagMigration{
+		ID:               primitive.NewObjectID().Hex(),
+		TagMigrationSpec: *spec,
+		State:            MigrationRunning,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tagMigrations[migration.ID] = &memoryTagMigration{migration: migration}
+	copied := *migration
+	return &copied, nil
+}
+
+func (r *MemoryRepository) GetTagMigration(ctx context.Context, id string) (*TagMigration, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		r.logger.Errorf("bad ID: %v", err)
+		return nil, invalidID(err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.tagMigrations[id]
+	if !ok {
+		return nil, ErrTagMigrationNotFound
+	}
+	copied := *stored.migration
+	return &copied, nil
+}
+
+func (r *MemoryRepository) RunTagMigration(ctx context.Context, id string, batchSize int64, progress func(migration *TagMigration) error) (*TagMigration, error) {
+	if err := ValidateMigrationBatchSize(batchSize); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+	batchSize = normalizeMigrationBatchSize(batchSize)
+
+	return r.runMigrationBatches(ctx, id, progress, func(stored *memoryTagMigration) (bool, error) {
+		migration := stored.migration
+		if migration.State != MigrationRunning {
+			return false, ErrTagMigrationState
+		}
+
+		batch := make([]*File, 0)
+		for _, song := range r.songs {
+			if !r.isDeleted(song) && song.ID > migration.Cursor {
+				batch = append(batch, song)
+			}
+		}
+		sort.Slice(batch, func(i, j int) bool {
+			return batch[i].ID < batch[j].ID
+		})
+
+		done := int64(len(batch)) <= batchSize
+		if !done {
+			batch = batch[:batchSize]
+		}
+		for _, song := range batch {
+			change, conflict := migration.change(song.AllTags())
+			if change != nil || conflict {
+				migration.MatchedCount++
+			}
+			if conflict {
+				migration.ConflictCount++
+			}
+			if change != nil && r.applyTagChange(song, change) {
+				migration.ModifiedCount++
+				stored.changes = append(stored.changes, &memoryTagChange{songID: song.ID, change: change})
+			}
+		}
+
+		if done {
+			migration.State = MigrationCompleted
+		} else {
+			migration.Cursor = batch[len(batch)-1].ID
+		}
+		return done, nil
+	})
+}
+
+func (r *MemoryRepository) RollBackTagMigration(ctx context.Context, id string, batchSize int64, progress func(migration *TagMigration) error) (*TagMigration, error) {
+	if err := ValidateMigrationBatchSize(batchSize); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+	batchSize = normalizeMigrationBatchSize(batchSize)
+
+	return r.runMigrationBatches(ctx, id, progress, func(stored *memoryTagMigration) (bool, error) {
+		migration := stored.migration
+		if migration.State == MigrationRolledBack || migration.State == MigrationPlanned {
+			return false, ErrTagMigrationState
+		}
+		migration.State = MigrationRollingBack
+
+		start := stored.rolledBack
+		end := start + int(batchSize)
+		done := end >= len(stored.changes)
+		if done {
+			end = len(stored.changes)
+		}
+
+		for _, change := range stored.changes[start:end] {
+			song, ok := r.index[change.songID]
+			if ok && !r.isDeleted(r.songs[song]) && r.applyTagChange(r.songs[song], change.change.reverse()) {
+				migration.RolledBackCount++
+			} else {
+				migration.RollbackConflictCount++
+			}
+		}
+
+		stored.rolledBack = end
+		if end > start {
+			migration.RollbackCursor = stored.changes[end-1].songID
+		}
+		if done {
+			migration.State = MigrationRolledBack
+		}
+		return done, nil
+	})
+}
+
+// runMigrationBatches calls batch with the lock held until it reports it
+// is done, calling progress after each batch without the lock.
+func (r *MemoryRepository) runMigrationBatches(ctx context.Context, id string, progress func(migration *TagMigration) error, batch func(stored *memoryTagMigration) (bool, error)) (*TagMigration, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		r.logger.Errorf("bad ID: %v", err)
+		return nil, invalidID(err)
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		r.mu.Lock()
+		stored, ok := r.tagMigrations[id]
+		if !ok {
+			r.mu.Unlock()
+			return nil, ErrTagMigrationNotFound
+		}
+		done, err := batch(stored)
+		if err != nil {
+			r.mu.Unlock()
+			r.logger.Errorf("Failed to run tag migration %v: %v", id, err)
+			return nil, err
+		}
+		stored.migration.UpdatedAt = time.Now().UTC()
+		copied := *stored.migration
+		r.mu.Unlock()
+
+		if err := progress(&copied); err != nil {
+			return nil, err
+		}
+		if done {
+			r.logger.Infof("Finished tag migration %v in state %v", id, copied.State)
+			return &copied, nil
+		}
+	}
+}
+
+// applyTagChange changes the tags of song if they still have the values
+// change expects before, it reports whether it did.
+func (r *MemoryRepository) applyTagChange(song *File, change *tagChange) bool {
+	tags := song.AllTags()
+	for _, key := range change.keys() {
+		val, ok := tags[key]
+		before, expected := change.Before[key]
+		if ok != expected || ok && val != before {
+			return false
+		}
+	}
+
+	for _, key := range change.keys() {
+		if val, ok := change.After[key]; ok {
+			setTag(song, key, val)
+		} else {
+			delete(song.Tags, key)
+			delete(song.TypedTags, key)
+		}
+	}
+	r.publishChange(SongTagsChanged, song)
+	return true
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+func (r *MemoryRepository) PutTagSchema(ctx context.Context, schema *TagSchema) error {
+	if err := ValidateTagSchema(schema); err != nil {
+		r.logger.Error(err)
+		return invalidArgument(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	folded := strings.ToLower(schema.Key)
+	if existing, ok := r.tagSchemas[folded]; ok && existing.Key != schema.Key {
+		return ErrTagSchemaConflict
+	}
+	r.tagSchemas[folded] = copyTagSchema(schema)
+
+	r.logger.Infof("Put tag schema %v", schema.Key)
+
+	return nil
+}
+
+func (r *MemoryRepository) GetTagSchemas(ctx context.Context) ([]*TagSchema, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schemas := make([]*TagSchema, 0, len(r.tagSchemas))
+	for _, schema := range r.tagSchemas {
+		schemas = append(schemas, copyTagSchema(schema))
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].Key < schemas[j].Key
+	})
+
+	return schemas, nil
+}
+
+func (r *MemoryRepository) DeleteTagSchema(ctx context.Context, key string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	folded := strings.ToLower(key)
+	if existing, ok := r.tagSchemas[folded]; !ok || existing.Key != key {
+		return false, nil
+	}
+	delete(r.tagSchemas, folded)
+
+	return true, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (r *MemoryRepository) SetSearchTags(tagKeys []string) error {
+	if err := ValidateSearchTags(tagKeys); err != nil {
+		r.logger.Error(err)
+		return invalidArgument(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.searchTags = append([]string(nil), tagKeys...)
+	return nil
+}
+
+func (r *MemoryRepository) SearchSongs(ctx context.Context, text string, pageToken string, pageSize int64) ([]*SearchResult, string, int64, error) {
+	cursor, err := decodeSearchPageToken(pageToken)
+	if err != nil {
+		r.logger.Errorf("%v: %v", err, pageToken)
+		return nil, "", 0, err
+	}
+	pageSize, err = normalizePageSize(pageSize)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, "", 0, invalidArgument(err)
+	}
+	// Like mongo, only excluding words matches nothing
+	search := parseSearchText(text)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	weights := make([]float64, len(r.searchTags)+1)
+	weights[0] = nameSearchWeight
+	for i := range r.searchTags {
+		weights[i+1] = 1
+	}
+
+	var count int64
+	results := make([]*SearchResult, 0)
+	for _, song := range r.songs {
+		if r.isDeleted(song) {
+			continue
+		}
+		fields := []string{song.Name}
+		for _, key := range r.searchTags {
+			fields = append(fields, song.Tags[key])
+		}
+		score := search.score(fields, weights)
+		if score == 0 {
+			continue
+		}
+
+		count++
+		if cursor == nil || score < cursor.Score || score == cursor.Score && song.ID > cursor.ID.Hex() {
+			results = append(results, &SearchResult{Song: song, Score: score})
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Song.ID < results[j].Song.ID
+	})
+
+	var nextToken string
+	if int64(len(results)) > pageSize {
+		results = results[:pageSize]
+		last := results[pageSize-1]
+		id, _ := primitive.ObjectIDFromHex(last.Song.ID)
+		nextToken = encodePageToken(pageCursor{ID: id, Score: last.Score, Sort: searchList})
+	}
+
+	page := make([]*SearchResult, len(results))
+	for i, result := range results {
+		page[i] = &SearchResult{Song: copyFile(result.Song), Score: result.Score}
+	}
+
+	return page, nextToken, count, nil
+}
@@ -0,0 +1,14 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/TensorBeat/Datalake/internal/repository"
+	"github.com/TensorBeat/Datalake/internal/repository/repositorytest"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.Repository {
+		return repository.NewMemoryRepository(logger)
+	})
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultMigrationBatchSize = 500
+	maxMigrationBatchSize     = 10000
+)
+
+var (
+	ErrTagMigrationNotFound = newError(ErrNotFound, "tag migration", "tag migration not found")
+	// ErrTagMigrationState is returned for runs and rollbacks of migrations
+	// in another state, or changed by another run while running.
+	ErrTagMigrationState = newError(ErrConflict, "tag migration", "tag migration is in the wrong state")
+)
+
+type TagMigrationState string
+
+const (
+	// MigrationPlanned is the state of dry runs, they are never stored
+	MigrationPlanned     TagMigrationState = "planned"
+	MigrationRunning     TagMigrationState = "running"
+	MigrationCompleted   TagMigrationState = "completed"
+	MigrationRollingBack TagMigrationState = "rollingBack"
+	MigrationRolledBack  TagMigrationState = "rolledBack"
+)
+
+// TagMigrationSpec either renames Key to NewKey or remaps the string values
+// of Key with Values, which maps old values to new ones.
+type TagMigrationSpec struct {
+	Key    string
+	NewKey string
+	Values map[string]string
+}
+
+// TagMigration is a migration and its progress. Songs are migrated in ID
+// order, Cursor is the ID of the last song of the last batch.
+type TagMigration struct {
+	ID string
+	TagMigrationSpec
+	State  TagMigrationState
+	Cursor string
+	// MatchedCount counts the songs having Key, or one of the remapped
+	// values, ModifiedCount the ones that were changed. Songs already having
+	// NewKey are left alone and counted in ConflictCount.
+	MatchedCount  int64
+	ModifiedCount int64
+	ConflictCount int64
+	// RollbackCursor is the ID of the last change rolled back. Songs whose
+	// tags changed after the migration are left alone and counted in
+	// RollbackConflictCount.
+	RollbackCursor        string
+	RolledBackCount       int64
+	RollbackConflictCount int64
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// ValidateTagMigration checks that spec renames a key or remaps values, but
+// not both. New values can't be remapped themselves so batches can be
+// retried safely.
+func ValidateTagMigration(spec *TagMigrationSpec) error {
+	if err := ValidateTagKey(spec.Key); err != nil {
+		return err
+	}
+
+	switch {
+	case spec.NewKey != "" && len(spec.Values) > 0:
+		return errors.New("a migration can rename a key or remap values, not both")
+	case spec.NewKey != "":
+		if err := ValidateTagKey(spec.NewKey); err != nil {
+			return err
+		}
+		if spec.NewKey == spec.Key {
+			return errors.New("the new key must differ from the key")
+		}
+	case len(spec.Values) > 0:
+		for old, val := range spec.Values {
+			if old == val {
+				return fmt.Errorf("value %q is remapped to itself", old)
+			}
+			if _, ok := spec.Values[val]; ok {
+				return fmt.Errorf("value %q is both remapped and a new value", val)
+			}
+		}
+	default:
+		return errors.New("a new key or values to remap are required")
+	}
+	return nil
+}
+
+// ValidateMigrationBatchSize checks batchSize, 0 means
+// DefaultMigrationBatchSize.
+func ValidateMigrationBatchSize(batchSize int64) error {
+	if batchSize < 0 || batchSize > maxMigrationBatchSize {
+		return fmt.Errorf("batch size must be between 1 and %v", maxMigrationBatchSize)
+	}
+	return nil
+}
+
+func normalizeMigrationBatchSize(batchSize int64) int64 {
+	if batchSize == 0 {
+		return DefaultMigrationBatchSize
+	}
+	return batchSize
+}
+
+// tagChange holds the values of the tags a migration changed on a song
+// before and after, keys missing from a map are tags the song didn't have.
+type tagChange struct {
+	Before map[string]interface{}
+	After  map[string]interface{}
+}
+
+// change computes how the migration changes tags, it returns nil when the
+// song isn't matched and conflict when it is left alone.
+func (spec *TagMigrationSpec) change(tags map[string]interface{}) (change *tagChange, conflict bool) {
+	val, ok := tags[spec.Key]
+	if !ok {
+		return nil, false
+	}
+
+	if spec.NewKey != "" {
+		if _, ok := tags[spec.NewKey]; ok {
+			return nil, true
+		}
+		return &tagChange{
+			Before: map[string]interface{}{spec.Key: val},
+			After:  map[string]interface{}{spec.NewKey: val},
+		}, false
+	}
+
+	s, ok := val.(string)
+	if !ok {
+		return nil, false
+	}
+	remapped, ok := spec.Values[s]
+	if !ok {
+		return nil, false
+	}
+	return &tagChange{
+		Before: map[string]interface{}{spec.Key: s},
+		After:  map[string]interface{}{spec.Key: remapped},
+	}, false
+}
+
+// reverse returns the change undoing c.
+func (c *tagChange) reverse() *tagChange {
+	return &tagChange{Before: c.After, After: c.Before}
+}
+
+// keys returns the keys of the tags c touches.
+func (c *tagChange) keys() []string {
+	keys := make([]string, 0, len(c.Before)+len(c.After))
+	for key := range c.Before {
+		keys = append(keys, key)
+	}
+	for key := range c.After {
+		if _, ok := c.Before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -2,9 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/TensorBeat/Datalake/internal/query"
 	"github.com/TensorBeat/Datalake/pkg/proto"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -14,8 +19,9 @@ import (
 
 const (
 	songCollectionName = "songs"
-	tagsPrefix         = "tags."
 	existsCharacter    = "*"
+	streamBatchSize    = 100
+	deletedAtField     = "deletedAt"
 )
 
 type MongoFile struct {
@@ -23,7 +29,13 @@ type MongoFile struct {
 	Name     string             `bson:"name,omitempty"`
 	Uri      string             `bson:"uri,omitempty"`
 	MimeType string             `bson:"mimeType,omitempty"`
-	Tags     map[string]string  `bson:"tags,omitempty"`
+	// Tags holds string and typed tags with their native bson types
+	Tags map[string]interface{} `bson:"tags,omitempty"`
+	// DeletedAt is set on soft deleted songs until they are purged
+	DeletedAt *time.Time `bson:"deletedAt,omitempty"`
+	// Revision is incremented by every update, songs inserted before
+	// revisions existed don't have it until BackfillRevisions ran
+	Revision int64 `bson:"revision,omitempty"`
 }
 
 type MongoRepository struct {
@@ -31,58 +43,126 @@ type MongoRepository struct {
 	logger       *zap.SugaredLogger
 	databaseName string
 
-	songCollection *mongo.Collection
+	songCollection         *mongo.Collection
+	tagSchemaCollection    *mongo.Collection
+	idempotencyCollection  *mongo.Collection
+	tagMigrationCollection *mongo.Collection
+	tagChangeCollection    *mongo.Collection
+
+	// searchTags are the tag keys of the search index
+	searchTags []string
+
+	// uriIndexMu is held while the unique URI index is checked
+	uriIndexMu    sync.Mutex
+	uriIndexReady bool
+
+	// tagKeysMu is held while the tag key summary is rebuilt
+	tagKeysMu        sync.Mutex
+	tagKeysRebuiltAt time.Time
 }
 
 func NewMongoRepository(client *mongo.Client, logger *zap.SugaredLogger, databaseName string) *MongoRepository {
 	songCollection := client.Database(databaseName).Collection(songCollectionName)
+	tagSchemaCollection := client.Database(databaseName).Collection(tagSchemaCollectionName)
+	idempotencyCollection := client.Database(databaseName).Collection(idempotencyCollectionName)
+	tagMigrationCollection := client.Database(databaseName).Collection(tagMigrationCollectionName)
+	tagChangeCollection := client.Database(databaseName).Collection(tagChangeCollectionName)
 
 	return &MongoRepository{
-		client:         client,
-		logger:         logger,
-		databaseName:   databaseName,
-		songCollection: songCollection,
+		client:                 client,
+		logger:                 logger,
+		databaseName:           databaseName,
+		songCollection:         songCollection,
+		tagSchemaCollection:    tagSchemaCollection,
+		idempotencyCollection:  idempotencyCollection,
+		tagMigrationCollection: tagMigrationCollection,
+		tagChangeCollection:    tagChangeCollection,
 	}
 }
 
-func (r *MongoRepository) AddSongs(ctx context.Context, songs []*File) error {
+func (r *MongoRepository) AddSongs(ctx context.Context, songs []*File) ([]*SongResult, error) {
+
+	return r.insertSongs(ctx, songs, true)
+
+}
+
+func (r *MongoRepository) IngestSongs(ctx context.Context, songs []*File) ([]*SongResult, error) {
+
+	return r.insertSongs(ctx, songs, false)
+
+}
+
+// insertSongs adds songs with a single InsertMany. An ordered insert stops at
+// the first failing song and the ones after it are reported as skipped.
+func (r *MongoRepository) insertSongs(ctx context.Context, songs []*File, ordered bool) ([]*SongResult, error) {
+
+	if err := validateSongTagKeys(songs); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	results := make([]*SongResult, len(songs))
+	if len(songs) == 0 {
+		return results, nil
+	}
+	if err := r.ensureUriIndex(ctx); err != nil {
+		return nil, err
+	}
 
 	mongoFiles := r.FilesToMongoFiles(songs)
 
+	// IDs are assigned here so they are known even when the batch fails
 	documents := make([]interface{}, len(songs))
 	for i := range mongoFiles {
+		if mongoFiles[i].ID.IsZero() {
+			mongoFiles[i].ID = primitive.NewObjectID()
+		}
+		mongoFiles[i].Revision = 1
 		documents[i] = mongoFiles[i]
+		results[i] = &SongResult{ID: mongoFiles[i].ID.Hex()}
 	}
 
-	_, err := r.songCollection.InsertMany(ctx, documents)
-
-	if err != nil {
-		r.logger.Errorf("Failed to add songs to mongo: %v", songs)
-		return err
+	_, err := r.songCollection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(ordered))
+
+	if bulkErr, ok := err.(mongo.BulkWriteException); ok && bulkErr.WriteConcernError == nil {
+		for _, writeErr := range bulkErr.WriteErrors {
+			results[writeErr.Index] = &SongResult{Err: writeErr.WriteError}
+			if writeErr.Code == duplicateKey && strings.Contains(writeErr.Message, uriIndexName) {
+				results[writeErr.Index].Err = fmt.Errorf("%w: %v", ErrUriTaken, songs[writeErr.Index].Uri)
+			}
+			if ordered {
+				for i := writeErr.Index + 1; i < len(results); i++ {
+					results[i] = &SongResult{Err: ErrSongSkipped}
+				}
+			}
+		}
+		r.logger.Warnf("Failed to add %v of %v songs to mongo: %v", len(bulkErr.WriteErrors), len(songs), err)
+	} else if err != nil {
+		r.logger.Errorf("Failed to add songs to mongo: %v", err)
+		return nil, mongoError(err)
 	}
 
 	r.logger.Infof("Added songs to mongo: %v", songs)
 
-	return nil
-
+	return results, nil
 }
 
-func (r *MongoRepository) GetSongsByTags(ctx context.Context, tags map[string]string, operator proto.Filter, pageToken int64, pageSize int64) ([]*File, int64, int64, error) {
-
-	tagsEntries := make([]bson.M, 0)
-	for tagName, val := range tags {
+func (r *MongoRepository) GetSongsByTags(ctx context.Context, tags map[string]*TagMatch, operator proto.Filter, opts ListOptions) ([]*File, string, int64, error) {
+	if err := ValidateTagMatches(tags); err != nil {
+		r.logger.Error(err)
+		return nil, "", 0, invalidArgument(err)
+	}
 
-		var filterEntry bson.M
+	return r.getSongs(ctx, tagsQuery(tags, operator), opts)
+}
 
-		if val == existsCharacter {
-			filterEntry = bson.M{tagsPrefix + tagName: bson.M{
-				"$exists": true,
-			}}
-		} else {
-			filterEntry = bson.M{tagsPrefix + tagName: val}
-		}
+// tagsQuery builds the filter matching songs with the given tags combined
+// using operator.
+func tagsQuery(tags map[string]*TagMatch, operator proto.Filter) bson.M {
 
-		tagsEntries = append(tagsEntries, filterEntry)
+	tagsEntries := make([]bson.M, 0)
+	for tagName, match := range tags {
+		tagsEntries = append(tagsEntries, bson.M{TagsPrefix + tagName: mongoMatch(match)})
 	}
 
 	var query bson.M
@@ -105,57 +185,61 @@ func (r *MongoRepository) GetSongsByTags(ctx context.Context, tags map[string]st
 		}
 	}
 
-	return r.getSongs(ctx, query, pageToken, pageSize)
+	return query
 }
 
-func (r *MongoRepository) GetSongsByIDs(ctx context.Context, ids []string, pageToken int64, pageSize int64) ([]*File, int64, int64, error) {
+func (r *MongoRepository) QuerySongs(ctx context.Context, q query.Node, opts ListOptions) ([]*File, string, int64, error) {
+
+	return r.getSongs(ctx, queryFilter(q), opts)
+
+}
+
+func (r *MongoRepository) GetSongsByIDs(ctx context.Context, ids []string, opts ListOptions) ([]*File, string, int64, error) {
 	mongoIDs := make([]primitive.ObjectID, len(ids))
 
 	for i := range mongoIDs {
 		id, err := primitive.ObjectIDFromHex(ids[i])
 		if err != nil {
 			r.logger.Errorf("bad ID: %v", err)
-			return nil, pageToken, 0, err
+			return nil, "", 0, invalidID(err)
 		}
 		mongoIDs[i] = id
 	}
 
 	query := bson.M{"_id": bson.M{"$in": mongoIDs}}
 
-	return r.getSongs(ctx, query, pageToken, pageSize)
+	return r.getSongs(ctx, query, opts)
 }
 
-func (r *MongoRepository) GetAllSongs(ctx context.Context, pageToken int64, pageSize int64) ([]*File, int64, int64, error) {
+func (r *MongoRepository) GetAllSongs(ctx context.Context, opts ListOptions) ([]*File, string, int64, error) {
 
-	return r.getSongs(ctx, bson.M{}, pageToken, pageSize)
+	return r.getSongs(ctx, bson.M{}, opts)
 
 }
 
-func (r *MongoRepository) getSongs(ctx context.Context, query bson.M, pageToken int64, pageSize int64) ([]*File, int64, int64, error) {
+func (r *MongoRepository) getSongs(ctx context.Context, query bson.M, opts ListOptions) ([]*File, string, int64, error) {
+
+	query = liveSongs(query)
 
 	r.logger.Debugf("query: %v", query)
 
-	findOptions := options.Find()
-	if pageToken < 0 {
-		err := fmt.Errorf("pageToken must be non-negative: %v", pageToken)
+	if err := ValidateSortOrder(opts.Sort); err != nil {
 		r.logger.Error(err)
-		return nil, 0, 0, err
-	} else if pageSize > 0 {
-		findOptions.SetLimit(pageSize)
+		return nil, "", 0, invalidArgument(err)
 	}
-
-	if pageSize < 0 {
-		err := fmt.Errorf("pageSize must be non-negative: %v", pageSize)
+	if err := ValidateReadPaths(opts.Fields); err != nil {
 		r.logger.Error(err)
-		return nil, pageToken, 0, err
-	} else if pageToken > 0 {
-		findOptions.SetSkip(pageToken)
+		return nil, "", 0, invalidArgument(err)
 	}
-
-	cur, err := r.songCollection.Find(ctx, query)
+	cursor, err := decodeSortedPageToken(opts.PageToken, opts.Sort)
 	if err != nil {
-		r.logger.Errorf("Failed to find songs in mongo: %v", err)
-		return nil, pageToken, 0, err
+		r.logger.Errorf("%v: %v", err, opts.PageToken)
+		return nil, "", 0, err
+	}
+	pageSize, err := normalizePageSize(opts.PageSize)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, "", 0, invalidArgument(err)
 	}
 
 	count, countErr := r.songCollection.CountDocuments(ctx, query)
@@ -163,87 +247,376 @@ func (r *MongoRepository) getSongs(ctx context.Context, query bson.M, pageToken
 		r.logger.Errorf("Failed to count songs in mongo: %v", countErr)
 	}
 
+	pageQuery := query
+	if cursor != nil {
+		pageQuery = bson.M{
+			"$and": []bson.M{query, mongoAfterCursor(opts.Sort, cursor)},
+		}
+	}
+
+	// Fetch one extra song to know if there is another page
+	findOptions := options.Find().
+		SetSort(mongoSort(opts.Sort)).
+		SetLimit(pageSize + 1)
+	if projection := mongoProjection(opts.Fields, opts.Sort); projection != nil {
+		findOptions.SetProjection(projection)
+	}
+
+	cur, err := r.songCollection.Find(ctx, pageQuery, findOptions)
+	if err != nil {
+		r.logger.Errorf("Failed to find songs in mongo: %v", err)
+		return nil, "", 0, mongoError(err)
+	}
+
 	songs := make([]*MongoFile, 0)
 
-	cur.All(ctx, &songs)
+	err = cur.All(ctx, &songs)
 	if err != nil {
 		r.logger.Errorf("Failed to get songs in mongo: %v", err)
-		return nil, pageToken, 0, err
+		return nil, "", 0, mongoError(err)
 	}
 
 	r.logger.Debugf("Songs: %v", songs)
 
 	files := r.MongoFilesToFiles(songs)
-	if pageSize == 0 {
-		files = files[pageToken:]
-	} else {
-		files = files[pageToken : pageToken+pageSize]
+
+	var nextToken string
+	if int64(len(files)) > pageSize {
+		files = files[:pageSize]
+		last := files[pageSize-1]
+		nextToken = encodePageToken(pageCursor{
+			ID:    songs[pageSize-1].ID,
+			Sort:  opts.Sort.String(),
+			Value: sortValue(last, opts.Sort),
+		})
+	}
+	for _, file := range files {
+		projectFile(file, opts.Fields)
 	}
 
-	return files, pageToken + pageSize, count, nil
+	return files, nextToken, count, nil
 }
 
-func (r *MongoRepository) AddTags(ctx context.Context, id string, tags map[string]string) error {
-	mongoID, err := primitive.ObjectIDFromHex(id)
+func (r *MongoRepository) StreamSongsByTags(ctx context.Context, tags map[string]string, operator proto.Filter, resumeToken string, send func(song *File, resumeToken string) error) error {
+
+	cursor, err := decodePageToken(resumeToken)
 	if err != nil {
-		r.logger.Errorf("bad ID: %v", err)
+		r.logger.Errorf("%v: %v", err, resumeToken)
 		return err
 	}
 
-	tagsToSet := make(map[string]string)
-	for tagName, val := range tags {
-		tagsToSet[tagsPrefix+tagName] = val
+	query := bson.M{}
+	if len(tags) > 0 {
+		query = tagsQuery(ExactMatches(tags), operator)
 	}
+	query = liveSongs(query)
+
+	r.logger.Debugf("stream query: %v", query)
+
+	// Songs are read a page at a time with the keyset of the resume token,
+	// so no server cursor is held open while a slow client drains a page
+	findOptions := options.Find().
+		SetSort(bson.M{"_id": 1}).
+		SetLimit(streamBatchSize)
+
+	for {
+		pageQuery := query
+		if cursor != nil {
+			pageQuery = bson.M{
+				"$and": []bson.M{query, {"_id": bson.M{"$gt": cursor.ID}}},
+			}
+		}
 
-	filter := bson.M{
-		"_id": mongoID,
+		cur, err := r.songCollection.Find(ctx, pageQuery, findOptions)
+		if err != nil {
+			r.logger.Errorf("Failed to find songs in mongo: %v", err)
+			return mongoError(err)
+		}
+		songs := make([]*MongoFile, 0, streamBatchSize)
+		if err := cur.All(ctx, &songs); err != nil {
+			r.logger.Errorf("Failed to stream songs from mongo: %v", err)
+			return mongoError(err)
+		}
+
+		for _, file := range r.MongoFilesToFiles(songs) {
+			id, _ := primitive.ObjectIDFromHex(file.ID)
+			cursor = &pageCursor{ID: id}
+			if err := send(file, encodePageToken(*cursor)); err != nil {
+				return err
+			}
+		}
+
+		if len(songs) < streamBatchSize {
+			return nil
+		}
+	}
+}
+
+func (r *MongoRepository) AddTags(ctx context.Context, id string, tags map[string]string, typedTags map[string]interface{}, expectedRevision int64) (int64, error) {
+	mongoID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		r.logger.Errorf("bad ID: %v", err)
+		return 0, invalidID(err)
+	}
+	if err := ValidateChangedTagKeys(tagKeys(mergeTags(tags, typedTags))); err != nil {
+		r.logger.Error(err)
+		return 0, invalidArgument(err)
+	}
+
+	tagsToSet := make(map[string]interface{})
+	for tagName, val := range mergeTags(tags, typedTags) {
+		tagsToSet[TagsPrefix+tagName] = val
 	}
+
 	update := bson.M{
 		"$set": tagsToSet,
 	}
-	_, err = r.songCollection.UpdateOne(ctx, filter, update)
+	updated, err := r.reviseSong(ctx, mongoID, expectedRevision, update)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return updated.Revision, nil
 }
 
-func (r *MongoRepository) RemoveTags(ctx context.Context, id string, tags map[string]string) error {
+func (r *MongoRepository) RemoveTags(ctx context.Context, id string, tags map[string]string, expectedRevision int64) (int64, error) {
 	mongoID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		r.logger.Errorf("bad ID: %v", err)
-		return err
+		return 0, invalidID(err)
+	}
+	if err := ValidateChangedTagKeys(tagKeys(mergeTags(tags, nil))); err != nil {
+		r.logger.Error(err)
+		return 0, invalidArgument(err)
 	}
 
 	tagsToUnset := make(map[string]string)
 	for tagName := range tags {
-		tagsToUnset[tagsPrefix+tagName] = ""
+		tagsToUnset[TagsPrefix+tagName] = ""
 	}
 
+	update := bson.M{
+		"$unset": tagsToUnset,
+	}
+	updated, err := r.reviseSong(ctx, mongoID, expectedRevision, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return updated.Revision, nil
+}
+
+func (r *MongoRepository) UpdateSong(ctx context.Context, song *File, paths []string) (*File, error) {
+	mongoID, err := primitive.ObjectIDFromHex(song.ID)
+	if err != nil {
+		r.logger.Errorf("bad ID: %v", err)
+		return nil, invalidID(err)
+	}
+	if err := ValidateUpdatePaths(paths); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+	if err := ValidateTagKeys(song.Tags, song.TypedTags); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	toSet := bson.M{}
+	toUnset := bson.M{}
+	for _, path := range paths {
+		switch path {
+		case namePath:
+			toSet[path] = song.Name
+		case uriPath:
+			// An empty URI is unset so it isn't in the unique index
+			if song.Uri != "" {
+				if err := r.ensureUriIndex(ctx); err != nil {
+					return nil, err
+				}
+				toSet[path] = song.Uri
+			} else {
+				toUnset[path] = ""
+			}
+		case mimeTypePath:
+			toSet[path] = song.MimeType
+		case TagsPath:
+			if tags := mergeTags(song.Tags, song.TypedTags); tags != nil {
+				toSet[path] = tags
+			} else {
+				toUnset[path] = ""
+			}
+		default:
+			tagName := strings.TrimPrefix(path, TagsPrefix)
+			if val, ok := song.AllTags()[tagName]; ok {
+				toSet[path] = val
+			} else {
+				toUnset[path] = ""
+			}
+		}
+	}
+
+	update := bson.M{}
+	if len(toSet) > 0 {
+		update["$set"] = toSet
+	}
+	if len(toUnset) > 0 {
+		update["$unset"] = toUnset
+	}
+
+	updated, err := r.reviseSong(ctx, mongoID, song.Revision, update)
+	if isDuplicateUriError(err) {
+		return nil, fmt.Errorf("%w: %v", ErrUriTaken, song.Uri)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return r.MongoFilesToFiles([]*MongoFile{updated})[0], nil
+}
+
+func (r *MongoRepository) BackfillRevisions(ctx context.Context) (int64, error) {
+
+	filter := bson.M{revisionField: bson.M{"$exists": false}}
+	res, err := r.songCollection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{revisionField: 1}})
+	if err != nil {
+		r.logger.Errorf("Failed to backfill revisions in mongo: %v", err)
+		return 0, mongoError(err)
+	}
+
+	if res.ModifiedCount > 0 {
+		r.logger.Infof("Backfilled the revision of %v songs in mongo", res.ModifiedCount)
+	}
+
+	return res.ModifiedCount, nil
+}
+
+// reviseSong applies update to the live song with id and increments its
+// revision. Unless expectedRevision is 0 the song must have that revision.
+// Errors other than ErrSongNotFound and RevisionMismatchError come from the
+// update.
+func (r *MongoRepository) reviseSong(ctx context.Context, id primitive.ObjectID, expectedRevision int64, update bson.M) (*MongoFile, error) {
+
 	filter := bson.M{
-		"_id": mongoID,
+		"_id":          id,
+		deletedAtField: bson.M{"$exists": false},
+	}
+	if expectedRevision != 0 {
+		filter[revisionField] = expectedRevision
 	}
+	update["$inc"] = bson.M{revisionField: 1}
+
+	updated := &MongoFile{}
+	err := r.songCollection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(updated)
+	if err == mongo.ErrNoDocuments {
+		if expectedRevision == 0 {
+			return nil, ErrSongNotFound
+		}
+		return nil, r.revisionMismatch(ctx, id, expectedRevision)
+	} else if err != nil {
+		if !isDuplicateUriError(err) {
+			r.logger.Errorf("Failed to update song in mongo: %v", err)
+		}
+		return nil, mongoError(err)
+	}
+
+	return updated, nil
+}
+
+// revisionMismatch tells why a write expecting a revision matched no song.
+func (r *MongoRepository) revisionMismatch(ctx context.Context, id primitive.ObjectID, expectedRevision int64) error {
+	current := &MongoFile{}
+	filter := bson.M{
+		"_id":          id,
+		deletedAtField: bson.M{"$exists": false},
+	}
+	err := r.songCollection.FindOne(ctx, filter, options.FindOne().SetProjection(bson.M{revisionField: 1})).Decode(current)
+	if err == mongo.ErrNoDocuments {
+		return ErrSongNotFound
+	} else if err != nil {
+		r.logger.Errorf("Failed to find song in mongo: %v", err)
+		return mongoError(err)
+	}
+	return &RevisionMismatchError{ID: id.Hex(), Expected: expectedRevision, Current: current.Revision}
+}
+
+func (r *MongoRepository) DeleteSongsByIDs(ctx context.Context, ids []string) (int64, error) {
+	mongoIDs := make([]primitive.ObjectID, len(ids))
+
+	for i := range mongoIDs {
+		id, err := primitive.ObjectIDFromHex(ids[i])
+		if err != nil {
+			r.logger.Errorf("bad ID: %v", err)
+			return 0, invalidID(err)
+		}
+		mongoIDs[i] = id
+	}
+
+	return r.deleteSongs(ctx, bson.M{"_id": bson.M{"$in": mongoIDs}})
+}
+
+func (r *MongoRepository) DeleteSongsByTags(ctx context.Context, tags map[string]string, operator proto.Filter) (int64, error) {
+	if len(tags) == 0 {
+		err := errors.New("at least one tag is required to delete songs by tags")
+		r.logger.Error(err)
+		return 0, invalidArgument(err)
+	}
+
+	return r.deleteSongs(ctx, tagsQuery(ExactMatches(tags), operator))
+}
+
+// deleteSongs soft deletes the songs matching query by setting a tombstone
+// that every read path excludes.
+func (r *MongoRepository) deleteSongs(ctx context.Context, query bson.M) (int64, error) {
+
 	update := bson.M{
-		"$unset": tagsToUnset,
+		"$set": bson.M{deletedAtField: time.Now().UTC()},
+		"$inc": bson.M{revisionField: 1},
 	}
-	_, err = r.songCollection.UpdateOne(ctx, filter, update)
+	result, err := r.songCollection.UpdateMany(ctx, liveSongs(query), update)
 	if err != nil {
-		return err
+		r.logger.Errorf("Failed to delete songs in mongo: %v", err)
+		return 0, mongoError(err)
+	}
+
+	r.logger.Infof("Deleted %v songs in mongo", result.ModifiedCount)
+
+	return result.ModifiedCount, nil
+}
+
+func (r *MongoRepository) PurgeDeletedSongs(ctx context.Context, deletedBefore time.Time) (int64, error) {
+
+	filter := bson.M{
+		deletedAtField: bson.M{"$lt": deletedBefore},
+	}
+	result, err := r.songCollection.DeleteMany(ctx, filter)
+	if err != nil {
+		r.logger.Errorf("Failed to purge songs in mongo: %v", err)
+		return 0, mongoError(err)
 	}
 
-	return nil
+	r.logger.Infof("Purged %v songs deleted before %v", result.DeletedCount, deletedBefore)
+
+	return result.DeletedCount, nil
+}
+
+// liveSongs restricts query to songs that haven't been deleted.
+func liveSongs(query bson.M) bson.M {
+	return bson.M{
+		"$and": []bson.M{query, {deletedAtField: bson.M{"$exists": false}}},
+	}
 }
 
 func (r *MongoRepository) MongoFilesToFiles(mongoFiles []*MongoFile) []*File {
 	files := make([]*File, len(mongoFiles))
 	for i, mongoFile := range mongoFiles {
+		tags, typedTags := splitTags(mongoFile.Tags)
 		files[i] = &File{
-			ID:       mongoFile.ID.Hex(),
-			Name:     mongoFile.Name,
-			Uri:      mongoFile.Uri,
-			MimeType: mongoFile.MimeType,
-			Tags:     mongoFile.Tags,
+			ID:        mongoFile.ID.Hex(),
+			Name:      mongoFile.Name,
+			Uri:       mongoFile.Uri,
+			MimeType:  mongoFile.MimeType,
+			Tags:      tags,
+			TypedTags: typedTags,
+			Revision:  mongoFile.Revision,
 		}
 	}
 	return files
@@ -259,7 +632,7 @@ func (r *MongoRepository) FilesToMongoFiles(files []*File) []*MongoFile {
 				Name:     file.Name,
 				Uri:      file.Uri,
 				MimeType: file.MimeType,
-				Tags:     file.Tags,
+				Tags:     mergeTags(file.Tags, file.TypedTags),
 			})
 		} else {
 			mongoFiles = append(mongoFiles, &MongoFile{
@@ -267,7 +640,7 @@ func (r *MongoRepository) FilesToMongoFiles(files []*File) []*MongoFile {
 				Name:     file.Name,
 				Uri:      file.Uri,
 				MimeType: file.MimeType,
-				Tags:     file.Tags,
+				Tags:     mergeTags(file.Tags, file.TypedTags),
 			})
 		}
 
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (r *MongoRepository) BatchAddTags(ctx context.Context, selector SongSelector, tags map[string]string, typedTags map[string]interface{}, dryRun bool) (*BatchResult, error) {
+
+	all := mergeTags(tags, typedTags)
+	if err := ValidateChangedTagKeys(tagKeys(all)); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	// Values are literals so strings starting with $ aren't read as paths
+	tagsToSet := bson.M{}
+	changed := make([]bson.M, 0, len(all))
+	for tagName, val := range all {
+		tagsToSet[TagsPrefix+tagName] = bson.M{"$literal": val}
+		changed = append(changed, bson.M{"$ne": bson.A{"$" + TagsPrefix + tagName, bson.M{"$literal": val}}})
+	}
+
+	return r.batchUpdate(ctx, selector, bson.D{{Key: "$set", Value: tagsToSet}}, changed, dryRun)
+}
+
+func (r *MongoRepository) BatchRemoveTags(ctx context.Context, selector SongSelector, keys []string, dryRun bool) (*BatchResult, error) {
+
+	if err := ValidateChangedTagKeys(keys); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	tagsToUnset := make(bson.A, 0, len(keys))
+	changed := make([]bson.M, 0, len(keys))
+	for _, key := range keys {
+		tagsToUnset = append(tagsToUnset, TagsPrefix+key)
+		changed = append(changed, bson.M{"$ne": bson.A{bson.M{"$type": "$" + TagsPrefix + key}, "missing"}})
+	}
+
+	return r.batchUpdate(ctx, selector, bson.D{{Key: "$unset", Value: tagsToUnset}}, changed, dryRun)
+}
+
+// batchUpdate applies the update stage to every selected song with a single
+// UpdateMany, so the matched count comes from the same write, a dry run
+// only counts the selected songs. The revision only grows on songs one of
+// the changed expressions holds for, the others are left as they are.
+func (r *MongoRepository) batchUpdate(ctx context.Context, selector SongSelector, stage bson.D, changed []bson.M, dryRun bool) (*BatchResult, error) {
+
+	if err := ValidateSongSelector(selector); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	var filter bson.M
+	if selector.Query != nil {
+		filter = queryFilter(selector.Query)
+	} else {
+		ids := make([]primitive.ObjectID, len(selector.IDs))
+		for i, id := range selector.IDs {
+			ids[i], _ = primitive.ObjectIDFromHex(id)
+		}
+		filter = bson.M{"_id": bson.M{"$in": ids}}
+	}
+	filter = liveSongs(filter)
+
+	if dryRun {
+		matched, err := r.songCollection.CountDocuments(ctx, filter)
+		if err != nil {
+			r.logger.Errorf("Failed to count songs in mongo: %v", err)
+			return nil, mongoError(err)
+		}
+		return &BatchResult{MatchedCount: matched}, nil
+	}
+
+	// The revision is set before the tags change, an update pipeline
+	// needs mongo 4.2
+	revision := bson.M{"$cond": bson.A{
+		bson.M{"$or": changed},
+		bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$" + revisionField, 0}}, 1}},
+		"$" + revisionField,
+	}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{revisionField: revision}}},
+		stage,
+	}
+	res, err := r.songCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		r.logger.Errorf("Failed to update songs in mongo: %v", err)
+		return nil, mongoError(err)
+	}
+
+	r.logger.Infof("Updated the tags of %v of %v songs in mongo", res.ModifiedCount, res.MatchedCount)
+
+	return &BatchResult{MatchedCount: res.MatchedCount, ModifiedCount: res.ModifiedCount}, nil
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// tagKeyCollectionName holds the summary of the tag keys, a document
+	// per key with the number of songs having it
+	tagKeyCollectionName = "tagKeys"
+	// tagKeysMaxAge is how long the summary is listed before it is rebuilt
+	tagKeysMaxAge = time.Minute
+)
+
+// ListTagKeys lists the keys of the tag key summary, which is rebuilt from
+// every song when it is older than tagKeysMaxAge rather than for each page.
+func (r *MongoRepository) ListTagKeys(ctx context.Context, pageToken string, pageSize int64) ([]*TagKeyCount, string, error) {
+
+	cursor, err := decodeValuePageToken(pageToken, tagKeysList)
+	if err != nil {
+		r.logger.Errorf("%v: %v", err, pageToken)
+		return nil, "", err
+	}
+	pageSize, err = normalizePageSize(pageSize)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, "", invalidArgument(err)
+	}
+
+	if err := r.rebuildTagKeys(ctx); err != nil {
+		return nil, "", err
+	}
+
+	filter := bson.M{}
+	if cursor != nil {
+		filter["_id"] = bson.M{"$gt": cursor.Value}
+	}
+	// Fetch one extra key to know if there is another page
+	opts := options.Find().
+		SetSort(bson.M{"_id": 1}).
+		SetLimit(pageSize + 1)
+	cur, err := r.client.Database(r.databaseName).Collection(tagKeyCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Errorf("Failed to list tag keys in mongo: %v", err)
+		return nil, "", mongoError(err)
+	}
+
+	buckets := make([]mongoFacetBucket, 0)
+	if err := cur.All(ctx, &buckets); err != nil {
+		r.logger.Errorf("Failed to get tag keys from mongo: %v", err)
+		return nil, "", mongoError(err)
+	}
+
+	var nextToken string
+	if int64(len(buckets)) > pageSize {
+		buckets = buckets[:pageSize]
+		nextToken = encodePageToken(pageCursor{Sort: tagKeysList, Value: buckets[pageSize-1].Value})
+	}
+
+	keys := make([]*TagKeyCount, len(buckets))
+	for i, bucket := range buckets {
+		key, _ := bucket.Value.(string)
+		keys[i] = &TagKeyCount{Key: key, Count: bucket.Count}
+	}
+
+	return keys, nextToken, nil
+}
+
+// rebuildTagKeys replaces the tag key summary when it is too old. $out
+// swaps the whole collection at once, so pages listed meanwhile read either
+// summary.
+func (r *MongoRepository) rebuildTagKeys(ctx context.Context) error {
+	r.tagKeysMu.Lock()
+	defer r.tagKeysMu.Unlock()
+
+	if time.Since(r.tagKeysRebuiltAt) < tagKeysMaxAge {
+		return nil
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: liveSongs(bson.M{TagsPath: bson.M{"$exists": true}})}},
+		{{Key: "$project", Value: bson.M{"tag": bson.M{"$objectToArray": "$" + TagsPath}}}},
+		{{Key: "$unwind", Value: "$tag"}},
+		{{Key: "$group", Value: bson.M{"_id": "$tag.k", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$out", Value: tagKeyCollectionName}},
+	}
+	cur, err := r.songCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Errorf("Failed to summarise tag keys in mongo: %v", err)
+		return mongoError(err)
+	}
+	cur.Close(ctx)
+
+	r.tagKeysRebuiltAt = time.Now()
+	return nil
+}
+
+func (r *MongoRepository) ListTagValues(ctx context.Context, key string, pageToken string, pageSize int64) ([]*FacetValue, string, error) {
+
+	if err := ValidateTagKey(key); err != nil {
+		r.logger.Error(err)
+		return nil, "", invalidArgument(err)
+	}
+	cursor, err := decodeValuePageToken(pageToken, tagValuesList+key)
+	if err != nil {
+		r.logger.Errorf("%v: %v", err, pageToken)
+		return nil, "", err
+	}
+	pageSize, err = normalizePageSize(pageSize)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, "", invalidArgument(err)
+	}
+
+	field := TagsPrefix + key
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: liveSongs(bson.M{field: bson.M{"$exists": true}})}},
+		{{Key: "$group", Value: bson.M{"_id": "$" + field, "count": bson.M{"$sum": 1}}}},
+	}
+	if cursor != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"$or": mongoAfterValue("_id", cursor.Value, false)}}})
+	}
+	// Fetch one extra value to know if there is another page
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.M{"_id": 1}}},
+		bson.D{{Key: "$limit", Value: pageSize + 1}},
+	)
+
+	buckets, err := r.aggregateBuckets(ctx, pipeline)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextToken string
+	if int64(len(buckets)) > pageSize {
+		buckets = buckets[:pageSize]
+		nextToken = encodePageToken(pageCursor{Sort: tagValuesList + key, Value: buckets[pageSize-1].Value})
+	}
+
+	values := make([]*FacetValue, len(buckets))
+	for i, bucket := range buckets {
+		values[i] = &FacetValue{Value: normalizeSortValue(bucket.Value), Count: bucket.Count}
+	}
+
+	return values, nextToken, nil
+}
+
+func (r *MongoRepository) aggregateBuckets(ctx context.Context, pipeline mongo.Pipeline) ([]mongoFacetBucket, error) {
+	r.logger.Debugf("tag pipeline: %v", pipeline)
+
+	cur, err := r.songCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Errorf("Failed to list tags in mongo: %v", err)
+		return nil, mongoError(err)
+	}
+
+	buckets := make([]mongoFacetBucket, 0)
+	if err := cur.All(ctx, &buckets); err != nil {
+		r.logger.Errorf("Failed to get tags from mongo: %v", err)
+		return nil, mongoError(err)
+	}
+	return buckets, nil
+}
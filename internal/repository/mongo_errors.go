@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Error labels mongo and the driver put on errors that are worth retrying.
+var retryableErrorLabels = []string{"NetworkError", "RetryableWriteError"}
+
+// mongoError marks the errors of mongo being unreachable as ErrUnavailable,
+// other errors are returned as they are.
+func mongoError(err error) error {
+	if err == nil || !isUnavailableError(err) {
+		return err
+	}
+	return &Error{Kind: ErrUnavailable, Err: err}
+}
+
+func isUnavailableError(err error) bool {
+	var labeled interface{ HasErrorLabel(string) bool }
+	var cmdErr mongo.CommandError
+	var writeErr mongo.WriteException
+	var bulkErr mongo.BulkWriteException
+	switch {
+	case errors.Is(err, mongo.ErrClientDisconnected):
+		return true
+	case errors.As(err, &cmdErr):
+		labeled = cmdErr
+	case errors.As(err, &writeErr):
+		labeled = writeErr
+	case errors.As(err, &bulkErr):
+		labeled = bulkErr
+	default:
+		// The driver only keeps the message of server selection errors
+		return strings.HasPrefix(err.Error(), "server selection error")
+	}
+
+	for _, label := range retryableErrorLabels {
+		if labeled.HasErrorLabel(label) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/TensorBeat/Datalake/pkg/proto"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoFacetBucket struct {
+	Value interface{} `bson:"_id"`
+	Count int64       `bson:"count"`
+}
+
+// GetTagFacets counts every key in a single $facet stage. Only the top
+// values leave the database, the songs with the tag are counted separately
+// to get the missing and other buckets.
+func (r *MongoRepository) GetTagFacets(ctx context.Context, keys []string, tags map[string]string, operator proto.Filter, limit int64) ([]*TagFacet, error) {
+
+	if err := ValidateFacets(keys, limit); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+	limit = normalizeFacetLimit(limit)
+
+	query := bson.M{}
+	if len(tags) > 0 {
+		query = tagsQuery(ExactMatches(tags), operator)
+	}
+	query = liveSongs(query)
+
+	facets := bson.M{
+		"total": bson.A{bson.M{"$count": "count"}},
+	}
+	for i, key := range keys {
+		present := bson.M{"$match": bson.M{TagsPrefix + key: bson.M{"$exists": true}}}
+		facets[fmt.Sprintf("present%v", i)] = bson.A{present, bson.M{"$count": "count"}}
+		facets[fmt.Sprintf("values%v", i)] = bson.A{
+			present,
+			bson.M{"$group": bson.M{"_id": "$" + TagsPrefix + key, "count": bson.M{"$sum": 1}}},
+			bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+			bson.M{"$limit": limit},
+		}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: query}},
+		{{Key: "$facet", Value: facets}},
+	}
+
+	r.logger.Debugf("facet pipeline: %v", pipeline)
+
+	cur, err := r.songCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Errorf("Failed to count tags in mongo: %v", err)
+		return nil, mongoError(err)
+	}
+
+	docs := make([]map[string][]mongoFacetBucket, 0)
+	if err := cur.All(ctx, &docs); err != nil {
+		r.logger.Errorf("Failed to get tag counts from mongo: %v", err)
+		return nil, mongoError(err)
+	}
+	if len(docs) != 1 {
+		return nil, fmt.Errorf("expected one facet document, got %v", len(docs))
+	}
+	doc := docs[0]
+
+	total := bucketCount(doc["total"])
+	result := make([]*TagFacet, len(keys))
+	for i, key := range keys {
+		present := bucketCount(doc[fmt.Sprintf("present%v", i)])
+		facet := &TagFacet{
+			Key:          key,
+			Values:       make([]*FacetValue, 0),
+			MissingCount: total - present,
+			OtherCount:   present,
+		}
+		for _, bucket := range doc[fmt.Sprintf("values%v", i)] {
+			facet.Values = append(facet.Values, &FacetValue{
+				Value: normalizeSortValue(bucket.Value),
+				Count: bucket.Count,
+			})
+			facet.OtherCount -= bucket.Count
+		}
+		result[i] = facet
+	}
+
+	return result, nil
+}
+
+// bucketCount reads the result of a $count stage, which is empty when
+// nothing was counted.
+func bucketCount(buckets []mongoFacetBucket) int64 {
+	if len(buckets) == 0 {
+		return 0
+	}
+	return buckets[0].Count
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const idempotencyCollectionName = "idempotencyKeys"
+
+var idempotencyTTLSeconds = int64(IdempotencyTTL / time.Second)
+
+var idempotencyIndexes = []indexSpec{
+	{
+		// Mongo removes expired records about once a minute, until then
+		// they are reservable
+		name:        "created_at_ttl",
+		keys:        bson.D{{Key: "createdAt", Value: 1}},
+		expireAfter: &idempotencyTTLSeconds,
+	},
+}
+
+type MongoIdempotencyRecord struct {
+	Key         string    `bson:"_id"`
+	Method      string    `bson:"method"`
+	RequestHash string    `bson:"requestHash"`
+	Owner       string    `bson:"owner,omitempty"`
+	Response    []byte    `bson:"response,omitempty"`
+	CreatedAt   time.Time `bson:"createdAt"`
+	RenewedAt   time.Time `bson:"renewedAt,omitempty"`
+}
+
+func (r *MongoRepository) ReserveIdempotencyKey(ctx context.Context, record *IdempotencyRecord) (*IdempotencyRecord, error) {
+
+	now := time.Now().UTC()
+	doc := &MongoIdempotencyRecord{
+		Key:         record.Key,
+		Method:      record.Method,
+		RequestHash: record.RequestHash,
+		Owner:       record.Owner,
+		CreatedAt:   now,
+		RenewedAt:   now,
+	}
+
+	// The record may expire between the insert and the find, so try twice
+	for attempt := 0; attempt < 2; attempt++ {
+		_, err := r.idempotencyCollection.InsertOne(ctx, doc)
+		if err == nil {
+			return nil, nil
+		} else if !isDuplicateKeyError(err) {
+			r.logger.Errorf("Failed to reserve idempotency key in mongo: %v", err)
+			return nil, mongoError(err)
+		}
+
+		existing := &MongoIdempotencyRecord{}
+		err = r.idempotencyCollection.FindOne(ctx, bson.M{"_id": doc.Key}).Decode(existing)
+		if err == mongo.ErrNoDocuments {
+			continue
+		} else if err != nil {
+			r.logger.Errorf("Failed to find idempotency key in mongo: %v", err)
+			return nil, mongoError(err)
+		}
+
+		found := &IdempotencyRecord{
+			Key:         existing.Key,
+			Method:      existing.Method,
+			RequestHash: existing.RequestHash,
+			Owner:       existing.Owner,
+			Response:    existing.Response,
+			CreatedAt:   existing.CreatedAt,
+			RenewedAt:   existing.RenewedAt,
+		}
+		if !found.reservable(doc.CreatedAt) {
+			return found, nil
+		}
+
+		// Only one of the requests taking over the key replaces the record
+		filter := bson.M{"_id": doc.Key, "createdAt": existing.CreatedAt, "renewedAt": existing.RenewedAt}
+		if existing.RenewedAt.IsZero() {
+			filter["renewedAt"] = bson.M{"$exists": false}
+		}
+		res, err := r.idempotencyCollection.ReplaceOne(ctx, filter, doc)
+		if err != nil {
+			r.logger.Errorf("Failed to take over idempotency key in mongo: %v", err)
+			return nil, mongoError(err)
+		}
+		if res.MatchedCount == 0 {
+			return found, nil
+		}
+		return nil, nil
+	}
+
+	return nil, errors.New("idempotency key was removed while reserving it")
+}
+
+// reservedBy matches the record of key while owner holds its reservation.
+func reservedBy(key string, owner string) bson.M {
+	return bson.M{"_id": key, "owner": owner, "response": bson.M{"$exists": false}}
+}
+
+func (r *MongoRepository) RenewIdempotencyKey(ctx context.Context, key string, owner string) error {
+
+	update := bson.M{"$set": bson.M{"renewedAt": time.Now().UTC()}}
+	if _, err := r.idempotencyCollection.UpdateOne(ctx, reservedBy(key, owner), update); err != nil {
+		r.logger.Errorf("Failed to renew idempotency key in mongo: %v", err)
+		return mongoError(err)
+	}
+
+	return nil
+}
+
+func (r *MongoRepository) CompleteIdempotencyKey(ctx context.Context, key string, owner string, response []byte) error {
+
+	update := bson.M{"$set": bson.M{"response": response}}
+	res, err := r.idempotencyCollection.UpdateOne(ctx, reservedBy(key, owner), update)
+	if err != nil {
+		r.logger.Errorf("Failed to complete idempotency key in mongo: %v", err)
+		return mongoError(err)
+	}
+	if res.MatchedCount == 0 {
+		r.logger.Warnf("Idempotency key %v was taken over before its response was stored", key)
+	}
+
+	return nil
+}
+
+func (r *MongoRepository) ReleaseIdempotencyKey(ctx context.Context, key string, owner string) error {
+
+	if _, err := r.idempotencyCollection.DeleteOne(ctx, reservedBy(key, owner)); err != nil {
+		r.logger.Errorf("Failed to release idempotency key in mongo: %v", err)
+		return mongoError(err)
+	}
+
+	return nil
+}
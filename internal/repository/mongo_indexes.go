@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (r *MongoRepository) CheckIndexes(ctx context.Context) ([]*IndexDrift, error) {
+
+	drift := make([]*IndexDrift, 0)
+	for _, collection := range indexedCollections() {
+		existing, err := r.listIndexes(ctx, collection)
+		if err != nil {
+			return nil, err
+		}
+		drift = append(drift, indexDrift(collection, r.indexSpecs(collection), existing)...)
+	}
+
+	return drift, nil
+}
+
+// SyncIndexes builds the missing indexes one at a time, an index failing to
+// build doesn't stop the others. Changed indexes are only reported, queries
+// would run without them while they are rebuilt.
+func (r *MongoRepository) SyncIndexes(ctx context.Context) ([]*IndexDrift, error) {
+
+	return r.syncIndexes(ctx, false)
+
+}
+
+// RebuildIndexes builds the missing indexes like SyncIndexes, and drops and
+// builds again the changed ones.
+func (r *MongoRepository) RebuildIndexes(ctx context.Context) ([]*IndexDrift, error) {
+
+	return r.syncIndexes(ctx, true)
+
+}
+
+func (r *MongoRepository) syncIndexes(ctx context.Context, rebuild bool) ([]*IndexDrift, error) {
+	drift, err := r.CheckIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := 0
+	for _, d := range drift {
+		if d.Kind == IndexUnmanaged || d.Kind == IndexChanged && !rebuild {
+			r.logger.Warnf("Leaving %v", d)
+			continue
+		}
+		r.logger.Infof("Syncing %v", d)
+
+		indexes := r.client.Database(r.databaseName).Collection(d.Collection).Indexes()
+		if d.Kind == IndexChanged {
+			if _, err := indexes.DropOne(ctx, d.Name); err != nil {
+				r.logger.Errorf("Failed to drop index %v.%v: %v", d.Collection, d.Name, err)
+				failed++
+				continue
+			}
+		}
+		if err := createIndex(ctx, indexes, r.indexSpecNamed(d.Collection, d.Name)); err != nil {
+			r.logger.Errorf("Failed to build index %v.%v: %v", d.Collection, d.Name, err)
+			failed++
+			continue
+		}
+		r.logger.Infof("Built index %v.%v", d.Collection, d.Name)
+	}
+
+	if failed > 0 {
+		return drift, fmt.Errorf("failed to sync %v indexes", failed)
+	}
+	return drift, nil
+}
+
+func indexedCollections() []string {
+	collections := make([]string, 0, len(declaredIndexes))
+	for collection := range declaredIndexes {
+		collections = append(collections, collection)
+	}
+	sort.Strings(collections)
+	return collections
+}
+
+// indexSpecs are the declared indexes of a collection, with the search index
+// for the search tags of the repository.
+func (r *MongoRepository) indexSpecs(collection string) []indexSpec {
+	specs := declaredIndexes[collection]
+	if collection == songCollectionName {
+		specs = append(specs[:len(specs):len(specs)], searchIndex(r.searchTags))
+	}
+	return specs
+}
+
+func (r *MongoRepository) indexSpecNamed(collection string, name string) indexSpec {
+	for _, spec := range r.indexSpecs(collection) {
+		if spec.name == name {
+			return spec
+		}
+	}
+	return indexSpec{}
+}
+
+func createIndex(ctx context.Context, indexes mongo.IndexView, spec indexSpec) error {
+	// Only servers before 4.2 read background, newer ones never block the
+	// collection for the whole build
+	opts := options.Index().
+		SetName(spec.name).
+		SetBackground(true)
+	if spec.unique {
+		opts.SetUnique(true)
+	}
+	if spec.partial != nil {
+		opts.SetPartialFilterExpression(spec.partial)
+	}
+	if spec.expireAfter != nil {
+		opts.SetExpireAfterSeconds(int32(*spec.expireAfter))
+	}
+	if spec.weights != nil {
+		opts.SetWeights(spec.weights).SetDefaultLanguage(spec.defaultLanguage)
+	}
+
+	_, err := indexes.CreateOne(ctx, mongo.IndexModel{Keys: spec.keys, Options: opts})
+	return err
+}
+
+func (r *MongoRepository) listIndexes(ctx context.Context, collection string) ([]*mongoIndex, error) {
+	cur, err := r.client.Database(r.databaseName).Collection(collection).Indexes().List(ctx)
+	if err != nil {
+		r.logger.Errorf("Failed to list indexes of %v in mongo: %v", collection, err)
+		return nil, mongoError(err)
+	}
+
+	indexes := make([]*mongoIndex, 0)
+	if err := cur.All(ctx, &indexes); err != nil {
+		r.logger.Errorf("Failed to get indexes of %v from mongo: %v", collection, err)
+		return nil, mongoError(err)
+	}
+	return indexes, nil
+}
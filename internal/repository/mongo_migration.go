@@ -0,0 +1,469 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	tagMigrationCollectionName = "tagMigrations"
+	tagChangeCollectionName    = "tagMigrationChanges"
+	// migrationBatchTimeout is how long a batch may run, a batch that
+	// didn't finish by then is taken to have died with its run
+	migrationBatchTimeout = 5 * time.Minute
+	// migrationBatchPoll is how often a rollback checks whether the batch
+	// it waits for finished
+	migrationBatchPoll = time.Second
+)
+
+var tagChangeIndexes = []indexSpec{
+	{
+		// Keeps the first change recorded for a song when a batch is
+		// retried and serves rollbacks, which go through songs in ID order
+		name:   "migration_song",
+		keys:   bson.D{{Key: "migrationId", Value: 1}, {Key: "songId", Value: 1}},
+		unique: true,
+	},
+}
+
+type MongoTagMigration struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty"`
+	Key    string             `bson:"key"`
+	NewKey string             `bson:"newKey,omitempty"`
+	Values map[string]string  `bson:"values,omitempty"`
+	State  string             `bson:"state"`
+	// The cursors start at the zero ID, which is before every song
+	Cursor                primitive.ObjectID `bson:"cursor"`
+	MatchedCount          int64              `bson:"matchedCount"`
+	ModifiedCount         int64              `bson:"modifiedCount"`
+	ConflictCount         int64              `bson:"conflictCount"`
+	RollbackCursor        primitive.ObjectID `bson:"rollbackCursor"`
+	RolledBackCount       int64              `bson:"rolledBackCount"`
+	RollbackConflictCount int64              `bson:"rollbackConflictCount"`
+	CreatedAt             time.Time          `bson:"createdAt"`
+	UpdatedAt             time.Time          `bson:"updatedAt"`
+	// BatchStartedAt is set while a run writes a batch, so rollbacks wait
+	// for it and other runs don't start another one
+	BatchStartedAt *time.Time `bson:"batchStartedAt,omitempty"`
+}
+
+// MongoTagChange is written before the song it changes so a rollback can
+// undo every change, even the ones of a batch that failed halfway.
+type MongoTagChange struct {
+	ID          primitive.ObjectID     `bson:"_id,omitempty"`
+	MigrationID primitive.ObjectID     `bson:"migrationId"`
+	SongID      primitive.ObjectID     `bson:"songId"`
+	Before      map[string]interface{} `bson:"before"`
+	After       map[string]interface{} `bson:"after"`
+}
+
+func (r *MongoRepository) PlanTagMigration(ctx context.Context, spec *TagMigrationSpec) (*TagMigration, error) {
+
+	if err := ValidateTagMigration(spec); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	plan := &TagMigration{TagMigrationSpec: *spec, State: MigrationPlanned}
+
+	matched, err := r.songCollection.CountDocuments(ctx, liveSongs(migrationFilter(spec)))
+	if err != nil {
+		r.logger.Errorf("Failed to count songs in mongo: %v", err)
+		return nil, mongoError(err)
+	}
+	plan.MatchedCount = matched
+
+	if spec.NewKey != "" {
+		conflicts := migrationFilter(spec)
+		conflicts[TagsPrefix+spec.NewKey] = bson.M{"$exists": true}
+		plan.ConflictCount, err = r.songCollection.CountDocuments(ctx, liveSongs(conflicts))
+		if err != nil {
+			r.logger.Errorf("Failed to count songs in mongo: %v", err)
+			return nil, mongoError(err)
+		}
+	}
+
+	return plan, nil
+}
+
+func (r *MongoRepository) CreateTagMigration(ctx context.Context, spec *TagMigrationSpec) (*TagMigration, error) {
+
+	if err := ValidateTagMigration(spec); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	now := time.Now().UTC()
+	doc := &MongoTagMigration{
+		ID:        primitive.NewObjectID(),
+		Key:       spec.Key,
+		NewKey:    spec.NewKey,
+		Values:    spec.Values,
+		State:     string(MigrationRunning),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if _, err := r.tagMigrationCollection.InsertOne(ctx, doc); err != nil {
+		r.logger.Errorf("Failed to add tag migration to mongo: %v", err)
+		return nil, mongoError(err)
+	}
+
+	r.logger.Infof("Created tag migration %v", doc.ID.Hex())
+
+	return doc.toTagMigration(), nil
+}
+
+func (r *MongoRepository) GetTagMigration(ctx context.Context, id string) (*TagMigration, error) {
+
+	mongoID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		r.logger.Errorf("bad ID: %v", err)
+		return nil, invalidID(err)
+	}
+
+	doc, err := r.findTagMigration(ctx, mongoID)
+	if err != nil {
+		return nil, err
+	}
+	return doc.toTagMigration(), nil
+}
+
+func (r *MongoRepository) RunTagMigration(ctx context.Context, id string, batchSize int64, progress func(migration *TagMigration) error) (*TagMigration, error) {
+
+	mongoID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		r.logger.Errorf("bad ID: %v", err)
+		return nil, invalidID(err)
+	}
+	if err := ValidateMigrationBatchSize(batchSize); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+	batchSize = normalizeMigrationBatchSize(batchSize)
+
+	for {
+		doc, err := r.findTagMigration(ctx, mongoID)
+		if err != nil {
+			return nil, err
+		}
+		if doc.State != string(MigrationRunning) {
+			return nil, ErrTagMigrationState
+		}
+		// Claim the batch, fails when the migration is being rolled back or
+		// another run writes a batch
+		startedAt := time.Now().UTC()
+		doc, err = r.updateTagMigration(ctx, idleMigration(doc.ID, bson.M{"state": MigrationRunning, "cursor": doc.Cursor}, startedAt), bson.M{
+			"$set": bson.M{"batchStartedAt": startedAt},
+		})
+		if err != nil {
+			return nil, err
+		}
+		spec := &TagMigrationSpec{Key: doc.Key, NewKey: doc.NewKey, Values: doc.Values}
+
+		filter := migrationFilter(spec)
+		filter["_id"] = bson.M{"$gt": doc.Cursor}
+		opts := options.Find().
+			SetSort(bson.D{{Key: "_id", Value: 1}}).
+			SetLimit(batchSize).
+			SetProjection(bson.M{"tags": 1})
+		cur, err := r.songCollection.Find(ctx, liveSongs(filter), opts)
+		if err != nil {
+			r.logger.Errorf("Failed to find songs in mongo: %v", err)
+			return nil, mongoError(err)
+		}
+		songs := make([]*MongoFile, 0)
+		if err := cur.All(ctx, &songs); err != nil {
+			r.logger.Errorf("Failed to get songs from mongo: %v", err)
+			return nil, mongoError(err)
+		}
+
+		changeModels := make([]mongo.WriteModel, 0, len(songs))
+		songModels := make([]mongo.WriteModel, 0, len(songs))
+		conflicts := int64(0)
+		for _, song := range songs {
+			change, conflict := spec.change(song.Tags)
+			if conflict {
+				conflicts++
+			}
+			if change == nil {
+				continue
+			}
+			changeModels = append(changeModels, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"migrationId": doc.ID, "songId": song.ID}).
+				SetUpdate(bson.M{"$setOnInsert": bson.M{"before": change.Before, "after": change.After}}).
+				SetUpsert(true))
+			songModels = append(songModels, mongo.NewUpdateOneModel().
+				SetFilter(tagChangeFilter(song.ID, change)).
+				SetUpdate(tagChangeUpdate(change)))
+		}
+
+		modified := int64(0)
+		if len(songModels) > 0 {
+			if _, err := r.tagChangeCollection.BulkWrite(ctx, changeModels, options.BulkWrite().SetOrdered(false)); err != nil {
+				r.logger.Errorf("Failed to record tag changes in mongo: %v", err)
+				return nil, mongoError(err)
+			}
+			res, err := r.songCollection.BulkWrite(ctx, songModels, options.BulkWrite().SetOrdered(false))
+			if err != nil {
+				r.logger.Errorf("Failed to migrate tags in mongo: %v", err)
+				return nil, mongoError(err)
+			}
+			modified = res.ModifiedCount
+		}
+
+		done := int64(len(songs)) < batchSize
+		set := bson.M{"updatedAt": time.Now().UTC()}
+		if done {
+			set["state"] = MigrationCompleted
+		} else {
+			set["cursor"] = songs[len(songs)-1].ID
+		}
+		// Fails when the batch ran longer than migrationBatchTimeout and the
+		// migration was rolled back or run elsewhere meanwhile
+		doc, err = r.updateTagMigration(ctx, bson.M{"_id": doc.ID, "state": MigrationRunning, "cursor": doc.Cursor, "batchStartedAt": doc.BatchStartedAt}, bson.M{
+			"$set":   set,
+			"$unset": bson.M{"batchStartedAt": ""},
+			"$inc":   bson.M{"matchedCount": len(songs), "modifiedCount": modified, "conflictCount": conflicts},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		migration := doc.toTagMigration()
+		if err := progress(migration); err != nil {
+			return nil, err
+		}
+		if done {
+			r.logger.Infof("Finished tag migration %v in state %v", id, migration.State)
+			return migration, nil
+		}
+	}
+}
+
+func (r *MongoRepository) RollBackTagMigration(ctx context.Context, id string, batchSize int64, progress func(migration *TagMigration) error) (*TagMigration, error) {
+
+	mongoID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		r.logger.Errorf("bad ID: %v", err)
+		return nil, invalidID(err)
+	}
+	if err := ValidateMigrationBatchSize(batchSize); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+	batchSize = normalizeMigrationBatchSize(batchSize)
+
+	doc, err := r.stopTagMigration(ctx, mongoID)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		filter := bson.M{"migrationId": doc.ID, "songId": bson.M{"$gt": doc.RollbackCursor}}
+		opts := options.Find().
+			SetSort(bson.D{{Key: "songId", Value: 1}}).
+			SetLimit(batchSize)
+		cur, err := r.tagChangeCollection.Find(ctx, filter, opts)
+		if err != nil {
+			r.logger.Errorf("Failed to find tag changes in mongo: %v", err)
+			return nil, mongoError(err)
+		}
+		changes := make([]*MongoTagChange, 0)
+		if err := cur.All(ctx, &changes); err != nil {
+			r.logger.Errorf("Failed to get tag changes from mongo: %v", err)
+			return nil, mongoError(err)
+		}
+
+		rolledBack := int64(0)
+		if len(changes) > 0 {
+			models := make([]mongo.WriteModel, len(changes))
+			for i, c := range changes {
+				reverse := (&tagChange{Before: c.Before, After: c.After}).reverse()
+				models[i] = mongo.NewUpdateOneModel().
+					SetFilter(tagChangeFilter(c.SongID, reverse)).
+					SetUpdate(tagChangeUpdate(reverse))
+			}
+			res, err := r.songCollection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+			if err != nil {
+				r.logger.Errorf("Failed to roll back tags in mongo: %v", err)
+				return nil, mongoError(err)
+			}
+			rolledBack = res.MatchedCount
+		}
+
+		done := int64(len(changes)) < batchSize
+		set := bson.M{"updatedAt": time.Now().UTC()}
+		if done {
+			set["state"] = MigrationRolledBack
+		} else {
+			set["rollbackCursor"] = changes[len(changes)-1].SongID
+		}
+		doc, err = r.updateTagMigration(ctx, bson.M{"_id": doc.ID, "state": MigrationRollingBack, "rollbackCursor": doc.RollbackCursor}, bson.M{
+			"$set": set,
+			"$inc": bson.M{"rolledBackCount": rolledBack, "rollbackConflictCount": int64(len(changes)) - rolledBack},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		migration := doc.toTagMigration()
+		if err := progress(migration); err != nil {
+			return nil, err
+		}
+		if done {
+			r.logger.Infof("Finished tag migration %v in state %v", id, migration.State)
+			return migration, nil
+		}
+	}
+}
+
+// stopTagMigration moves a migration to MigrationRollingBack. A batch being
+// written is waited for, so it can't change songs after they were rolled
+// back, the next batch of the run then fails to claim the migration.
+func (r *MongoRepository) stopTagMigration(ctx context.Context, id primitive.ObjectID) (*MongoTagMigration, error) {
+	stoppable := []TagMigrationState{MigrationRunning, MigrationCompleted, MigrationRollingBack}
+	for waited := false; ; waited = true {
+		now := time.Now().UTC()
+		doc, err := r.updateTagMigration(ctx, idleMigration(id, bson.M{"state": bson.M{"$in": stoppable}}, now), bson.M{
+			"$set": bson.M{"state": MigrationRollingBack, "updatedAt": now},
+		})
+		if err != ErrTagMigrationState {
+			return doc, err
+		}
+
+		current, err := r.findTagMigration(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if current.State != string(MigrationRunning) || current.BatchStartedAt == nil {
+			return nil, ErrTagMigrationState
+		}
+		if !waited {
+			r.logger.Infof("Waiting for the batch of tag migration %v to finish", id.Hex())
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(migrationBatchPoll):
+		}
+	}
+}
+
+// idleMigration adds to filter that no batch of the migration with id is
+// being written at now.
+func idleMigration(id primitive.ObjectID, filter bson.M, now time.Time) bson.M {
+	filter["_id"] = id
+	filter["$or"] = []bson.M{
+		{"batchStartedAt": bson.M{"$exists": false}},
+		{"batchStartedAt": bson.M{"$lte": now.Add(-migrationBatchTimeout)}},
+	}
+	return filter
+}
+
+func (r *MongoRepository) findTagMigration(ctx context.Context, id primitive.ObjectID) (*MongoTagMigration, error) {
+	doc := &MongoTagMigration{}
+	err := r.tagMigrationCollection.FindOne(ctx, bson.M{"_id": id}).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrTagMigrationNotFound
+	} else if err != nil {
+		r.logger.Errorf("Failed to find tag migration in mongo: %v", err)
+		return nil, mongoError(err)
+	}
+	return doc, nil
+}
+
+// updateTagMigration returns ErrTagMigrationState when filter doesn't match
+// the migration.
+func (r *MongoRepository) updateTagMigration(ctx context.Context, filter bson.M, update bson.M) (*MongoTagMigration, error) {
+	doc := &MongoTagMigration{}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := r.tagMigrationCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		if _, err := r.findTagMigration(ctx, filter["_id"].(primitive.ObjectID)); err != nil {
+			return nil, err
+		}
+		return nil, ErrTagMigrationState
+	} else if err != nil {
+		r.logger.Errorf("Failed to update tag migration in mongo: %v", err)
+		return nil, mongoError(err)
+	}
+	return doc, nil
+}
+
+// migrationFilter matches the songs a migration may change.
+func migrationFilter(spec *TagMigrationSpec) bson.M {
+	if spec.NewKey != "" {
+		return bson.M{TagsPrefix + spec.Key: bson.M{"$exists": true}}
+	}
+	values := make([]string, 0, len(spec.Values))
+	for old := range spec.Values {
+		values = append(values, old)
+	}
+	return bson.M{TagsPrefix + spec.Key: bson.M{"$in": values}}
+}
+
+// tagChangeFilter matches the song if its tags still have the values change
+// expects before.
+func tagChangeFilter(id primitive.ObjectID, change *tagChange) bson.M {
+	filter := bson.M{"_id": id}
+	for _, key := range change.keys() {
+		if val, ok := change.Before[key]; ok {
+			filter[TagsPrefix+key] = val
+		} else {
+			filter[TagsPrefix+key] = bson.M{"$exists": false}
+		}
+	}
+	return liveSongs(filter)
+}
+
+func tagChangeUpdate(change *tagChange) bson.M {
+	set := bson.M{}
+	unset := bson.M{}
+	for _, key := range change.keys() {
+		if val, ok := change.After[key]; ok {
+			set[TagsPrefix+key] = val
+		} else {
+			unset[TagsPrefix+key] = ""
+		}
+	}
+
+	update := bson.M{"$inc": bson.M{revisionField: 1}}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update
+}
+
+func (doc *MongoTagMigration) toTagMigration() *TagMigration {
+	migration := &TagMigration{
+		ID: doc.ID.Hex(),
+		TagMigrationSpec: TagMigrationSpec{
+			Key:    doc.Key,
+			NewKey: doc.NewKey,
+			Values: doc.Values,
+		},
+		State:                 TagMigrationState(doc.State),
+		MatchedCount:          doc.MatchedCount,
+		ModifiedCount:         doc.ModifiedCount,
+		ConflictCount:         doc.ConflictCount,
+		RolledBackCount:       doc.RolledBackCount,
+		RollbackConflictCount: doc.RollbackConflictCount,
+		CreatedAt:             doc.CreatedAt,
+		UpdatedAt:             doc.UpdatedAt,
+	}
+	if !doc.Cursor.IsZero() {
+		migration.Cursor = doc.Cursor.Hex()
+	}
+	if !doc.RollbackCursor.IsZero() {
+		migration.RollbackCursor = doc.RollbackCursor.Hex()
+	}
+	return migration
+}
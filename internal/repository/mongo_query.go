@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"github.com/TensorBeat/Datalake/internal/query"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var comparisonOperators = map[query.Operator]string{
+	query.Less:           "$lt",
+	query.LessOrEqual:    "$lte",
+	query.Greater:        "$gt",
+	query.GreaterOrEqual: "$gte",
+}
+
+// queryFilter compiles a parsed query into a mongo filter.
+func queryFilter(node query.Node) bson.M {
+	switch n := node.(type) {
+	case *query.And:
+		return bson.M{"$and": queryFilters(n.Nodes)}
+	case *query.Or:
+		return bson.M{"$or": queryFilters(n.Nodes)}
+	case *query.Not:
+		return bson.M{"$nor": []bson.M{queryFilter(n.Node)}}
+	case *query.Predicate:
+		field := TagsPrefix + n.Key
+		switch n.Op {
+		case query.Exists:
+			return bson.M{field: bson.M{"$exists": true}}
+		case query.Equal:
+			return bson.M{field: bson.M{"$in": equalValues(n.Values[0])}}
+		case query.NotEqual:
+			return bson.M{field: bson.M{"$nin": equalValues(n.Values[0])}}
+		case query.Between:
+			return bson.M{field: bson.M{"$gte": n.Values[0].Value, "$lte": n.Values[1].Value}}
+		case query.Less, query.LessOrEqual, query.Greater, query.GreaterOrEqual:
+			// Comparisons only match values of the same bson type bracket
+			return bson.M{field: bson.M{comparisonOperators[n.Op]: n.Values[0].Value}}
+		}
+	}
+
+	// Unknown nodes match nothing rather than everything
+	return bson.M{"_id": bson.M{"$exists": false}}
+}
+
+func queryFilters(nodes []query.Node) []bson.M {
+	filters := make([]bson.M, len(nodes))
+	for i, node := range nodes {
+		filters[i] = queryFilter(node)
+	}
+	return filters
+}
+
+// equalValues lists the values a literal is equal to, typed literals also
+// match string tags written the same way.
+func equalValues(literal query.Literal) []interface{} {
+	if _, ok := literal.Value.(string); ok {
+		return []interface{}{literal.Text}
+	}
+	return []interface{}{literal.Value, literal.Text}
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/TensorBeat/Datalake/pkg/proto"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	tagSchemaCollectionName = "tagSchemas"
+	// duplicateKey is the error code of writes violating a unique index
+	duplicateKey = 11000
+)
+
+// MongoTagSchema is stored with the lower case key as its ID so keys that
+// only differ in case collide.
+type MongoTagSchema struct {
+	ID            string        `bson:"_id"`
+	Key           string        `bson:"key"`
+	Type          proto.TagType `bson:"type"`
+	AllowedValues []string      `bson:"allowedValues,omitempty"`
+	Required      bool          `bson:"required"`
+	Description   string        `bson:"description,omitempty"`
+}
+
+func (r *MongoRepository) PutTagSchema(ctx context.Context, schema *TagSchema) error {
+	if err := ValidateTagSchema(schema); err != nil {
+		r.logger.Error(err)
+		return invalidArgument(err)
+	}
+
+	doc := &MongoTagSchema{
+		ID:            strings.ToLower(schema.Key),
+		Key:           schema.Key,
+		Type:          schema.Type,
+		AllowedValues: schema.AllowedValues,
+		Required:      schema.Required,
+		Description:   schema.Description,
+	}
+
+	// A schema with the same ID but another key doesn't match the filter, so
+	// the upsert fails on the _id index
+	filter := bson.M{"_id": doc.ID, "key": doc.Key}
+	_, err := r.tagSchemaCollection.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+	if isDuplicateKeyError(err) {
+		return ErrTagSchemaConflict
+	} else if err != nil {
+		r.logger.Errorf("Failed to put tag schema in mongo: %v", err)
+		return mongoError(err)
+	}
+
+	r.logger.Infof("Put tag schema %v", schema.Key)
+
+	return nil
+}
+
+func (r *MongoRepository) GetTagSchemas(ctx context.Context) ([]*TagSchema, error) {
+
+	cur, err := r.tagSchemaCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"key": 1}))
+	if err != nil {
+		r.logger.Errorf("Failed to find tag schemas in mongo: %v", err)
+		return nil, mongoError(err)
+	}
+
+	docs := make([]*MongoTagSchema, 0)
+	if err := cur.All(ctx, &docs); err != nil {
+		r.logger.Errorf("Failed to get tag schemas in mongo: %v", err)
+		return nil, mongoError(err)
+	}
+
+	schemas := make([]*TagSchema, len(docs))
+	for i, doc := range docs {
+		schemas[i] = &TagSchema{
+			Key:           doc.Key,
+			Type:          doc.Type,
+			AllowedValues: doc.AllowedValues,
+			Required:      doc.Required,
+			Description:   doc.Description,
+		}
+	}
+	return schemas, nil
+}
+
+func (r *MongoRepository) DeleteTagSchema(ctx context.Context, key string) (bool, error) {
+
+	result, err := r.tagSchemaCollection.DeleteOne(ctx, bson.M{"_id": strings.ToLower(key), "key": key})
+	if err != nil {
+		r.logger.Errorf("Failed to delete tag schema in mongo: %v", err)
+		return false, mongoError(err)
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+func isDuplicateKeyError(err error) bool {
+	switch e := err.(type) {
+	case mongo.WriteException:
+		for _, writeErr := range e.WriteErrors {
+			if writeErr.Code == duplicateKey {
+				return true
+			}
+		}
+	case mongo.CommandError:
+		return e.Code == duplicateKey
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoSearchResult struct {
+	MongoFile `bson:",inline"`
+	Score     float64 `bson:"_score"`
+}
+
+// SetSearchTags declares the search index over the name and the tags with
+// the given keys, SyncIndexes builds it. It is called before serving.
+func (r *MongoRepository) SetSearchTags(tagKeys []string) error {
+	if err := ValidateSearchTags(tagKeys); err != nil {
+		r.logger.Error(err)
+		return invalidArgument(err)
+	}
+
+	r.searchTags = append([]string(nil), tagKeys...)
+	return nil
+}
+
+func (r *MongoRepository) SearchSongs(ctx context.Context, text string, pageToken string, pageSize int64) ([]*SearchResult, string, int64, error) {
+
+	cursor, err := decodeSearchPageToken(pageToken)
+	if err != nil {
+		r.logger.Errorf("%v: %v", err, pageToken)
+		return nil, "", 0, err
+	}
+	pageSize, err = normalizePageSize(pageSize)
+	if err != nil {
+		r.logger.Error(err)
+		return nil, "", 0, invalidArgument(err)
+	}
+
+	// $text has to be at the top level of the first stage
+	query := bson.M{
+		"$text":        bson.M{"$search": text},
+		deletedAtField: bson.M{"$exists": false},
+	}
+
+	count, countErr := r.songCollection.CountDocuments(ctx, query)
+	if countErr != nil {
+		r.logger.Errorf("Failed to count songs in mongo: %v", countErr)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: query}},
+		{{Key: "$addFields", Value: bson.M{"_score": bson.M{"$meta": "textScore"}}}},
+	}
+	if cursor != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"$or": []bson.M{
+			{"_score": bson.M{"$lt": cursor.Score}},
+			{"_score": cursor.Score, "_id": bson.M{"$gt": cursor.ID}},
+		}}}})
+	}
+	// Fetch one extra song to know if there is another page
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "_score", Value: -1}, {Key: "_id", Value: 1}}}},
+		bson.D{{Key: "$limit", Value: pageSize + 1}},
+	)
+
+	cur, err := r.songCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Errorf("Failed to search songs in mongo: %v", err)
+		return nil, "", 0, mongoError(err)
+	}
+
+	docs := make([]*mongoSearchResult, 0)
+	if err := cur.All(ctx, &docs); err != nil {
+		r.logger.Errorf("Failed to get songs in mongo: %v", err)
+		return nil, "", 0, mongoError(err)
+	}
+
+	var nextToken string
+	if int64(len(docs)) > pageSize {
+		docs = docs[:pageSize]
+		last := docs[pageSize-1]
+		nextToken = encodePageToken(pageCursor{ID: last.ID, Score: last.Score, Sort: searchList})
+	}
+
+	results := make([]*SearchResult, len(docs))
+	for i, doc := range docs {
+		results[i] = &SearchResult{
+			Song:  r.MongoFilesToFiles([]*MongoFile{&doc.MongoFile})[0],
+			Score: doc.Score,
+		}
+	}
+
+	return results, nextToken, count, nil
+}
@@ -1,4 +1,4 @@
-package repository
+package repository_test
 
 import (
 	"context"
@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/TensorBeat/Datalake/internal/repository"
+	"github.com/TensorBeat/Datalake/internal/repository/repositorytest"
 	"github.com/TensorBeat/Datalake/internal/util"
 	"github.com/benweissmann/memongo"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -14,7 +16,7 @@ import (
 	"go.uber.org/zap"
 )
 
-var mongoRepo *MongoRepository
+var mongoClient *mongo.Client
 var logger *zap.SugaredLogger
 var ctx context.Context
 
@@ -25,13 +27,16 @@ func TestMain(m *testing.M) {
 	ctx = context.Background()
 
 	if err != nil {
-		logger.Fatalf("Couldn't start memongo: %v", err)
+		// The memory repository tests don't need mongo, so keep going and
+		// let the mongo tests skip themselves.
+		logger.Warnf("Couldn't start memongo, skipping mongo tests: %v", err)
+		os.Exit(m.Run())
 	}
 	defer mongoServer.Stop()
 
 	mongoCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	mongoClient, err := mongo.Connect(mongoCtx, options.Client().ApplyURI(mongoServer.URI()))
+	mongoClient, err = mongo.Connect(mongoCtx, options.Client().ApplyURI(mongoServer.URI()))
 	if err != nil {
 		logger.Fatalf("Couldn't connect to mongo: %v", err)
 	}
@@ -41,19 +46,16 @@ func TestMain(m *testing.M) {
 	if err != nil {
 		logger.Fatalf("Couldn't ping mongo: %v", err)
 	}
-	mongoRepo = NewMongoRepository(mongoClient, logger, memongo.RandomDatabase())
 
 	os.Exit(m.Run())
 }
 
-func TestGetSongsByTags(t *testing.T) {
-
-	addSongs := []*File{
-		{
-			Name: "SuperPop Song",
-			Uri:  "gs://example.mp3",
-		},
+func TestMongoRepository(t *testing.T) {
+	if mongoClient == nil {
+		t.Skip("memongo is not available")
 	}
 
-	mongoRepo.AddSongs(ctx, addSongs)
+	repositorytest.Run(t, func(t *testing.T) repository.Repository {
+		return repository.NewMongoRepository(mongoClient, logger, memongo.RandomDatabase())
+	})
 }
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UpsertSongs writes the songs one at a time since each one may be merged
+// into another song, songs without a URI are always inserted.
+func (r *MongoRepository) UpsertSongs(ctx context.Context, songs []*File) ([]*SongResult, error) {
+
+	if err := validateSongTagKeys(songs); err != nil {
+		r.logger.Error(err)
+		return nil, invalidArgument(err)
+	}
+
+	results := make([]*SongResult, len(songs))
+	failed := false
+	for i, song := range songs {
+		if failed {
+			results[i] = &SongResult{Err: ErrSongSkipped}
+			continue
+		}
+
+		if song.Uri == "" {
+			inserted, err := r.insertSongs(ctx, []*File{song}, true)
+			if err != nil {
+				inserted = []*SongResult{{Err: err}}
+			}
+			results[i] = inserted[0]
+		} else {
+			id, err := r.upsertSong(ctx, song)
+			// A concurrent upsert of the same URI may have inserted the
+			// song first, the retry merges into it
+			if isDuplicateUriError(err) {
+				id, err = r.upsertSong(ctx, song)
+			}
+			if isDuplicateUriError(err) {
+				err = fmt.Errorf("%w: %v", ErrUriTaken, song.Uri)
+			}
+			results[i] = &SongResult{ID: id, Err: err}
+		}
+		failed = results[i].Err != nil
+	}
+
+	r.logger.Infof("Upserted songs to mongo: %v", songs)
+
+	return results, nil
+}
+
+// upsertSong sets the tags of the live song with the URI of song, or inserts
+// song when there is none. A soft deleted song with the URI fails on the
+// unique index.
+func (r *MongoRepository) upsertSong(ctx context.Context, song *File) (string, error) {
+	if err := r.ensureUriIndex(ctx); err != nil {
+		return "", err
+	}
+	mongoFile := r.FilesToMongoFiles([]*File{song})[0]
+	if mongoFile.ID.IsZero() {
+		mongoFile.ID = primitive.NewObjectID()
+	}
+
+	// The URI is set from the filter on insert
+	onInsert := bson.M{"_id": mongoFile.ID}
+	if mongoFile.Name != "" {
+		onInsert[namePath] = mongoFile.Name
+	}
+	if mongoFile.MimeType != "" {
+		onInsert[mimeTypePath] = mongoFile.MimeType
+	}
+	update := bson.M{
+		"$setOnInsert": onInsert,
+		"$inc":         bson.M{revisionField: 1},
+	}
+	if len(mongoFile.Tags) > 0 {
+		tagsToSet := make(bson.M, len(mongoFile.Tags))
+		for tagName, val := range mongoFile.Tags {
+			tagsToSet[TagsPrefix+tagName] = val
+		}
+		update["$set"] = tagsToSet
+	}
+
+	filter := bson.M{
+		uriPath:        mongoFile.Uri,
+		deletedAtField: bson.M{"$exists": false},
+	}
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After).
+		SetProjection(bson.M{"_id": 1})
+
+	upserted := &MongoFile{}
+	if err := r.songCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(upserted); err != nil {
+		if !isDuplicateUriError(err) {
+			r.logger.Errorf("Failed to upsert song in mongo: %v", err)
+		}
+		return "", mongoError(err)
+	}
+	return upserted.ID.Hex(), nil
+}
+
+// ensureUriIndex builds the unique URI index before the first write that
+// sets a URI, as writes made without it could duplicate URIs. Writes are
+// unavailable until it exists, the next write tries again.
+func (r *MongoRepository) ensureUriIndex(ctx context.Context) error {
+	r.uriIndexMu.Lock()
+	defer r.uriIndexMu.Unlock()
+
+	if r.uriIndexReady {
+		return nil
+	}
+
+	err := createIndex(ctx, r.songCollection.Indexes(), r.indexSpecNamed(songCollectionName, uriIndexName))
+	if err != nil {
+		// An index that changed since still keeps URIs unique
+		existing, listErr := r.listIndexes(ctx, songCollectionName)
+		if listErr != nil {
+			return listErr
+		}
+		for _, index := range existing {
+			if index.Name == uriIndexName && index.Unique {
+				err = nil
+			}
+		}
+	}
+	if err != nil {
+		r.logger.Errorf("Failed to build the unique URI index: %v", err)
+		return &Error{Kind: ErrUnavailable, Resource: "song", Err: fmt.Errorf("URIs can't be kept unique: %v", err)}
+	}
+
+	r.uriIndexReady = true
+	return nil
+}
+
+// isDuplicateUriError reports whether err comes from the unique URI index.
+func isDuplicateUriError(err error) bool {
+	return isDuplicateKeyError(err) && strings.Contains(err.Error(), uriIndexName)
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// changeStreamNotSupported is the error code of opening a change stream
+	// on a standalone server.
+	changeStreamNotSupported = 40573
+	// changeStreamHistoryLost is the error code of resuming a change stream
+	// after the oplog rolled over the resume token.
+	changeStreamHistoryLost = 286
+)
+
+type changeEvent struct {
+	OperationType string `bson:"operationType"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument      *MongoFile `bson:"fullDocument"`
+	UpdateDescription struct {
+		UpdatedFields bson.M   `bson:"updatedFields"`
+		RemovedFields []string `bson:"removedFields"`
+	} `bson:"updateDescription"`
+}
+
+func (r *MongoRepository) WatchSongs(ctx context.Context, resumeToken string) (SongWatcher, error) {
+
+	streamOptions := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if resumeToken != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(resumeToken)
+		if err != nil || bson.Raw(raw).Validate() != nil {
+			r.logger.Errorf("%v: %v", ErrInvalidResumeToken, resumeToken)
+			return nil, ErrInvalidResumeToken
+		}
+		streamOptions.SetResumeAfter(bson.Raw(raw))
+	}
+
+	// Purges only remove songs that were already reported as deleted
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"operationType": bson.M{"$in": []string{"insert", "update", "replace"}},
+		}}},
+	}
+
+	stream, err := r.songCollection.Watch(ctx, pipeline, streamOptions)
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == changeStreamNotSupported {
+		r.logger.Errorf("Failed to watch songs: %v", err)
+		return nil, ErrWatchUnsupported
+	} else if isHistoryLostError(err) {
+		r.logger.Errorf("Failed to watch songs: %v", err)
+		return nil, ErrResumeTokenExpired
+	} else if err != nil {
+		r.logger.Errorf("Failed to watch songs: %v", err)
+		return nil, mongoError(err)
+	}
+
+	return &mongoSongWatcher{repo: r, stream: stream}, nil
+}
+
+// isHistoryLostError reports whether err comes from resuming after changes
+// that are no longer in the oplog.
+func isHistoryLostError(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Code == changeStreamHistoryLost
+}
+
+type mongoSongWatcher struct {
+	repo   *MongoRepository
+	stream *mongo.ChangeStream
+}
+
+func (w *mongoSongWatcher) Next(ctx context.Context) (*SongEvent, error) {
+	if !w.stream.Next(ctx) {
+		if err := w.stream.Err(); isHistoryLostError(err) {
+			return nil, ErrResumeTokenExpired
+		} else if err != nil {
+			return nil, mongoError(err)
+		}
+		return nil, ctx.Err()
+	}
+
+	change := &changeEvent{}
+	if err := w.stream.Decode(change); err != nil {
+		w.repo.logger.Errorf("Failed to decode change event: %v", err)
+		return nil, err
+	}
+
+	event := &SongEvent{
+		ID:          change.DocumentKey.ID.Hex(),
+		ResumeToken: base64.RawURLEncoding.EncodeToString(w.stream.ResumeToken()),
+	}
+	if change.FullDocument != nil {
+		event.Song = w.repo.MongoFilesToFiles([]*MongoFile{change.FullDocument})[0]
+	}
+
+	switch change.OperationType {
+	case "insert":
+		event.Type = SongInserted
+	case "replace":
+		event.Type = SongUpdated
+	default:
+		fields := make([]string, 0, len(change.UpdateDescription.UpdatedFields)+len(change.UpdateDescription.RemovedFields))
+		for field := range change.UpdateDescription.UpdatedFields {
+			fields = append(fields, field)
+		}
+		fields = append(fields, change.UpdateDescription.RemovedFields...)
+		event.Type = updateEventType(fields)
+	}
+
+	return event, nil
+}
+
+func (w *mongoSongWatcher) Close(ctx context.Context) error {
+	return w.stream.Close(ctx)
+}
+
+// updateEventType classifies an update by the top level fields it changed.
+// The revision changes with every update so it doesn't count.
+func updateEventType(fields []string) SongEventType {
+	tags, others := false, false
+	for _, field := range fields {
+		switch {
+		case field == deletedAtField:
+			return SongDeleted
+		case field == revisionField:
+		case field == TagsPath || strings.HasPrefix(field, TagsPrefix):
+			tags = true
+		default:
+			others = true
+		}
+	}
+
+	if tags && !others {
+		return SongTagsChanged
+	}
+	return SongUpdated
+}
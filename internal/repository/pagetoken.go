@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// searchList names search results in their page tokens.
+const searchList = "search"
+
+var ErrInvalidPageToken = newError(ErrInvalidArgument, "", "invalid page token")
+
+// pageCursor is the position after the last song of a page. Songs are
+// ordered by ID so a cursor stays valid while songs are being added. Sorted
+// pages are ordered by the Value of the Sort field first, search results by
+// Score.
+type pageCursor struct {
+	ID    primitive.ObjectID `bson:"id"`
+	Score float64            `bson:"score,omitempty"`
+	Sort  string             `bson:"sort,omitempty"`
+	Value interface{}        `bson:"value,omitempty"`
+}
+
+func encodePageToken(cursor pageCursor) string {
+	raw, err := bson.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeSortedPageToken decodes a token of a page sorted by order, tokens of
+// pages sorted another way are invalid.
+func decodeSortedPageToken(token string, order SortOrder) (*pageCursor, error) {
+	cursor, err := decodePageToken(token)
+	if err != nil || cursor == nil {
+		return cursor, err
+	}
+	if cursor.Sort != order.String() {
+		return nil, ErrInvalidPageToken
+	}
+	cursor.Value = normalizeSortValue(cursor.Value)
+	return cursor, nil
+}
+
+// decodeSearchPageToken decodes a token of search results, tokens of other
+// lists are invalid.
+func decodeSearchPageToken(token string) (*pageCursor, error) {
+	cursor, err := decodePageToken(token)
+	if err != nil || cursor == nil {
+		return cursor, err
+	}
+	if cursor.Sort != searchList {
+		return nil, ErrInvalidPageToken
+	}
+	return cursor, nil
+}
+
+// decodeValuePageToken decodes a token of the distinct values of list, whose
+// cursors only hold the last Value.
+func decodeValuePageToken(token string, list string) (*pageCursor, error) {
+	cursor, err := unmarshalPageToken(token)
+	if err != nil || cursor == nil {
+		return cursor, err
+	}
+	if cursor.Sort != list || cursor.Value == nil {
+		return nil, ErrInvalidPageToken
+	}
+	cursor.Value = normalizeSortValue(cursor.Value)
+	return cursor, nil
+}
+
+// decodePageToken returns nil for the empty token, which is the first page.
+func decodePageToken(token string) (*pageCursor, error) {
+	cursor, err := unmarshalPageToken(token)
+	if err != nil || cursor == nil {
+		return cursor, err
+	}
+	if cursor.ID.IsZero() {
+		return nil, ErrInvalidPageToken
+	}
+	return cursor, nil
+}
+
+func unmarshalPageToken(token string) (*pageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	cursor := &pageCursor{}
+	if err := bson.Unmarshal(raw, cursor); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	return cursor, nil
+}
+
+// normalizePageSize applies the default page size for 0 and caps it at
+// maxPageSize.
+func normalizePageSize(pageSize int64) (int64, error) {
+	switch {
+	case pageSize < 0:
+		return 0, fmt.Errorf("pageSize must be non-negative: %v", pageSize)
+	case pageSize == 0:
+		return defaultPageSize, nil
+	case pageSize > maxPageSize:
+		return maxPageSize, nil
+	}
+	return pageSize, nil
+}
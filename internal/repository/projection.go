@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// idPath and revisionField may be listed in read paths, the ID and the
+// revision are returned either way.
+const idPath = "id"
+
+// ValidateReadPaths checks that songs can be read with only the fields in
+// paths: id, revision, name, uri, mimeType, tags or tags.<key> for single
+// tags. No paths reads every field.
+func ValidateReadPaths(paths []string) error {
+	for _, path := range paths {
+		switch {
+		case path == idPath, path == revisionField, path == namePath, path == uriPath, path == mimeTypePath, path == TagsPath:
+		case strings.HasPrefix(path, TagsPrefix):
+			// Dots would read a nested field in mongo
+			if err := ValidateTagKey(strings.TrimPrefix(path, TagsPrefix)); err != nil {
+				return fmt.Errorf("invalid read path %q: %v", path, err)
+			}
+		default:
+			return fmt.Errorf("unknown read path: %q", path)
+		}
+	}
+	return nil
+}
+
+// readSelection is the set of fields read, single tags are dropped when
+// every tag is read since mongo rejects overlapping projections.
+func readSelection(paths []string) map[string]bool {
+	selected := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		if path != idPath && path != revisionField {
+			selected[path] = true
+		}
+	}
+	if selected[TagsPath] {
+		for path := range selected {
+			if strings.HasPrefix(path, TagsPrefix) {
+				delete(selected, path)
+			}
+		}
+	}
+	return selected
+}
+
+// mongoProjection only fetches the fields in paths and the field songs are
+// sorted by, which is needed for the next page token. It returns nil to fetch
+// every field.
+func mongoProjection(paths []string, order SortOrder) bson.M {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	if !order.sortedByID() {
+		paths = append(paths[:len(paths):len(paths)], order.Field)
+	}
+	projection := bson.M{"_id": 1, revisionField: 1}
+	for path := range readSelection(paths) {
+		projection[path] = 1
+	}
+	return projection
+}
+
+// projectFile clears the fields of file that aren't in paths.
+func projectFile(file *File, paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+
+	selected := readSelection(paths)
+	if !selected[namePath] {
+		file.Name = ""
+	}
+	if !selected[uriPath] {
+		file.Uri = ""
+	}
+	if !selected[mimeTypePath] {
+		file.MimeType = ""
+	}
+	if selected[TagsPath] {
+		return
+	}
+
+	tags := make(map[string]interface{})
+	for key, val := range file.AllTags() {
+		if selected[TagsPrefix+key] {
+			tags[key] = val
+		}
+	}
+	file.Tags, file.TypedTags = splitTags(tags)
+}
@@ -0,0 +1,2338 @@
+// Package repositorytest provides a conformance suite that every
+// repository.Repository implementation is expected to pass.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TensorBeat/Datalake/internal/query"
+	"github.com/TensorBeat/Datalake/internal/repository"
+	"github.com/TensorBeat/Datalake/pkg/proto"
+)
+
+// Factory returns a new, empty Repository. It is called once per test case so
+// implementations must not share songs between the repositories they return.
+type Factory func(t *testing.T) repository.Repository
+
+// Run exercises the full SongRepository contract against repositories made by
+// newRepo.
+func Run(t *testing.T, newRepo Factory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo repository.Repository)
+	}{
+		{"AddSongs", testAddSongs},
+		{"IngestSongs", testIngestSongs},
+		{"GetAllSongsPagination", testGetAllSongsPagination},
+		{"BadPagination", testBadPagination},
+		{"SortSongs", testSortSongs},
+		{"ReadFields", testReadFields},
+		{"TagFacets", testTagFacets},
+		{"ListTags", testListTags},
+		{"Indexes", testIndexes},
+		{"UniqueUris", testUniqueUris},
+		{"UniqueUrisWithoutSync", testUniqueUrisWithoutSync},
+		{"UpsertSongs", testUpsertSongs},
+		{"IdempotencyKeys", testIdempotencyKeys},
+		{"GetSongsByTags", testGetSongsByTags},
+		{"TagMatchModes", testTagMatchModes},
+		{"QuerySongs", testQuerySongs},
+		{"TypedTags", testTypedTags},
+		{"SearchSongs", testSearchSongs},
+		{"GetSongsByIDs", testGetSongsByIDs},
+		{"BadIDs", testBadIDs},
+		{"ErrorKinds", testErrorKinds},
+		{"InvalidTagKeys", testInvalidTagKeys},
+		{"StreamSongsByTags", testStreamSongsByTags},
+		{"AddTags", testAddTags},
+		{"RemoveTags", testRemoveTags},
+		{"BatchTags", testBatchTags},
+		{"TagMigrations", testTagMigrations},
+		{"TagMigrationRollbackWhileRunning", testTagMigrationRollbackWhileRunning},
+		{"UpdateSong", testUpdateSong},
+		{"Revisions", testRevisions},
+		{"DeleteSongs", testDeleteSongs},
+		{"WatchSongs", testWatchSongs},
+		{"PurgeDeletedSongs", testPurgeDeletedSongs},
+		{"TagSchemas", testTagSchemas},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepo(t))
+		})
+	}
+}
+
+var seedSongs = []*repository.File{
+	{
+		Name:     "Rock Song",
+		Uri:      "gs://test-tensorbeat-songs/rock.mp3",
+		MimeType: "audio/mpeg",
+		Tags:     map[string]string{"genre": "rock", "mood": "happy"},
+	},
+	{
+		Name:     "Sad Rock Song",
+		Uri:      "gs://test-tensorbeat-songs/sad-rock.mp3",
+		MimeType: "audio/mpeg",
+		Tags:     map[string]string{"genre": "rock", "mood": "sad"},
+	},
+	{
+		Name:     "Pop Song",
+		Uri:      "gs://test-tensorbeat-songs/pop.mp3",
+		MimeType: "audio/wav",
+		Tags:     map[string]string{"genre": "pop"},
+	},
+	{
+		Name:     "Untagged Song",
+		Uri:      "gs://test-tensorbeat-songs/untagged.mp3",
+		MimeType: "audio/mpeg",
+	},
+	{
+		Name:     "Jazz Song",
+		Uri:      "gs://test-tensorbeat-songs/jazz.mp3",
+		MimeType: "audio/mpeg",
+		Tags:     map[string]string{"genre": "jazz", "explicit": "true"},
+	},
+}
+
+// seed adds seedSongs to repo and returns them keyed by name with IDs set.
+func seed(t *testing.T, repo repository.Repository) map[string]*repository.File {
+	t.Helper()
+	ctx := context.Background()
+
+	results, err := repo.AddSongs(ctx, copyFiles(seedSongs))
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+	for i, result := range results {
+		if result.Err != nil {
+			t.Fatalf("AddSongs: song %v: %v", i, result.Err)
+		}
+	}
+
+	songs, _, _, err := repo.GetAllSongs(ctx, repository.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetAllSongs: %v", err)
+	}
+
+	byName := make(map[string]*repository.File, len(songs))
+	for _, song := range songs {
+		byName[song.Name] = song
+	}
+	return byName
+}
+
+func testAddSongs(t *testing.T, repo repository.Repository) {
+	songs := seed(t, repo)
+
+	if len(songs) != len(seedSongs) {
+		t.Fatalf("got %v songs, want %v", len(songs), len(seedSongs))
+	}
+	for _, want := range seedSongs {
+		got, ok := songs[want.Name]
+		if !ok {
+			t.Errorf("song %q was not stored", want.Name)
+			continue
+		}
+		if got.ID == "" {
+			t.Errorf("song %q has no ID", want.Name)
+		}
+		if got.Uri != want.Uri || got.MimeType != want.MimeType || !equalTags(got.Tags, want.Tags) {
+			t.Errorf("song %q = %+v, want %+v", want.Name, got, want)
+		}
+	}
+
+	ctx := context.Background()
+	batch := []*repository.File{
+		{Name: "First New Song", Uri: "gs://test-tensorbeat-songs/first.mp3"},
+		{Name: "Second New Song", Uri: "gs://test-tensorbeat-songs/second.mp3"},
+	}
+	results, err := repo.AddSongs(ctx, batch)
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+	if len(results) != len(batch) {
+		t.Fatalf("got %v results, want %v", len(results), len(batch))
+	}
+	// IDs come back in input order
+	for i, result := range results {
+		if result.Err != nil {
+			t.Fatalf("song %v: %v", i, result.Err)
+		}
+		if got := getSong(t, repo, result.ID); got.Name != batch[i].Name {
+			t.Errorf("ID %v belongs to %q, want %q", i, got.Name, batch[i].Name)
+		}
+	}
+
+	// A failure stops the songs after it but keeps the ones before
+	batch = []*repository.File{
+		{Name: "Third New Song", Uri: "gs://test-tensorbeat-songs/third.mp3"},
+		{ID: songs["Rock Song"].ID, Name: "Duplicate Song", Uri: "gs://test-tensorbeat-songs/duplicate.mp3"},
+		{Name: "Fourth New Song", Uri: "gs://test-tensorbeat-songs/fourth.mp3"},
+	}
+	results, err = repo.AddSongs(ctx, batch)
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+	if len(results) != len(batch) {
+		t.Fatalf("got %v results, want %v", len(results), len(batch))
+	}
+	if results[0].Err != nil || results[0].ID == "" {
+		t.Errorf("first song result = %+v, want an ID", results[0])
+	}
+	if results[1].Err == nil || results[1].ID != "" {
+		t.Errorf("duplicate song result = %+v, want an error", results[1])
+	}
+	if results[2].Err != repository.ErrSongSkipped {
+		t.Errorf("song after the duplicate result = %+v, want %v", results[2], repository.ErrSongSkipped)
+	}
+
+	all, _, total, err := repo.GetAllSongs(ctx, repository.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetAllSongs: %v", err)
+	}
+	if want := len(seedSongs) + 3; len(all) != want || total != int64(want) {
+		t.Errorf("got %v songs (total %v), want %v", len(all), total, want)
+	}
+}
+
+func testIngestSongs(t *testing.T, repo repository.Repository) {
+	songs := seed(t, repo)
+	ctx := context.Background()
+
+	batch := []*repository.File{
+		{Name: "First New Song", Uri: "gs://test-tensorbeat-songs/first.mp3"},
+		{ID: songs["Rock Song"].ID, Name: "Duplicate Song", Uri: "gs://test-tensorbeat-songs/duplicate.mp3"},
+		{Name: "Second New Song", Uri: "gs://test-tensorbeat-songs/second.mp3"},
+	}
+
+	results, err := repo.IngestSongs(ctx, batch)
+	if err != nil {
+		t.Fatalf("IngestSongs: %v", err)
+	}
+	if len(results) != len(batch) {
+		t.Fatalf("got %v results, want %v", len(results), len(batch))
+	}
+
+	// The duplicate fails without stopping the songs after it
+	if results[1].Err == nil || results[1].ID != "" {
+		t.Errorf("duplicate song result = %+v, want an error", results[1])
+	}
+	for _, i := range []int{0, 2} {
+		if results[i].Err != nil || results[i].ID == "" {
+			t.Errorf("song %v result = %+v, want an ID", i, results[i])
+			continue
+		}
+		if got := getSong(t, repo, results[i].ID); got.Name != batch[i].Name {
+			t.Errorf("song %v stored as %q, want %q", i, got.Name, batch[i].Name)
+		}
+	}
+	if got := getSong(t, repo, songs["Rock Song"].ID); got.Name != "Rock Song" {
+		t.Errorf("duplicate overwrote the existing song: %+v", got)
+	}
+
+	results, err = repo.IngestSongs(ctx, []*repository.File{})
+	if err != nil || len(results) != 0 {
+		t.Errorf("IngestSongs of no songs = %v, %v", results, err)
+	}
+}
+
+func testGetAllSongsPagination(t *testing.T, repo repository.Repository) {
+	seed(t, repo)
+	ctx := context.Background()
+	total := int64(len(seedSongs))
+
+	tests := []struct {
+		name     string
+		pageSize int64
+		pages    []int
+	}{
+		{"default page size", 0, []int{len(seedSongs)}},
+		{"even pages", 1, []int{1, 1, 1, 1, 1}},
+		{"last partial page", 2, []int{2, 2, 1}},
+		{"exact page size", int64(len(seedSongs)), []int{len(seedSongs)}},
+		{"page size larger than total", 100, []int{len(seedSongs)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := make(map[string]bool)
+			pageToken := ""
+			for i, want := range tt.pages {
+				songs, next, totalSize, err := repo.GetAllSongs(ctx, repository.ListOptions{PageToken: pageToken, PageSize: tt.pageSize})
+				if err != nil {
+					t.Fatalf("GetAllSongs: %v", err)
+				}
+				if len(songs) != want {
+					t.Errorf("page %v: got %v songs, want %v", i, len(songs), want)
+				}
+				if totalSize != total {
+					t.Errorf("page %v: got total size %v, want %v", i, totalSize, total)
+				}
+				for _, song := range songs {
+					if seen[song.ID] {
+						t.Errorf("song %v returned twice", song.ID)
+					}
+					seen[song.ID] = true
+				}
+
+				last := i == len(tt.pages)-1
+				if last && next != "" {
+					t.Errorf("page %v: got next page token %q on the last page", i, next)
+				}
+				if !last && next == "" {
+					t.Fatalf("page %v: got no next page token", i)
+				}
+				pageToken = next
+			}
+			if len(seen) != len(seedSongs) {
+				t.Errorf("paging visited %v songs, want %v", len(seen), len(seedSongs))
+			}
+		})
+	}
+
+	// Songs added while paging must not shift the pages already handed out
+	t.Run("concurrent inserts", func(t *testing.T) {
+		first, next, _, err := repo.GetAllSongs(ctx, repository.ListOptions{PageSize: 2})
+		if err != nil {
+			t.Fatalf("GetAllSongs: %v", err)
+		}
+		extra := []*repository.File{{Name: "Late Song", Uri: "gs://test-tensorbeat-songs/late.mp3"}}
+		if _, err := repo.AddSongs(ctx, extra); err != nil {
+			t.Fatalf("AddSongs: %v", err)
+		}
+
+		seen := make(map[string]bool)
+		for _, song := range first {
+			seen[song.ID] = true
+		}
+		for next != "" {
+			var songs []*repository.File
+			songs, next, _, err = repo.GetAllSongs(ctx, repository.ListOptions{PageToken: next, PageSize: 2})
+			if err != nil {
+				t.Fatalf("GetAllSongs: %v", err)
+			}
+			for _, song := range songs {
+				if seen[song.ID] {
+					t.Errorf("song %v returned twice", song.ID)
+				}
+				seen[song.ID] = true
+			}
+		}
+		if len(seen) != len(seedSongs)+1 {
+			t.Errorf("paging visited %v songs, want %v", len(seen), len(seedSongs)+1)
+		}
+	})
+}
+
+func testBadPagination(t *testing.T, repo repository.Repository) {
+	seed(t, repo)
+	ctx := context.Background()
+
+	for _, token := range []string{"not a token", "bm90IGEgdG9rZW4", "AAAAAA"} {
+		if _, _, _, err := repo.GetAllSongs(ctx, repository.ListOptions{PageToken: token}); err == nil {
+			t.Errorf("expected an error for page token %q", token)
+		}
+	}
+	if _, _, _, err := repo.GetAllSongs(ctx, repository.ListOptions{PageSize: -1}); err == nil {
+		t.Error("expected an error for a negative page size")
+	}
+}
+
+func testSortSongs(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	// rank has a value of every type, mixed types sort by type first
+	songs := []*repository.File{
+		{Name: "Bravo", TypedTags: map[string]interface{}{"rank": int64(2)}},
+		{Name: "Charlie", TypedTags: map[string]interface{}{"rank": 1.5}},
+		{Name: "Delta", Tags: map[string]string{"rank": "x"}},
+		{Name: "Echo"},
+		{Name: "Foxtrot", TypedTags: map[string]interface{}{"rank": true}},
+		{Name: "Golf", TypedTags: map[string]interface{}{"rank": time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		{Name: "Hotel", TypedTags: map[string]interface{}{"rank": int64(2)}},
+	}
+	if _, err := repo.AddSongs(ctx, copyFiles(songs)); err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	tests := []struct {
+		sort repository.SortOrder
+		want []string
+	}{
+		{
+			sort: repository.SortOrder{Field: repository.SortByName},
+			want: []string{"Bravo", "Charlie", "Delta", "Echo", "Foxtrot", "Golf", "Hotel"},
+		},
+		{
+			sort: repository.SortOrder{Field: repository.SortByName, Descending: true},
+			want: []string{"Hotel", "Golf", "Foxtrot", "Echo", "Delta", "Charlie", "Bravo"},
+		},
+		{
+			sort: repository.SortOrder{Field: repository.SortByCreatedTime, Descending: true},
+			want: []string{"Hotel", "Golf", "Foxtrot", "Echo", "Delta", "Charlie", "Bravo"},
+		},
+		{
+			sort: repository.SortOrder{Field: "tags.rank"},
+			want: []string{"Echo", "Charlie", "Bravo", "Hotel", "Delta", "Foxtrot", "Golf"},
+		},
+		{
+			// Ties are still ordered by ID
+			sort: repository.SortOrder{Field: "tags.rank", Descending: true},
+			want: []string{"Golf", "Foxtrot", "Delta", "Bravo", "Hotel", "Charlie", "Echo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort.String(), func(t *testing.T) {
+			for _, pageSize := range []int64{0, 1, 2, 3} {
+				got := make([]*repository.File, 0)
+				opts := repository.ListOptions{PageSize: pageSize, Sort: tt.sort}
+				for pages := 0; pages == 0 || opts.PageToken != ""; pages++ {
+					if pages > len(songs) {
+						t.Fatal("too many pages")
+					}
+					page, next, _, err := repo.GetAllSongs(ctx, opts)
+					if err != nil {
+						t.Fatalf("GetAllSongs: %v", err)
+					}
+					got = append(got, page...)
+					opts.PageToken = next
+				}
+
+				names := make([]string, len(got))
+				for i, song := range got {
+					names[i] = song.Name
+				}
+				if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+					t.Errorf("page size %v: got %v, want %v", pageSize, names, tt.want)
+				}
+			}
+		})
+	}
+
+	_, next, _, err := repo.GetAllSongs(ctx, repository.ListOptions{PageSize: 1, Sort: repository.SortOrder{Field: repository.SortByName}})
+	if err != nil {
+		t.Fatalf("GetAllSongs: %v", err)
+	}
+	if _, _, _, err := repo.GetAllSongs(ctx, repository.ListOptions{PageToken: next}); err == nil {
+		t.Error("GetAllSongs with a token of another sort order succeeded")
+	}
+	for _, order := range []repository.SortOrder{{Field: "uri"}, {Field: "tags."}, {Field: "tags.rank.max"}, {Field: "tags.$rank"}, {Descending: true}} {
+		if _, _, _, err := repo.GetAllSongs(ctx, repository.ListOptions{Sort: order}); err == nil {
+			t.Errorf("GetAllSongs sorted by %+v succeeded", order)
+		}
+	}
+}
+
+func testReadFields(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	songs := []*repository.File{
+		{Name: "Bravo", Uri: "gs://bravo", MimeType: "audio/mpeg", Tags: map[string]string{"genre": "rock", "mood": "happy"}, TypedTags: map[string]interface{}{"bpm": int64(120)}},
+		{Name: "Alpha", Uri: "gs://alpha", MimeType: "audio/mpeg", Tags: map[string]string{"mood": "sad"}},
+	}
+	if _, err := repo.AddSongs(ctx, copyFiles(songs)); err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	got, _, _, err := repo.GetAllSongs(ctx, repository.ListOptions{Fields: []string{"id", "name", "tags.genre", "tags.bpm"}})
+	if err != nil {
+		t.Fatalf("GetAllSongs: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %v songs, want 2", len(got))
+	}
+	for _, song := range got {
+		if song.ID == "" || song.Uri != "" || song.MimeType != "" || song.Tags["mood"] != "" {
+			t.Errorf("unread fields returned: %+v", song)
+		}
+	}
+	if got[0].Name != "Bravo" || got[0].Tags["genre"] != "rock" || got[0].TypedTags["bpm"] != int64(120) {
+		t.Errorf("read fields missing: %+v", got[0])
+	}
+	if len(got[1].Tags)+len(got[1].TypedTags) != 0 {
+		t.Errorf("got tags %v %v, want none", got[1].Tags, got[1].TypedTags)
+	}
+
+	// The sort field is read for the page token but not returned
+	opts := repository.ListOptions{
+		PageSize: 1,
+		Sort:     repository.SortOrder{Field: repository.SortByName},
+		Fields:   []string{"uri", "tags", "tags.mood"},
+	}
+	names := make([]string, 0)
+	for pages := 0; pages == 0 || opts.PageToken != ""; pages++ {
+		if pages > len(songs) {
+			t.Fatal("too many pages")
+		}
+		page, next, _, err := repo.GetAllSongs(ctx, opts)
+		if err != nil {
+			t.Fatalf("GetAllSongs: %v", err)
+		}
+		for _, song := range page {
+			if song.Name != "" || song.Tags["mood"] == "" {
+				t.Errorf("unexpected fields: %+v", song)
+			}
+			names = append(names, song.Uri)
+		}
+		opts.PageToken = next
+	}
+	if strings.Join(names, ",") != "gs://alpha,gs://bravo" {
+		t.Errorf("got %v, want sorted by name", names)
+	}
+
+	for _, fields := range [][]string{{"deletedAt"}, {"tags."}, {"tags.$bad"}, {"tags.a.b"}} {
+		if _, _, _, err := repo.GetAllSongs(ctx, repository.ListOptions{Fields: fields}); err == nil {
+			t.Errorf("GetAllSongs reading %v succeeded", fields)
+		}
+	}
+}
+
+func testTagFacets(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	// The same numbers and instants are counted together whatever their
+	// type or location
+	released := time.Date(2019, 3, 1, 12, 30, 0, 0, time.UTC)
+	songs := []*repository.File{
+		{Name: "a", Tags: map[string]string{"genre": "rock", "mood": "happy"}, TypedTags: map[string]interface{}{"bpm": int64(120), "released": released}},
+		{Name: "b", Tags: map[string]string{"genre": "rock", "mood": "sad"}, TypedTags: map[string]interface{}{"bpm": int64(90), "released": released.In(time.FixedZone("CET", 3600))}},
+		{Name: "c", Tags: map[string]string{"genre": "jazz", "mood": "happy"}, TypedTags: map[string]interface{}{"bpm": float64(120)}},
+		{Name: "d", Tags: map[string]string{"genre": "pop"}},
+		{Name: "e", Tags: map[string]string{"mood": "happy"}},
+	}
+	if _, err := repo.AddSongs(ctx, copyFiles(songs)); err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	format := func(facet *repository.TagFacet) string {
+		parts := make([]string, 0)
+		for _, val := range facet.Values {
+			parts = append(parts, fmt.Sprintf("%v=%v", val.Value, val.Count))
+		}
+		return fmt.Sprintf("%v: %v missing=%v other=%v", facet.Key, strings.Join(parts, ","), facet.MissingCount, facet.OtherCount)
+	}
+
+	tests := []struct {
+		name   string
+		tags   map[string]string
+		filter proto.Filter
+		limit  int64
+		want   []string
+	}{
+		{
+			name: "all songs",
+			want: []string{
+				"genre: rock=2,jazz=1,pop=1 missing=1 other=0",
+				"bpm: 120=2,90=1 missing=2 other=0",
+			},
+		},
+		{
+			name:  "limited",
+			limit: 1,
+			want: []string{
+				"genre: rock=2 missing=1 other=2",
+				"bpm: 120=2 missing=2 other=1",
+			},
+		},
+		{
+			name:   "filtered",
+			tags:   map[string]string{"mood": "happy"},
+			filter: proto.Filter_ALL,
+			want: []string{
+				"genre: jazz=1,rock=1 missing=1 other=0",
+				"bpm: 120=2 missing=1 other=0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facets, err := repo.GetTagFacets(ctx, []string{"genre", "bpm"}, tt.tags, tt.filter, tt.limit)
+			if err != nil {
+				t.Fatalf("GetTagFacets: %v", err)
+			}
+			got := make([]string, len(facets))
+			for i, facet := range facets {
+				got[i] = format(facet)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	facets, err := repo.GetTagFacets(ctx, []string{"released"}, nil, proto.Filter_ANY, 0)
+	if err != nil {
+		t.Fatalf("GetTagFacets: %v", err)
+	}
+	if len(facets[0].Values) != 1 || facets[0].Values[0].Count != 2 {
+		t.Errorf("got released facet %v, want one value counted twice", format(facets[0]))
+	}
+
+	for _, keys := range [][]string{nil, {""}, {"tags.genre"}, {"genre", "genre"}} {
+		if _, err := repo.GetTagFacets(ctx, keys, nil, proto.Filter_ANY, 0); err == nil {
+			t.Errorf("GetTagFacets of %q succeeded", keys)
+		}
+	}
+	if _, err := repo.GetTagFacets(ctx, []string{"genre"}, nil, proto.Filter_ANY, -1); err == nil {
+		t.Error("GetTagFacets with a negative limit succeeded")
+	}
+}
+
+func testListTags(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	songs := []*repository.File{
+		{Name: "a", Tags: map[string]string{"genre": "rock", "mood": "happy"}, TypedTags: map[string]interface{}{"rank": int64(2)}},
+		{Name: "b", Tags: map[string]string{"genre": "rock", "rank": "x"}},
+		{Name: "c", Tags: map[string]string{"genre": "jazz"}, TypedTags: map[string]interface{}{"rank": 1.5}},
+		{Name: "d", TypedTags: map[string]interface{}{"rank": 2.0}},
+	}
+	if _, err := repo.AddSongs(ctx, copyFiles(songs)); err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	for _, pageSize := range []int64{0, 1, 2} {
+		keys := make([]string, 0)
+		var token string
+		for pages := 0; pages == 0 || token != ""; pages++ {
+			if pages > 3 {
+				t.Fatal("too many pages")
+			}
+			page, next, err := repo.ListTagKeys(ctx, token, pageSize)
+			if err != nil {
+				t.Fatalf("ListTagKeys: %v", err)
+			}
+			for _, key := range page {
+				keys = append(keys, fmt.Sprintf("%v=%v", key.Key, key.Count))
+			}
+			token = next
+		}
+		if got, want := strings.Join(keys, ","), "genre=3,mood=1,rank=4"; got != want {
+			t.Errorf("page size %v: got keys %v, want %v", pageSize, got, want)
+		}
+
+		values := make([]string, 0)
+		token = ""
+		for pages := 0; pages == 0 || token != ""; pages++ {
+			if pages > 3 {
+				t.Fatal("too many pages")
+			}
+			page, next, err := repo.ListTagValues(ctx, "rank", token, pageSize)
+			if err != nil {
+				t.Fatalf("ListTagValues: %v", err)
+			}
+			for _, val := range page {
+				values = append(values, fmt.Sprintf("%v=%v", val.Value, val.Count))
+			}
+			token = next
+		}
+		// Values of different types are ordered by type, equal numbers are
+		// counted together
+		if got, want := strings.Join(values, ","), "1.5=1,2=2,x=1"; got != want {
+			t.Errorf("page size %v: got values %v, want %v", pageSize, got, want)
+		}
+	}
+
+	_, next, err := repo.ListTagValues(ctx, "genre", "", 1)
+	if err != nil {
+		t.Fatalf("ListTagValues: %v", err)
+	}
+	if _, _, err := repo.ListTagValues(ctx, "mood", next, 1); err == nil {
+		t.Error("ListTagValues with a token of another key succeeded")
+	}
+	if _, _, err := repo.ListTagKeys(ctx, next, 1); err == nil {
+		t.Error("ListTagKeys with a token of values succeeded")
+	}
+	if _, _, err := repo.ListTagValues(ctx, "", "", 0); err == nil {
+		t.Error("ListTagValues without a key succeeded")
+	}
+}
+
+func testIndexes(t *testing.T, repo repository.Repository) {
+	seed(t, repo)
+	ctx := context.Background()
+
+	if _, err := repo.SyncIndexes(ctx); err != nil {
+		t.Fatalf("SyncIndexes: %v", err)
+	}
+	drift, err := repo.CheckIndexes(ctx)
+	if err != nil {
+		t.Fatalf("CheckIndexes: %v", err)
+	}
+	if len(drift) != 0 {
+		t.Errorf("got drift %v after syncing", drift)
+	}
+
+	// Syncing again has nothing to do
+	drift, err = repo.SyncIndexes(ctx)
+	if err != nil {
+		t.Fatalf("SyncIndexes: %v", err)
+	}
+	if len(drift) != 0 {
+		t.Errorf("got drift %v after syncing", drift)
+	}
+
+	// The search index changes with the search tags, syncing leaves it
+	// and rebuilding replaces it
+	if err := repo.SetSearchTags([]string{"genre"}); err != nil {
+		t.Fatalf("SetSearchTags: %v", err)
+	}
+	if _, err := repo.SyncIndexes(ctx); err != nil {
+		t.Fatalf("SyncIndexes: %v", err)
+	}
+	drift, err = repo.CheckIndexes(ctx)
+	if err != nil {
+		t.Fatalf("CheckIndexes: %v", err)
+	}
+	for _, d := range drift {
+		if d.Kind != repository.IndexChanged {
+			t.Errorf("got drift %v after changing the search tags", d)
+		}
+	}
+	if _, err := repo.RebuildIndexes(ctx); err != nil {
+		t.Fatalf("RebuildIndexes: %v", err)
+	}
+	drift, err = repo.CheckIndexes(ctx)
+	if err != nil {
+		t.Fatalf("CheckIndexes: %v", err)
+	}
+	if len(drift) != 0 {
+		t.Errorf("got drift %v after rebuilding", drift)
+	}
+}
+
+func testUniqueUris(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	// Mongo enforces unique URIs with an index
+	if _, err := repo.SyncIndexes(ctx); err != nil {
+		t.Fatalf("SyncIndexes: %v", err)
+	}
+
+	results, err := repo.AddSongs(ctx, []*repository.File{
+		{Name: "a", Uri: "gs://songs/a"},
+		{Name: "no uri"},
+		{Name: "no uri either"},
+		{Name: "a again", Uri: "gs://songs/a"},
+		{Name: "b", Uri: "gs://songs/b"},
+	})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if results[i].Err != nil {
+			t.Errorf("song %v: %v", i, results[i].Err)
+		}
+	}
+	if !errors.Is(results[3].Err, repository.ErrUriTaken) {
+		t.Errorf("duplicate song: got %v, want ErrUriTaken", results[3].Err)
+	}
+	if results[4].Err != repository.ErrSongSkipped {
+		t.Errorf("song after the duplicate: got %v, want ErrSongSkipped", results[4].Err)
+	}
+
+	results, err = repo.IngestSongs(ctx, []*repository.File{
+		{Name: "a again", Uri: "gs://songs/a"},
+		{Name: "b", Uri: "gs://songs/b"},
+	})
+	if err != nil {
+		t.Fatalf("IngestSongs: %v", err)
+	}
+	if !errors.Is(results[0].Err, repository.ErrUriTaken) || results[1].Err != nil {
+		t.Errorf("IngestSongs: got %v, %v", results[0].Err, results[1].Err)
+	}
+
+	_, err = repo.UpdateSong(ctx, &repository.File{ID: results[1].ID, Uri: "gs://songs/a"}, []string{"uri"})
+	if !errors.Is(err, repository.ErrUriTaken) {
+		t.Errorf("UpdateSong to a taken URI: got %v, want ErrUriTaken", err)
+	}
+	// Freeing a URI lets another song take it
+	if _, err := repo.UpdateSong(ctx, &repository.File{ID: results[1].ID}, []string{"uri"}); err != nil {
+		t.Fatalf("UpdateSong: %v", err)
+	}
+	if results, err := repo.AddSongs(ctx, []*repository.File{{Name: "b again", Uri: "gs://songs/b"}}); err != nil || results[0].Err != nil {
+		t.Errorf("AddSongs with a freed URI: %v, %v", err, results)
+	}
+}
+
+// testUniqueUrisWithoutSync writes before the indexes were ever synced, the
+// first write has to make sure URIs are kept unique.
+func testUniqueUrisWithoutSync(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	results, err := repo.AddSongs(ctx, []*repository.File{{Name: "a", Uri: "gs://songs/a"}})
+	if err != nil || results[0].Err != nil {
+		t.Fatalf("AddSongs: %v, %v", err, results)
+	}
+	results, err = repo.AddSongs(ctx, []*repository.File{{Name: "a again", Uri: "gs://songs/a"}})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+	if !errors.Is(results[0].Err, repository.ErrUriTaken) {
+		t.Errorf("duplicate song: got %v, want ErrUriTaken", results[0].Err)
+	}
+
+	results, err = repo.UpsertSongs(ctx, []*repository.File{{Name: "b", Uri: "gs://songs/b"}, {Name: "c"}})
+	if err != nil || results[0].Err != nil || results[1].Err != nil {
+		t.Fatalf("UpsertSongs: %v, %v", err, results)
+	}
+	_, err = repo.UpdateSong(ctx, &repository.File{ID: results[1].ID, Uri: "gs://songs/b"}, []string{"uri"})
+	if !errors.Is(err, repository.ErrUriTaken) {
+		t.Errorf("UpdateSong to a taken URI: got %v, want ErrUriTaken", err)
+	}
+}
+
+func testUpsertSongs(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	if _, err := repo.SyncIndexes(ctx); err != nil {
+		t.Fatalf("SyncIndexes: %v", err)
+	}
+
+	results, err := repo.AddSongs(ctx, []*repository.File{
+		{Name: "Original", Uri: "gs://songs/a", MimeType: "audio/mpeg", Tags: map[string]string{"genre": "rock", "mood": "happy"}},
+		{Name: "Deleted", Uri: "gs://songs/deleted"},
+	})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+	id := results[0].ID
+	if _, err := repo.DeleteSongsByIDs(ctx, []string{results[1].ID}); err != nil {
+		t.Fatalf("DeleteSongsByIDs: %v", err)
+	}
+
+	results, err = repo.UpsertSongs(ctx, []*repository.File{
+		{Name: "Renamed", Uri: "gs://songs/a", Tags: map[string]string{"mood": "sad"}, TypedTags: map[string]interface{}{"bpm": int64(120)}},
+		{Name: "New", Uri: "gs://songs/b", Tags: map[string]string{"genre": "jazz"}},
+		{Name: "New again", Uri: "gs://songs/b", Tags: map[string]string{"mood": "calm"}},
+		{Name: "No uri"},
+		{Name: "Deleted again", Uri: "gs://songs/deleted"},
+		{Name: "Skipped", Uri: "gs://songs/c"},
+	})
+	if err != nil {
+		t.Fatalf("UpsertSongs: %v", err)
+	}
+
+	if results[0].Err != nil || results[0].ID != id {
+		t.Errorf("upsert of an existing URI: got %+v, want ID %v", results[0], id)
+	}
+	merged := getSong(t, repo, id)
+	if merged.Name != "Original" || merged.MimeType != "audio/mpeg" || merged.Tags["genre"] != "rock" || merged.Tags["mood"] != "sad" || merged.TypedTags["bpm"] != int64(120) {
+		t.Errorf("unexpected merged song: %+v", merged)
+	}
+
+	if results[1].Err != nil || results[2].Err != nil || results[1].ID != results[2].ID {
+		t.Errorf("upserts of a new URI: got %+v, %+v", results[1], results[2])
+	}
+	inserted := getSong(t, repo, results[1].ID)
+	if inserted.Name != "New" || inserted.Uri != "gs://songs/b" || inserted.Tags["genre"] != "jazz" || inserted.Tags["mood"] != "calm" {
+		t.Errorf("unexpected inserted song: %+v", inserted)
+	}
+
+	if results[3].Err != nil || results[3].ID == "" {
+		t.Errorf("upsert without a URI: got %+v", results[3])
+	}
+	if !errors.Is(results[4].Err, repository.ErrUriTaken) {
+		t.Errorf("upsert of a deleted song's URI: got %v, want ErrUriTaken", results[4].Err)
+	}
+	if results[5].Err != repository.ErrSongSkipped {
+		t.Errorf("song after the failure: got %v, want ErrSongSkipped", results[5].Err)
+	}
+}
+
+func testIdempotencyKeys(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	record := &repository.IdempotencyRecord{Key: "retry-me", Method: "AddSongs", RequestHash: "hash", Owner: "first"}
+	existing, err := repo.ReserveIdempotencyKey(ctx, record)
+	if err != nil || existing != nil {
+		t.Fatalf("ReserveIdempotencyKey: %v, %v", existing, err)
+	}
+
+	// A retry while the request runs finds it without a response
+	existing, err = repo.ReserveIdempotencyKey(ctx, record)
+	if err != nil {
+		t.Fatalf("ReserveIdempotencyKey: %v", err)
+	}
+	if existing == nil || existing.Method != "AddSongs" || existing.RequestHash != "hash" || existing.Response != nil {
+		t.Errorf("got %+v, want the running request", existing)
+	}
+	if err := repo.RenewIdempotencyKey(ctx, record.Key, record.Owner); err != nil {
+		t.Fatalf("RenewIdempotencyKey: %v", err)
+	}
+	existing, err = repo.ReserveIdempotencyKey(ctx, record)
+	if err != nil {
+		t.Fatalf("ReserveIdempotencyKey: %v", err)
+	}
+	if existing == nil || existing.Response != nil || existing.RenewedAt.Before(existing.CreatedAt) {
+		t.Errorf("got %+v, want the renewed running request", existing)
+	}
+
+	// Requests not holding the reservation can't complete or release it
+	if err := repo.CompleteIdempotencyKey(ctx, record.Key, "other", []byte("other response")); err != nil {
+		t.Fatalf("CompleteIdempotencyKey: %v", err)
+	}
+	if err := repo.ReleaseIdempotencyKey(ctx, record.Key, "other"); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey: %v", err)
+	}
+	existing, err = repo.ReserveIdempotencyKey(ctx, record)
+	if err != nil {
+		t.Fatalf("ReserveIdempotencyKey: %v", err)
+	}
+	if existing == nil || existing.Response != nil || existing.Owner != record.Owner {
+		t.Errorf("got %+v, want the request of the first owner still running", existing)
+	}
+
+	if err := repo.CompleteIdempotencyKey(ctx, record.Key, record.Owner, []byte("response")); err != nil {
+		t.Fatalf("CompleteIdempotencyKey: %v", err)
+	}
+	// Completed keys aren't renewed or released
+	if err := repo.RenewIdempotencyKey(ctx, record.Key, record.Owner); err != nil {
+		t.Fatalf("RenewIdempotencyKey: %v", err)
+	}
+	if err := repo.ReleaseIdempotencyKey(ctx, record.Key, record.Owner); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey: %v", err)
+	}
+	existing, err = repo.ReserveIdempotencyKey(ctx, record)
+	if err != nil {
+		t.Fatalf("ReserveIdempotencyKey: %v", err)
+	}
+	if existing == nil || string(existing.Response) != "response" {
+		t.Errorf("got %+v, want the completed request", existing)
+	}
+
+	// Released keys can be reserved again
+	failing := &repository.IdempotencyRecord{Key: "fail-me", Method: "AddTags", RequestHash: "hash", Owner: "failing"}
+	if _, err := repo.ReserveIdempotencyKey(ctx, failing); err != nil {
+		t.Fatalf("ReserveIdempotencyKey: %v", err)
+	}
+	if err := repo.ReleaseIdempotencyKey(ctx, failing.Key, failing.Owner); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey: %v", err)
+	}
+	existing, err = repo.ReserveIdempotencyKey(ctx, failing)
+	if err != nil || existing != nil {
+		t.Errorf("ReserveIdempotencyKey of a released key: %v, %v", existing, err)
+	}
+}
+
+func testGetSongsByTags(t *testing.T, repo repository.Repository) {
+	seed(t, repo)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		tags   map[string]string
+		filter proto.Filter
+		want   []string
+	}{
+		{
+			name:   "any",
+			tags:   map[string]string{"genre": "pop", "mood": "happy"},
+			filter: proto.Filter_ANY,
+			want:   []string{"Rock Song", "Pop Song"},
+		},
+		{
+			name:   "all",
+			tags:   map[string]string{"genre": "rock", "mood": "sad"},
+			filter: proto.Filter_ALL,
+			want:   []string{"Sad Rock Song"},
+		},
+		{
+			name:   "none",
+			tags:   map[string]string{"genre": "rock"},
+			filter: proto.Filter_NONE,
+			want:   []string{"Pop Song", "Untagged Song", "Jazz Song"},
+		},
+		{
+			name:   "exists",
+			tags:   map[string]string{"mood": "*"},
+			filter: proto.Filter_ANY,
+			want:   []string{"Rock Song", "Sad Rock Song"},
+		},
+		{
+			name:   "exists with value",
+			tags:   map[string]string{"genre": "*", "explicit": "true"},
+			filter: proto.Filter_ALL,
+			want:   []string{"Jazz Song"},
+		},
+		{
+			name:   "none exists",
+			tags:   map[string]string{"genre": "*"},
+			filter: proto.Filter_NONE,
+			want:   []string{"Untagged Song"},
+		},
+		{
+			name:   "no match",
+			tags:   map[string]string{"genre": "metal"},
+			filter: proto.Filter_ALL,
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			songs, _, totalSize, err := repo.GetSongsByTags(ctx, repository.ExactMatches(tt.tags), tt.filter, repository.ListOptions{})
+			if err != nil {
+				t.Fatalf("GetSongsByTags: %v", err)
+			}
+			assertNames(t, songs, tt.want)
+			if totalSize != int64(len(tt.want)) {
+				t.Errorf("got total size %v, want %v", totalSize, len(tt.want))
+			}
+		})
+	}
+
+	tags := map[string]string{"genre": "*"}
+	first, next, totalSize, err := repo.GetSongsByTags(ctx, repository.ExactMatches(tags), proto.Filter_ANY, repository.ListOptions{PageSize: 3})
+	if err != nil {
+		t.Fatalf("GetSongsByTags: %v", err)
+	}
+	rest, last, _, err := repo.GetSongsByTags(ctx, repository.ExactMatches(tags), proto.Filter_ANY, repository.ListOptions{PageToken: next, PageSize: 3})
+	if err != nil {
+		t.Fatalf("GetSongsByTags: %v", err)
+	}
+	if len(first) != 3 || len(rest) != 1 || totalSize != 4 || last != "" {
+		t.Errorf("got pages of %v and %v songs (total %v, last token %q), want 3 and 1 (total 4)", len(first), len(rest), totalSize, last)
+	}
+}
+
+func testTagMatchModes(t *testing.T, repo repository.Repository) {
+	seed(t, repo)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		tags   map[string]*repository.TagMatch
+		filter proto.Filter
+		want   []string
+	}{
+		{
+			name: "prefix",
+			tags: map[string]*repository.TagMatch{"genre": {Mode: proto.MatchMode_PREFIX, Value: "ro"}},
+			want: []string{"Rock Song", "Sad Rock Song"},
+		},
+		{
+			name: "prefix is literal",
+			tags: map[string]*repository.TagMatch{"genre": {Mode: proto.MatchMode_PREFIX, Value: "r."}},
+			want: []string{},
+		},
+		{
+			name: "case insensitive",
+			tags: map[string]*repository.TagMatch{"genre": {Mode: proto.MatchMode_CASE_INSENSITIVE, Value: "ROCK"}},
+			want: []string{"Rock Song", "Sad Rock Song"},
+		},
+		{
+			name: "case insensitive is exact",
+			tags: map[string]*repository.TagMatch{"genre": {Mode: proto.MatchMode_CASE_INSENSITIVE, Value: "ROC"}},
+			want: []string{},
+		},
+		{
+			name: "anchored regex",
+			tags: map[string]*repository.TagMatch{"genre": {Mode: proto.MatchMode_REGEX, Value: "^(pop|jazz)$"}},
+			want: []string{"Pop Song", "Jazz Song"},
+		},
+		{
+			name: "unanchored regex",
+			tags: map[string]*repository.TagMatch{"genre": {Mode: proto.MatchMode_REGEX, Value: "o[cp]"}},
+			want: []string{"Rock Song", "Sad Rock Song", "Pop Song"},
+		},
+		{
+			name: "in",
+			tags: map[string]*repository.TagMatch{"genre": {Mode: proto.MatchMode_IN, Values: []string{"pop", "jazz", "metal"}}},
+			want: []string{"Pop Song", "Jazz Song"},
+		},
+		{
+			name: "combined with exact",
+			tags: map[string]*repository.TagMatch{
+				"genre": {Mode: proto.MatchMode_PREFIX, Value: "rock"},
+				"mood":  {Value: "sad"},
+			},
+			filter: proto.Filter_ALL,
+			want:   []string{"Sad Rock Song"},
+		},
+		{
+			name:   "none",
+			tags:   map[string]*repository.TagMatch{"genre": {Mode: proto.MatchMode_IN, Values: []string{"rock", "pop"}}},
+			filter: proto.Filter_NONE,
+			want:   []string{"Untagged Song", "Jazz Song"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			songs, _, _, err := repo.GetSongsByTags(ctx, tt.tags, tt.filter, repository.ListOptions{})
+			if err != nil {
+				t.Fatalf("GetSongsByTags: %v", err)
+			}
+			assertNames(t, songs, tt.want)
+		})
+	}
+
+	invalid := []*repository.TagMatch{
+		{Mode: proto.MatchMode_PREFIX},
+		{Mode: proto.MatchMode_IN},
+		{Mode: proto.MatchMode_REGEX, Value: "(a+)+$"},
+		{Mode: proto.MatchMode_REGEX, Value: "a{500}"},
+		{Mode: proto.MatchMode_REGEX, Value: "(?=a)"},
+		{Mode: proto.MatchMode_REGEX, Value: strings.Repeat("a", 1000)},
+		{Mode: proto.MatchMode_REGEX, Value: "(a|ab)*c"},
+		{Mode: proto.MatchMode_REGEX, Value: `\d*\d*\d*\d*\d*x`},
+		{Mode: proto.MatchMode_REGEX, Value: "(?m)^rock"},
+		{Mode: proto.MatchMode_REGEX, Value: `(a)\1`},
+		{Mode: proto.MatchMode(42), Value: "rock"},
+	}
+	for _, match := range invalid {
+		tags := map[string]*repository.TagMatch{"genre": match}
+		if _, _, _, err := repo.GetSongsByTags(ctx, tags, proto.Filter_ANY, repository.ListOptions{}); err == nil {
+			t.Errorf("GetSongsByTags with %+v succeeded", match)
+		}
+	}
+
+	// PCRE's $ also matches before a trailing newline, RE2's doesn't
+	if _, err := repo.AddSongs(ctx, []*repository.File{{Name: "Newline Song", Tags: map[string]string{"style": "punk\n"}}}); err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+	for pattern, want := range map[string][]string{`^punk$`: {}, `^punk\n$`: {"Newline Song"}, `^punk\s`: {"Newline Song"}} {
+		tags := map[string]*repository.TagMatch{"style": {Mode: proto.MatchMode_REGEX, Value: pattern}}
+		songs, _, _, err := repo.GetSongsByTags(ctx, tags, proto.Filter_ANY, repository.ListOptions{})
+		if err != nil {
+			t.Fatalf("GetSongsByTags with %v: %v", pattern, err)
+		}
+		assertNames(t, songs, want)
+	}
+}
+
+func testQuerySongs(t *testing.T, repo repository.Repository) {
+	seed(t, repo)
+	ctx := context.Background()
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"genre=rock", []string{"Rock Song", "Sad Rock Song"}},
+		{"genre=rock AND (mood=happy OR mood=energetic) AND NOT explicit=*", []string{"Rock Song"}},
+		{"genre!=rock", []string{"Pop Song", "Untagged Song", "Jazz Song"}},
+		{"genre=* AND NOT (genre=rock OR explicit=true)", []string{"Pop Song"}},
+		{"mood!=*", []string{"Pop Song", "Untagged Song", "Jazz Song"}},
+		{"NOT genre=*", []string{"Untagged Song"}},
+		{`explicit="true" OR mood="sad"`, []string{"Sad Rock Song", "Jazz Song"}},
+		{"genre=metal", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := query.Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			songs, _, totalSize, err := repo.QuerySongs(ctx, q, repository.ListOptions{})
+			if err != nil {
+				t.Fatalf("QuerySongs: %v", err)
+			}
+			assertNames(t, songs, tt.want)
+			if totalSize != int64(len(tt.want)) {
+				t.Errorf("got total size %v, want %v", totalSize, len(tt.want))
+			}
+		})
+	}
+}
+
+func testTypedTags(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	// Mongo stores times with millisecond precision
+	released := time.Date(2019, 3, 1, 12, 30, 0, 0, time.UTC)
+	recorded := released.Add(1234567 * time.Nanosecond)
+	songs := []*repository.File{
+		{
+			Name:      "Slow Song",
+			TypedTags: map[string]interface{}{"bpm": int64(80), "duration": 200.5, "live": true, "released": released},
+		},
+		{
+			Name:      "Fast Song",
+			Tags:      map[string]string{"genre": "rock"},
+			TypedTags: map[string]interface{}{"bpm": int64(160), "duration": 180.25, "live": false, "released": released.AddDate(2, 0, 0)},
+		},
+		{Name: "String Bpm Song", Tags: map[string]string{"bpm": "120"}},
+		{Name: "Typed Explicit Song", TypedTags: map[string]interface{}{"explicit": true, "recorded": recorded}},
+		{Name: "String Explicit Song", Tags: map[string]string{"explicit": "true"}},
+	}
+	results, err := repo.AddSongs(ctx, copyFiles(songs))
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	got := getSong(t, repo, results[0].ID)
+	if len(got.Tags) != 0 || !equalTypedTags(got.TypedTags, songs[0].TypedTags) {
+		t.Errorf("got tags %v and typed tags %v, want typed tags %v", got.Tags, got.TypedTags, songs[0].TypedTags)
+	}
+	// Every backend drops what is below a millisecond
+	if got := getSong(t, repo, results[3].ID); got.TypedTags["recorded"] != recorded.Truncate(time.Millisecond) {
+		t.Errorf("got recorded %v, want %v", got.TypedTags["recorded"], recorded.Truncate(time.Millisecond))
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"bpm>100", []string{"Fast Song"}},
+		{"bpm=120", []string{"String Bpm Song"}},
+		{"bpm=80.0", []string{"Slow Song"}},
+		{"bpm BETWEEN 70 AND 170", []string{"Slow Song", "Fast Song"}},
+		{`bpm BETWEEN "100" AND "130"`, []string{"String Bpm Song"}},
+		{"duration<190", []string{"Fast Song"}},
+		{"live=true", []string{"Slow Song"}},
+		{"live!=true", []string{"Fast Song", "String Bpm Song", "Typed Explicit Song", "String Explicit Song"}},
+		{"released>=2020-01-01T00:00:00Z", []string{"Fast Song"}},
+		{"released<=2019-03-01T12:30:00Z AND bpm<=80", []string{"Slow Song"}},
+		{"explicit=true", []string{"Typed Explicit Song", "String Explicit Song"}},
+		{`explicit="true"`, []string{"String Explicit Song"}},
+		{"recorded=2019-03-01T12:30:00.001Z", []string{"Typed Explicit Song"}},
+		{"recorded=2019-03-01T12:30:00.0019Z", []string{"Typed Explicit Song"}},
+		{"recorded>2019-03-01T12:30:00.001Z", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := query.Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			songs, _, _, err := repo.QuerySongs(ctx, q, repository.ListOptions{})
+			if err != nil {
+				t.Fatalf("QuerySongs: %v", err)
+			}
+			assertNames(t, songs, tt.want)
+		})
+	}
+
+	bpmSongs, _, _, err := repo.GetSongsByTags(ctx, repository.ExactMatches(map[string]string{"bpm": "*"}), proto.Filter_ANY, repository.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetSongsByTags: %v", err)
+	}
+	assertNames(t, bpmSongs, []string{"Slow Song", "Fast Song", "String Bpm Song"})
+
+	// Setting a typed value replaces the string one
+	if _, err := repo.AddTags(ctx, results[2].ID, nil, map[string]interface{}{"bpm": int64(125)}, 0); err != nil {
+		t.Fatalf("AddTags: %v", err)
+	}
+	got = getSong(t, repo, results[2].ID)
+	if want := map[string]interface{}{"bpm": int64(125)}; len(got.Tags) != 0 || !equalTypedTags(got.TypedTags, want) {
+		t.Errorf("got tags %v and typed tags %v, want typed tags %v", got.Tags, got.TypedTags, want)
+	}
+
+	if _, err := repo.RemoveTags(ctx, results[0].ID, map[string]string{"bpm": "", "live": ""}, 0); err != nil {
+		t.Fatalf("RemoveTags: %v", err)
+	}
+	got = getSong(t, repo, results[0].ID)
+	if want := map[string]interface{}{"duration": 200.5, "released": released}; !equalTypedTags(got.TypedTags, want) {
+		t.Errorf("got typed tags %v, want %v", got.TypedTags, want)
+	}
+}
+
+func testSearchSongs(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	for _, keys := range [][]string{{"artist.name"}, {"$artist"}, {""}} {
+		if err := repo.SetSearchTags(keys); !errors.Is(err, repository.ErrInvalidArgument) {
+			t.Errorf("SetSearchTags(%q) = %v, want ErrInvalidArgument", keys, err)
+		}
+	}
+	if err := repo.SetSearchTags([]string{"artist"}); err != nil {
+		t.Fatalf("SetSearchTags: %v", err)
+	}
+	if _, err := repo.SyncIndexes(ctx); err != nil {
+		t.Fatalf("SyncIndexes: %v", err)
+	}
+	songs := []*repository.File{
+		{Name: "Midnight City", Tags: map[string]string{"artist": "M83", "genre": "electronic"}},
+		{Name: "City of Stars", Tags: map[string]string{"artist": "Ryan Gosling"}},
+		{Name: "Midnight City Remix", Tags: map[string]string{"artist": "M83"}},
+		{Name: "Another Song", Tags: map[string]string{"artist": "Midnight Club"}},
+	}
+	if _, err := repo.AddSongs(ctx, copyFiles(songs)); err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"city", []string{"Midnight City", "City of Stars", "Midnight City Remix"}},
+		{"MIDNIGHT -remix", []string{"Midnight City", "Another Song"}},
+		{`"city of"`, []string{"City of Stars"}},
+		{"m83", []string{"Midnight City", "Midnight City Remix"}},
+		{"electronic", []string{}},
+		{"-city", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			results, _, totalSize, err := repo.SearchSongs(ctx, tt.text, "", 0)
+			if err != nil {
+				t.Fatalf("SearchSongs: %v", err)
+			}
+			names := make([]*repository.File, len(results))
+			for i, result := range results {
+				names[i] = result.Song
+				if result.Score <= 0 {
+					t.Errorf("result %v has score %v", i, result.Score)
+				}
+				if i > 0 && result.Score > results[i-1].Score {
+					t.Errorf("result %v has score %v after %v", i, result.Score, results[i-1].Score)
+				}
+			}
+			assertNames(t, names, tt.want)
+			if totalSize != int64(len(tt.want)) {
+				t.Errorf("got total size %v, want %v", totalSize, len(tt.want))
+			}
+		})
+	}
+
+	// A name match ranks above a tag match
+	results, _, _, err := repo.SearchSongs(ctx, "midnight -remix", "", 0)
+	if err != nil {
+		t.Fatalf("SearchSongs: %v", err)
+	}
+	if len(results) != 2 || results[0].Song.Name != "Midnight City" {
+		t.Errorf("got results %+v, want Midnight City first", results)
+	}
+
+	seen := make(map[string]bool)
+	token := ""
+	for pages := 0; pages == 0 || token != ""; pages++ {
+		if pages > 4 {
+			t.Fatal("too many pages")
+		}
+		var page []*repository.SearchResult
+		page, token, _, err = repo.SearchSongs(ctx, "midnight city", token, 1)
+		if err != nil {
+			t.Fatalf("SearchSongs: %v", err)
+		}
+		for _, result := range page {
+			if seen[result.Song.ID] {
+				t.Errorf("song %q returned twice", result.Song.Name)
+			}
+			seen[result.Song.ID] = true
+		}
+	}
+	if len(seen) != len(songs) {
+		t.Errorf("got %v songs across pages, want %v", len(seen), len(songs))
+	}
+
+	// Page tokens of other lists are rejected rather than misread
+	_, searchToken, _, err := repo.SearchSongs(ctx, "city", "", 1)
+	if err != nil || searchToken == "" {
+		t.Fatalf("SearchSongs = %q, %v, want a page token", searchToken, err)
+	}
+	if _, _, _, err := repo.GetAllSongs(ctx, repository.ListOptions{PageToken: searchToken}); !errors.Is(err, repository.ErrInvalidArgument) {
+		t.Errorf("GetAllSongs with a search token = %v, want ErrInvalidArgument", err)
+	}
+	for _, order := range []repository.SortOrder{{}, {Field: repository.SortByName}} {
+		_, listToken, _, err := repo.GetAllSongs(ctx, repository.ListOptions{PageSize: 1, Sort: order})
+		if err != nil || listToken == "" {
+			t.Fatalf("GetAllSongs = %q, %v, want a page token", listToken, err)
+		}
+		if _, _, _, err := repo.SearchSongs(ctx, "city", listToken, 1); !errors.Is(err, repository.ErrInvalidArgument) {
+			t.Errorf("SearchSongs with a token of songs sorted by %q = %v, want ErrInvalidArgument", order, err)
+		}
+	}
+}
+
+func testGetSongsByIDs(t *testing.T, repo repository.Repository) {
+	songs := seed(t, repo)
+	ctx := context.Background()
+
+	ids := []string{songs["Rock Song"].ID, songs["Jazz Song"].ID, "602b29014accf1b3f3d462d0"}
+	got, _, totalSize, err := repo.GetSongsByIDs(ctx, ids, repository.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetSongsByIDs: %v", err)
+	}
+	assertNames(t, got, []string{"Rock Song", "Jazz Song"})
+	if totalSize != 2 {
+		t.Errorf("got total size %v, want 2", totalSize)
+	}
+
+	got, _, _, err = repo.GetSongsByIDs(ctx, []string{}, repository.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetSongsByIDs: %v", err)
+	}
+	assertNames(t, got, []string{})
+}
+
+func testBadIDs(t *testing.T, repo repository.Repository) {
+	songs := seed(t, repo)
+	ctx := context.Background()
+	tags := map[string]string{"genre": "rock"}
+
+	if _, _, _, err := repo.GetSongsByIDs(ctx, []string{songs["Rock Song"].ID, "not-an-id"}, repository.ListOptions{}); !errors.Is(err, repository.ErrInvalidID) {
+		t.Errorf("GetSongsByIDs with a malformed ID = %v, want ErrInvalidID", err)
+	}
+	if _, err := repo.AddTags(ctx, "not-an-id", tags, nil, 0); !errors.Is(err, repository.ErrInvalidID) {
+		t.Errorf("AddTags with a malformed ID = %v, want ErrInvalidID", err)
+	}
+	if _, err := repo.RemoveTags(ctx, "not-an-id", tags, 0); !errors.Is(err, repository.ErrInvalidID) {
+		t.Errorf("RemoveTags with a malformed ID = %v, want ErrInvalidID", err)
+	}
+	if _, err := repo.GetTagMigration(ctx, "not-an-id"); !errors.Is(err, repository.ErrInvalidID) {
+		t.Errorf("GetTagMigration with a malformed ID = %v, want ErrInvalidID", err)
+	}
+}
+
+func testErrorKinds(t *testing.T, repo repository.Repository) {
+	songs := seed(t, repo)
+	ctx := context.Background()
+	tags := map[string]string{"genre": "rock"}
+	missing := "5f0000000000000000000000"
+
+	if _, err := repo.AddTags(ctx, missing, tags, nil, 0); err != repository.ErrSongNotFound || !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("AddTags on a missing song = %v, want ErrSongNotFound", err)
+	}
+	if _, err := repo.RemoveTags(ctx, missing, tags, 0); err != repository.ErrSongNotFound {
+		t.Errorf("RemoveTags on a missing song = %v, want ErrSongNotFound", err)
+	}
+
+	deleted := songs["Pop Song"]
+	if _, err := repo.DeleteSongsByIDs(ctx, []string{deleted.ID}); err != nil {
+		t.Fatalf("DeleteSongsByIDs: %v", err)
+	}
+	if _, err := repo.AddTags(ctx, deleted.ID, tags, nil, 0); err != repository.ErrSongNotFound {
+		t.Errorf("AddTags on a deleted song = %v, want ErrSongNotFound", err)
+	}
+
+	if _, err := repo.GetTagMigration(ctx, missing); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("GetTagMigration of a missing migration = %v, want ErrNotFound", err)
+	}
+
+	if _, _, _, err := repo.GetSongsByTags(ctx, nil, proto.Filter_ANY, repository.ListOptions{}); !errors.Is(err, repository.ErrInvalidArgument) {
+		t.Errorf("GetSongsByTags without tags = %v, want ErrInvalidArgument", err)
+	}
+	if _, _, _, err := repo.GetSongsByTags(ctx, map[string]*repository.TagMatch{}, proto.Filter_ALL, repository.ListOptions{}); !errors.Is(err, repository.ErrInvalidArgument) {
+		t.Errorf("GetSongsByTags with empty tags = %v, want ErrInvalidArgument", err)
+	}
+	jazz := getSong(t, repo, songs["Jazz Song"].ID)
+	if _, err := repo.AddTags(ctx, jazz.ID, map[string]string{}, nil, 0); !errors.Is(err, repository.ErrInvalidArgument) {
+		t.Errorf("AddTags without tags = %v, want ErrInvalidArgument", err)
+	}
+	if _, err := repo.RemoveTags(ctx, jazz.ID, nil, 0); !errors.Is(err, repository.ErrInvalidArgument) {
+		t.Errorf("RemoveTags without tags = %v, want ErrInvalidArgument", err)
+	}
+	if got := getSong(t, repo, jazz.ID); got.Revision != jazz.Revision {
+		t.Errorf("writes without tags changed the revision to %v, want %v", got.Revision, jazz.Revision)
+	}
+	if _, _, _, err := repo.GetAllSongs(ctx, repository.ListOptions{PageToken: "not a token"}); !errors.Is(err, repository.ErrInvalidArgument) {
+		t.Errorf("GetAllSongs with a bad page token = %v, want ErrInvalidArgument", err)
+	}
+
+	rock := getSong(t, repo, songs["Rock Song"].ID)
+	update := &repository.File{ID: songs["Jazz Song"].ID, Uri: rock.Uri}
+	if _, err := repo.UpdateSong(ctx, update, []string{"uri"}); !errors.Is(err, repository.ErrConflict) {
+		t.Errorf("UpdateSong to a taken URI = %v, want ErrConflict", err)
+	}
+	if _, err := repo.AddTags(ctx, rock.ID, tags, nil, rock.Revision+1); !errors.Is(err, repository.ErrConflict) {
+		t.Errorf("AddTags expecting another revision = %v, want ErrConflict", err)
+	}
+}
+
+func testInvalidTagKeys(t *testing.T, repo repository.Repository) {
+	songs := seed(t, repo)
+	ctx := context.Background()
+	rock := songs["Rock Song"]
+
+	for _, key := range []string{"", "mood.inner", "$where"} {
+		tags := map[string]string{key: "happy"}
+		typedTags := map[string]interface{}{key: int64(1)}
+		song := &repository.File{Name: "Bad Key", Uri: "gs://test-tensorbeat-songs/bad-key.mp3", Tags: tags}
+
+		if _, err := repo.AddSongs(ctx, []*repository.File{song}); !errors.Is(err, repository.ErrInvalidArgument) {
+			t.Errorf("AddSongs with tag %q = %v, want ErrInvalidArgument", key, err)
+		}
+		if _, err := repo.IngestSongs(ctx, []*repository.File{{Name: "Bad Key", TypedTags: typedTags}}); !errors.Is(err, repository.ErrInvalidArgument) {
+			t.Errorf("IngestSongs with typed tag %q = %v, want ErrInvalidArgument", key, err)
+		}
+		if _, err := repo.UpsertSongs(ctx, []*repository.File{song}); !errors.Is(err, repository.ErrInvalidArgument) {
+			t.Errorf("UpsertSongs with tag %q = %v, want ErrInvalidArgument", key, err)
+		}
+		if _, err := repo.AddTags(ctx, rock.ID, nil, typedTags, 0); !errors.Is(err, repository.ErrInvalidArgument) {
+			t.Errorf("AddTags of %q = %v, want ErrInvalidArgument", key, err)
+		}
+		if _, err := repo.RemoveTags(ctx, rock.ID, tags, 0); !errors.Is(err, repository.ErrInvalidArgument) {
+			t.Errorf("RemoveTags of %q = %v, want ErrInvalidArgument", key, err)
+		}
+		update := &repository.File{ID: rock.ID, Tags: tags}
+		if _, err := repo.UpdateSong(ctx, update, []string{"tags"}); !errors.Is(err, repository.ErrInvalidArgument) {
+			t.Errorf("UpdateSong replacing the tags with %q = %v, want ErrInvalidArgument", key, err)
+		}
+	}
+
+	if got := getSong(t, repo, rock.ID); got.Revision != rock.Revision {
+		t.Errorf("rejected writes changed the song: %+v", got)
+	}
+	all, _, _, err := repo.GetAllSongs(ctx, repository.ListOptions{})
+	if err != nil || len(all) != len(songs) {
+		t.Errorf("GetAllSongs = %v songs, %v, want %v", len(all), err, len(songs))
+	}
+}
+
+func testStreamSongsByTags(t *testing.T, repo repository.Repository) {
+	seed(t, repo)
+	ctx := context.Background()
+
+	errStop := errors.New("stop")
+	stream := func(tags map[string]string, filter proto.Filter, resumeToken string, limit int) ([]*repository.File, []string, error) {
+		songs := make([]*repository.File, 0)
+		tokens := make([]string, 0)
+		err := repo.StreamSongsByTags(ctx, tags, filter, resumeToken, func(song *repository.File, token string) error {
+			if len(songs) == limit {
+				return errStop
+			}
+			songs = append(songs, song)
+			tokens = append(tokens, token)
+			return nil
+		})
+		return songs, tokens, err
+	}
+
+	all, tokens, err := stream(nil, proto.Filter_ANY, "", -1)
+	if err != nil {
+		t.Fatalf("StreamSongsByTags: %v", err)
+	}
+	if len(all) != len(seedSongs) {
+		t.Errorf("streamed %v songs, want %v", len(all), len(seedSongs))
+	}
+
+	rock, _, err := stream(map[string]string{"genre": "rock"}, proto.Filter_ALL, "", -1)
+	if err != nil {
+		t.Fatalf("StreamSongsByTags: %v", err)
+	}
+	assertNames(t, rock, []string{"Rock Song", "Sad Rock Song"})
+
+	// Resuming after the second song must stream exactly the remaining ones
+	rest, _, err := stream(nil, proto.Filter_ANY, tokens[1], -1)
+	if err != nil {
+		t.Fatalf("StreamSongsByTags: %v", err)
+	}
+	if len(rest) != len(all)-2 {
+		t.Fatalf("resumed stream returned %v songs, want %v", len(rest), len(all)-2)
+	}
+	for i, song := range rest {
+		if song.ID != all[i+2].ID {
+			t.Errorf("resumed stream song %v = %v, want %v", i, song.ID, all[i+2].ID)
+		}
+	}
+
+	// An error from send stops the stream and is returned
+	partial, _, err := stream(nil, proto.Filter_ANY, "", 1)
+	if err != errStop || len(partial) != 1 {
+		t.Errorf("got %v songs and error %v from a stream stopped after 1 song", len(partial), err)
+	}
+
+	if err := repo.StreamSongsByTags(ctx, nil, proto.Filter_ANY, "not a token", func(*repository.File, string) error { return nil }); err == nil {
+		t.Error("expected an error for a bad resume token")
+	}
+}
+
+func testAddTags(t *testing.T, repo repository.Repository) {
+	songs := seed(t, repo)
+	ctx := context.Background()
+
+	rock := songs["Rock Song"]
+	if _, err := repo.AddTags(ctx, rock.ID, map[string]string{"mood": "angry", "bpm": "120"}, nil, 0); err != nil {
+		t.Fatalf("AddTags: %v", err)
+	}
+	untagged := songs["Untagged Song"]
+	if _, err := repo.AddTags(ctx, untagged.ID, map[string]string{"genre": "folk"}, nil, 0); err != nil {
+		t.Fatalf("AddTags: %v", err)
+	}
+
+	got := getSong(t, repo, rock.ID)
+	want := map[string]string{"genre": "rock", "mood": "angry", "bpm": "120"}
+	if !equalTags(got.Tags, want) {
+		t.Errorf("got tags %v, want %v", got.Tags, want)
+	}
+
+	got = getSong(t, repo, untagged.ID)
+	want = map[string]string{"genre": "folk"}
+	if !equalTags(got.Tags, want) {
+		t.Errorf("got tags %v, want %v", got.Tags, want)
+	}
+}
+
+func testRemoveTags(t *testing.T, repo repository.Repository) {
+	songs := seed(t, repo)
+	ctx := context.Background()
+
+	rock := songs["Rock Song"]
+	if _, err := repo.RemoveTags(ctx, rock.ID, map[string]string{"mood": "", "missing": ""}, 0); err != nil {
+		t.Fatalf("RemoveTags: %v", err)
+	}
+
+	got := getSong(t, repo, rock.ID)
+	want := map[string]string{"genre": "rock"}
+	if !equalTags(got.Tags, want) {
+		t.Errorf("got tags %v, want %v", got.Tags, want)
+	}
+
+	found, _, _, err := repo.GetSongsByTags(ctx, repository.ExactMatches(map[string]string{"mood": "*"}), proto.Filter_ANY, repository.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetSongsByTags: %v", err)
+	}
+	assertNames(t, found, []string{"Sad Rock Song"})
+}
+
+func testBatchTags(t *testing.T, repo repository.Repository) {
+	songs := seed(t, repo)
+	ctx := context.Background()
+
+	rock, err := query.Parse("genre=rock")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	byQuery := repository.SongSelector{Query: rock}
+	byIDs := repository.SongSelector{IDs: []string{songs["Rock Song"].ID, songs["Pop Song"].ID, "602b29014accf1b3f3d462d0"}}
+
+	result, err := repo.BatchAddTags(ctx, byQuery, map[string]string{"mood": "happy"}, nil, true)
+	if err != nil {
+		t.Fatalf("BatchAddTags: %v", err)
+	}
+	if *result != (repository.BatchResult{MatchedCount: 2}) {
+		t.Errorf("dry run: got %+v, want 2 matched", result)
+	}
+	if got := getSong(t, repo, songs["Sad Rock Song"].ID); got.Tags["mood"] != "sad" {
+		t.Errorf("dry run changed tags to %v", got.Tags)
+	}
+
+	result, err = repo.BatchAddTags(ctx, byQuery, map[string]string{"mood": "happy"}, map[string]interface{}{"bpm": int64(120)}, false)
+	if err != nil {
+		t.Fatalf("BatchAddTags: %v", err)
+	}
+	if *result != (repository.BatchResult{MatchedCount: 2, ModifiedCount: 2}) {
+		t.Errorf("got %+v, want 2 matched and modified", result)
+	}
+	// An equal number of another type doesn't change the tag either
+	result, err = repo.BatchAddTags(ctx, byQuery, map[string]string{"mood": "happy"}, map[string]interface{}{"bpm": 120.0}, false)
+	if err != nil {
+		t.Fatalf("BatchAddTags: %v", err)
+	}
+	if *result != (repository.BatchResult{MatchedCount: 2}) {
+		t.Errorf("unchanged tags: got %+v, want 2 matched and none modified", result)
+	}
+	// Integers too large for a float64 are compared exactly
+	for _, plays := range []int64{1 << 53, 1<<53 + 1} {
+		result, err = repo.BatchAddTags(ctx, byQuery, nil, map[string]interface{}{"plays": plays}, false)
+		if err != nil {
+			t.Fatalf("BatchAddTags: %v", err)
+		}
+		if *result != (repository.BatchResult{MatchedCount: 2, ModifiedCount: 2}) {
+			t.Errorf("plays %v: got %+v, want 2 matched and modified", plays, result)
+		}
+	}
+
+	got := getSong(t, repo, songs["Sad Rock Song"].ID)
+	if want := map[string]string{"genre": "rock", "mood": "happy"}; !equalTags(got.Tags, want) || got.TypedTags["bpm"] != int64(120) {
+		t.Errorf("got tags %v %v, want %v and bpm 120", got.Tags, got.TypedTags, want)
+	}
+
+	result, err = repo.BatchRemoveTags(ctx, byIDs, []string{"mood", "bpm", "plays"}, false)
+	if err != nil {
+		t.Fatalf("BatchRemoveTags: %v", err)
+	}
+	if *result != (repository.BatchResult{MatchedCount: 2, ModifiedCount: 1}) {
+		t.Errorf("got %+v, want 2 matched and 1 modified", result)
+	}
+	if got := getSong(t, repo, songs["Rock Song"].ID); !equalTags(got.Tags, map[string]string{"genre": "rock"}) || len(got.TypedTags) != 0 {
+		t.Errorf("got tags %v %v, want only genre", got.Tags, got.TypedTags)
+	}
+
+	// IDs in upper case select the same songs
+	upper := repository.SongSelector{IDs: []string{strings.ToUpper(songs["Jazz Song"].ID)}}
+	result, err = repo.BatchAddTags(ctx, upper, map[string]string{"mood": "smooth"}, nil, false)
+	if err != nil {
+		t.Fatalf("BatchAddTags: %v", err)
+	}
+	if *result != (repository.BatchResult{MatchedCount: 1, ModifiedCount: 1}) {
+		t.Errorf("upper case ID: got %+v, want 1 matched and modified", result)
+	}
+
+	if _, err := repo.DeleteSongsByIDs(ctx, []string{songs["Sad Rock Song"].ID}); err != nil {
+		t.Fatalf("DeleteSongsByIDs: %v", err)
+	}
+	result, err = repo.BatchRemoveTags(ctx, byQuery, []string{"genre"}, true)
+	if err != nil {
+		t.Fatalf("BatchRemoveTags: %v", err)
+	}
+	if result.MatchedCount != 1 {
+		t.Errorf("deleted songs matched: got %+v, want 1 matched", result)
+	}
+
+	invalid := []repository.SongSelector{{}, {IDs: []string{"not-an-id"}}, {IDs: byIDs.IDs, Query: rock}}
+	for _, selector := range invalid {
+		if _, err := repo.BatchAddTags(ctx, selector, map[string]string{"mood": "happy"}, nil, false); err == nil {
+			t.Errorf("BatchAddTags with selector %+v: expected an error", selector)
+		}
+	}
+	for _, keys := range [][]string{nil, {"mood.inner"}, {"$mood"}, {""}} {
+		if _, err := repo.BatchRemoveTags(ctx, byQuery, keys, false); !errors.Is(err, repository.ErrInvalidArgument) {
+			t.Errorf("BatchRemoveTags of %q = %v, want ErrInvalidArgument", keys, err)
+		}
+	}
+	if _, err := repo.BatchAddTags(ctx, byQuery, map[string]string{"mood.inner": "happy"}, nil, false); !errors.Is(err, repository.ErrInvalidArgument) {
+		t.Errorf("BatchAddTags of a dotted key = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func testTagMigrations(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	results, err := repo.AddSongs(ctx, []*repository.File{
+		{Name: "a", Tags: map[string]string{"style": "rock"}},
+		{Name: "b", Tags: map[string]string{"style": "jazz", "genre": "jazz"}},
+		{Name: "c", TypedTags: map[string]interface{}{"style": int64(3)}},
+		{Name: "d", Tags: map[string]string{"genre": "hiphop"}},
+		{Name: "e", Tags: map[string]string{"genre": "pop"}},
+	})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	rename := &repository.TagMigrationSpec{Key: "style", NewKey: "genre"}
+	plan, err := repo.PlanTagMigration(ctx, rename)
+	if err != nil {
+		t.Fatalf("PlanTagMigration: %v", err)
+	}
+	if plan.ID != "" || plan.State != repository.MigrationPlanned || plan.MatchedCount != 3 || plan.ConflictCount != 1 {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+	if got := getSong(t, repo, results[0].ID); got.Tags["style"] != "rock" {
+		t.Errorf("dry run changed tags to %v", got.Tags)
+	}
+
+	migration, err := repo.CreateTagMigration(ctx, rename)
+	if err != nil {
+		t.Fatalf("CreateTagMigration: %v", err)
+	}
+
+	// Stop after the first batch and resume
+	stop := errors.New("stop")
+	batches := 0
+	_, err = repo.RunTagMigration(ctx, migration.ID, 1, func(progress *repository.TagMigration) error {
+		batches++
+		return stop
+	})
+	if err != stop {
+		t.Fatalf("RunTagMigration = %v, want the progress error", err)
+	}
+	migration, err = repo.RunTagMigration(ctx, migration.ID, 1, func(progress *repository.TagMigration) error {
+		batches++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunTagMigration: %v", err)
+	}
+	if batches < 3 {
+		t.Errorf("got %v batches of 1 song, want at least 3", batches)
+	}
+	if migration.State != repository.MigrationCompleted || migration.MatchedCount != 3 || migration.ModifiedCount != 2 || migration.ConflictCount != 1 {
+		t.Errorf("unexpected migration: %+v", migration)
+	}
+
+	got := getSong(t, repo, results[0].ID)
+	if want := map[string]string{"genre": "rock"}; !equalTags(got.Tags, want) {
+		t.Errorf("got tags %v, want %v", got.Tags, want)
+	}
+	got = getSong(t, repo, results[1].ID)
+	if want := map[string]string{"style": "jazz", "genre": "jazz"}; !equalTags(got.Tags, want) {
+		t.Errorf("conflicting song: got tags %v, want %v", got.Tags, want)
+	}
+	got = getSong(t, repo, results[2].ID)
+	if want := map[string]interface{}{"genre": int64(3)}; !equalTypedTags(got.TypedTags, want) {
+		t.Errorf("got typed tags %v, want %v", got.TypedTags, want)
+	}
+
+	if _, err := repo.RunTagMigration(ctx, migration.ID, 0, func(*repository.TagMigration) error { return nil }); err != repository.ErrTagMigrationState {
+		t.Errorf("running a completed migration = %v, want ErrTagMigrationState", err)
+	}
+
+	remap := &repository.TagMigrationSpec{Key: "genre", Values: map[string]string{"hiphop": "hip-hop", "rock": "classic rock"}}
+	plan, err = repo.PlanTagMigration(ctx, remap)
+	if err != nil {
+		t.Fatalf("PlanTagMigration: %v", err)
+	}
+	if plan.MatchedCount != 2 || plan.ConflictCount != 0 {
+		t.Errorf("unexpected remap plan: %+v", plan)
+	}
+
+	// Songs changed after the migration are left alone by the rollback
+	if _, err := repo.AddTags(ctx, results[0].ID, map[string]string{"genre": "metal"}, nil, 0); err != nil {
+		t.Fatalf("AddTags: %v", err)
+	}
+	migration, err = repo.RollBackTagMigration(ctx, migration.ID, 0, func(*repository.TagMigration) error { return nil })
+	if err != nil {
+		t.Fatalf("RollBackTagMigration: %v", err)
+	}
+	if migration.State != repository.MigrationRolledBack || migration.RolledBackCount != 1 || migration.RollbackConflictCount != 1 {
+		t.Errorf("unexpected rolled back migration: %+v", migration)
+	}
+	got = getSong(t, repo, results[2].ID)
+	if want := map[string]interface{}{"style": int64(3)}; !equalTypedTags(got.TypedTags, want) {
+		t.Errorf("got typed tags %v, want %v", got.TypedTags, want)
+	}
+	got = getSong(t, repo, results[0].ID)
+	if want := map[string]string{"genre": "metal"}; !equalTags(got.Tags, want) {
+		t.Errorf("song changed after the migration: got tags %v, want %v", got.Tags, want)
+	}
+
+	stored, err := repo.GetTagMigration(ctx, migration.ID)
+	if err != nil {
+		t.Fatalf("GetTagMigration: %v", err)
+	}
+	if stored.State != repository.MigrationRolledBack || stored.ModifiedCount != 2 {
+		t.Errorf("unexpected stored migration: %+v", stored)
+	}
+	if _, err := repo.RollBackTagMigration(ctx, migration.ID, 0, func(*repository.TagMigration) error { return nil }); err != repository.ErrTagMigrationState {
+		t.Errorf("rolling back twice = %v, want ErrTagMigrationState", err)
+	}
+	if _, err := repo.GetTagMigration(ctx, "602b29014accf1b3f3d462d0"); err != repository.ErrTagMigrationNotFound {
+		t.Errorf("GetTagMigration of an unknown ID = %v, want ErrTagMigrationNotFound", err)
+	}
+
+	invalid := []*repository.TagMigrationSpec{
+		{Key: "style"},
+		{Key: "style", NewKey: "style"},
+		{Key: "style", NewKey: "genre", Values: map[string]string{"a": "b"}},
+		{Key: "genre", Values: map[string]string{"a": "b", "b": "c"}},
+		{Key: "tags.style", NewKey: "genre"},
+	}
+	for _, spec := range invalid {
+		if _, err := repo.CreateTagMigration(ctx, spec); err == nil {
+			t.Errorf("CreateTagMigration(%+v): expected an error", spec)
+		}
+	}
+}
+
+// testTagMigrationRollbackWhileRunning rolls a migration back between two
+// of its batches, the run stops and every song it changed is restored.
+func testTagMigrationRollbackWhileRunning(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	results, err := repo.AddSongs(ctx, []*repository.File{
+		{Name: "a", Tags: map[string]string{"era": "70s"}},
+		{Name: "b", Tags: map[string]string{"era": "70s"}},
+		{Name: "c", Tags: map[string]string{"era": "70s"}},
+	})
+	if err != nil {
+		t.Fatalf("AddSongs: %v", err)
+	}
+
+	migration, err := repo.CreateTagMigration(ctx, &repository.TagMigrationSpec{Key: "era", Values: map[string]string{"70s": "seventies"}})
+	if err != nil {
+		t.Fatalf("CreateTagMigration: %v", err)
+	}
+
+	var rolledBack *repository.TagMigration
+	_, err = repo.RunTagMigration(ctx, migration.ID, 1, func(progress *repository.TagMigration) error {
+		if rolledBack != nil {
+			t.Errorf("batch %+v ran after the rollback", progress)
+			return nil
+		}
+		var err error
+		rolledBack, err = repo.RollBackTagMigration(ctx, migration.ID, 0, func(*repository.TagMigration) error { return nil })
+		if err != nil {
+			t.Fatalf("RollBackTagMigration: %v", err)
+		}
+		return nil
+	})
+	if err != repository.ErrTagMigrationState {
+		t.Errorf("RunTagMigration = %v, want ErrTagMigrationState", err)
+	}
+	if rolledBack == nil || rolledBack.State != repository.MigrationRolledBack || rolledBack.RolledBackCount != 1 {
+		t.Errorf("unexpected rolled back migration: %+v", rolledBack)
+	}
+	for _, result := range results {
+		if got := getSong(t, repo, result.ID); got.Tags["era"] != "70s" {
+			t.Errorf("got tags %v after the rollback, want era 70s", got.Tags)
+		}
+	}
+}
+
+func testUpdateSong(t *testing.T, repo repository.Repository) {
+	songs := seed(t, repo)
+	ctx := context.Background()
+
+	rock := songs["Rock Song"]
+	tests := []struct {
+		name  string
+		patch *repository.File
+		paths []string
+		want  *repository.File
+	}{
+		{
+			name:  "fields",
+			patch: &repository.File{Name: "Renamed Song", Uri: "ignored", MimeType: "audio/flac"},
+			paths: []string{"name", "mimeType"},
+			want: &repository.File{
+				Name: "Renamed Song", Uri: rock.Uri, MimeType: "audio/flac",
+				Tags: map[string]string{"genre": "rock", "mood": "happy"},
+			},
+		},
+		{
+			name:  "single tags",
+			patch: &repository.File{Tags: map[string]string{"genre": "punk", "bpm": "180"}},
+			paths: []string{"tags.genre", "tags.bpm", "tags.mood"},
+			want: &repository.File{
+				Name: "Renamed Song", Uri: rock.Uri, MimeType: "audio/flac",
+				Tags: map[string]string{"genre": "punk", "bpm": "180"},
+			},
+		},
+		{
+			name:  "all tags",
+			patch: &repository.File{Uri: "gs://test-tensorbeat-songs/moved.mp3", Tags: map[string]string{"explicit": "false"}},
+			paths: []string{"tags", "uri"},
+			want: &repository.File{
+				Name: "Renamed Song", Uri: "gs://test-tensorbeat-songs/moved.mp3", MimeType: "audio/flac",
+				Tags: map[string]string{"explicit": "false"},
+			},
+		},
+		{
+			name:  "clear tags",
+			patch: &repository.File{},
+			paths: []string{"tags"},
+			want: &repository.File{
+				Name: "Renamed Song", Uri: "gs://test-tensorbeat-songs/moved.mp3", MimeType: "audio/flac",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.patch.ID = rock.ID
+			updated, err := repo.UpdateSong(ctx, tt.patch, tt.paths)
+			if err != nil {
+				t.Fatalf("UpdateSong: %v", err)
+			}
+
+			for _, got := range []*repository.File{updated, getSong(t, repo, rock.ID)} {
+				if got.ID != rock.ID || got.Name != tt.want.Name || got.Uri != tt.want.Uri ||
+					got.MimeType != tt.want.MimeType || !equalTags(got.Tags, tt.want.Tags) {
+					t.Errorf("got %+v, want %+v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	pop := songs["Pop Song"]
+	if _, err := repo.DeleteSongsByIDs(ctx, []string{pop.ID}); err != nil {
+		t.Fatalf("DeleteSongsByIDs: %v", err)
+	}
+	for _, id := range []string{"602b29014accf1b3f3d462d0", pop.ID} {
+		if _, err := repo.UpdateSong(ctx, &repository.File{ID: id}, []string{"name"}); err != repository.ErrSongNotFound {
+			t.Errorf("UpdateSong(%v) = %v, want %v", id, err, repository.ErrSongNotFound)
+		}
+	}
+
+	invalid := [][]string{nil, {"id"}, {"tags", "tags.genre"}, {"name", "name"}, {"tags."}, {"tags.a.b"}}
+	for _, paths := range invalid {
+		if _, err := repo.UpdateSong(ctx, &repository.File{ID: rock.ID}, paths); err == nil {
+			t.Errorf("UpdateSong with paths %v: expected an error", paths)
+		}
+	}
+	if _, err := repo.UpdateSong(ctx, &repository.File{ID: "not-an-id"}, []string{"name"}); err == nil {
+		t.Error("UpdateSong: expected an error for a malformed ID")
+	}
+}
+
+func testRevisions(t *testing.T, repo repository.Repository) {
+	songs := seed(t, repo)
+	ctx := context.Background()
+
+	rock := songs["Rock Song"]
+	if rock.Revision != 1 {
+		t.Fatalf("new song has revision %v, want 1", rock.Revision)
+	}
+
+	revision, err := repo.AddTags(ctx, rock.ID, map[string]string{"bpm": "120"}, nil, 1)
+	if err != nil || revision != 2 {
+		t.Fatalf("AddTags = %v, %v, want revision 2", revision, err)
+	}
+
+	var mismatch *repository.RevisionMismatchError
+	_, err = repo.AddTags(ctx, rock.ID, map[string]string{"bpm": "90"}, nil, 1)
+	if !errors.As(err, &mismatch) || !mismatch.Stale() || mismatch.Current != 2 || !errors.Is(err, repository.ErrRevisionMismatch) {
+		t.Errorf("AddTags with a stale revision = %v, want a stale RevisionMismatchError", err)
+	}
+	_, err = repo.RemoveTags(ctx, rock.ID, map[string]string{"bpm": ""}, 5)
+	if !errors.As(err, &mismatch) || mismatch.Stale() {
+		t.Errorf("RemoveTags with a future revision = %v, want a RevisionMismatchError", err)
+	}
+	if got := getSong(t, repo, rock.ID); got.Tags["bpm"] != "120" || got.Revision != 2 {
+		t.Errorf("rejected writes changed the song: %+v", got)
+	}
+
+	revision, err = repo.RemoveTags(ctx, rock.ID, map[string]string{"bpm": ""}, 0)
+	if err != nil || revision != 3 {
+		t.Errorf("RemoveTags without a revision = %v, %v, want revision 3", revision, err)
+	}
+
+	updated, err := repo.UpdateSong(ctx, &repository.File{ID: rock.ID, Name: "Renamed", Revision: 3}, []string{"name"})
+	if err != nil || updated.Revision != 4 {
+		t.Fatalf("UpdateSong = %+v, %v, want revision 4", updated, err)
+	}
+	if _, err := repo.UpdateSong(ctx, &repository.File{ID: rock.ID, Name: "Stale", Revision: 3}, []string{"name"}); !errors.As(err, &mismatch) {
+		t.Errorf("UpdateSong with a stale revision = %v, want a RevisionMismatchError", err)
+	}
+
+	// Batches only change the revision of songs they modify
+	selector := repository.SongSelector{IDs: []string{rock.ID}}
+	result, err := repo.BatchAddTags(ctx, selector, map[string]string{"genre": "rock"}, nil, false)
+	if err != nil {
+		t.Fatalf("BatchAddTags: %v", err)
+	}
+	if result.MatchedCount != 1 || result.ModifiedCount != 0 {
+		t.Errorf("BatchAddTags of an unchanged tag = %+v, want 1 matched and 0 modified", result)
+	}
+	if got := getSong(t, repo, rock.ID); got.Revision != 4 {
+		t.Errorf("unchanged song has revision %v, want 4", got.Revision)
+	}
+	if _, err := repo.BatchAddTags(ctx, selector, map[string]string{"genre": "punk"}, nil, false); err != nil {
+		t.Fatalf("BatchAddTags: %v", err)
+	}
+
+	read, _, _, err := repo.GetSongsByIDs(ctx, []string{rock.ID}, repository.ListOptions{Fields: []string{"name"}})
+	if err != nil {
+		t.Fatalf("GetSongsByIDs: %v", err)
+	}
+	if len(read) != 1 || read[0].Revision != 5 {
+		t.Errorf("got %+v, want revision 5 with a read mask", read)
+	}
+
+	// Songs with a revision are left alone
+	if backfilled, err := repo.BackfillRevisions(ctx); err != nil || backfilled != 0 {
+		t.Errorf("BackfillRevisions = %v, %v, want 0", backfilled, err)
+	}
+	if got := getSong(t, repo, rock.ID); got.Revision != 5 {
+		t.Errorf("backfilled song has revision %v, want 5", got.Revision)
+	}
+
+	if _, err := repo.AddTags(ctx, "602b29014accf1b3f3d462d0", map[string]string{"bpm": "90"}, nil, 1); err != repository.ErrSongNotFound {
+		t.Errorf("AddTags of a missing song with a revision = %v, want ErrSongNotFound", err)
+	}
+}
+
+func testDeleteSongs(t *testing.T, repo repository.Repository) {
+	songs := seed(t, repo)
+	ctx := context.Background()
+
+	deleted, err := repo.DeleteSongsByIDs(ctx, []string{songs["Pop Song"].ID, "602b29014accf1b3f3d462d0"})
+	if err != nil {
+		t.Fatalf("DeleteSongsByIDs: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted %v songs by ID, want 1", deleted)
+	}
+
+	deleted, err = repo.DeleteSongsByTags(ctx, map[string]string{"genre": "rock", "mood": "sad"}, proto.Filter_ALL)
+	if err != nil {
+		t.Fatalf("DeleteSongsByTags: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted %v songs by tags, want 1", deleted)
+	}
+
+	// Deleting again doesn't count songs that are already deleted
+	deleted, err = repo.DeleteSongsByIDs(ctx, []string{songs["Pop Song"].ID})
+	if err != nil || deleted != 0 {
+		t.Errorf("deleting a deleted song = %v, %v, want 0", deleted, err)
+	}
+
+	// Every read path hides deleted songs
+	remaining := []string{"Rock Song", "Untagged Song", "Jazz Song"}
+	all, _, total, err := repo.GetAllSongs(ctx, repository.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetAllSongs: %v", err)
+	}
+	assertNames(t, all, remaining)
+	if total != int64(len(remaining)) {
+		t.Errorf("got total size %v, want %v", total, len(remaining))
+	}
+
+	byID, _, _, err := repo.GetSongsByIDs(ctx, []string{songs["Pop Song"].ID, songs["Rock Song"].ID}, repository.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetSongsByIDs: %v", err)
+	}
+	assertNames(t, byID, []string{"Rock Song"})
+
+	byTags, _, _, err := repo.GetSongsByTags(ctx, repository.ExactMatches(map[string]string{"genre": "*"}), proto.Filter_ANY, repository.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetSongsByTags: %v", err)
+	}
+	assertNames(t, byTags, []string{"Rock Song", "Jazz Song"})
+
+	streamed := make([]*repository.File, 0)
+	err = repo.StreamSongsByTags(ctx, nil, proto.Filter_ANY, "", func(song *repository.File, _ string) error {
+		streamed = append(streamed, song)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamSongsByTags: %v", err)
+	}
+	assertNames(t, streamed, remaining)
+
+	if _, err := repo.DeleteSongsByIDs(ctx, []string{"not-an-id"}); err == nil {
+		t.Error("DeleteSongsByIDs: expected an error for a malformed ID")
+	}
+	if _, err := repo.DeleteSongsByTags(ctx, map[string]string{}, proto.Filter_NONE); err == nil {
+		t.Error("DeleteSongsByTags: expected an error without tags")
+	}
+}
+
+func testWatchSongs(t *testing.T, repo repository.Repository) {
+	seed(t, repo)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	watcher, err := repo.WatchSongs(ctx, "")
+	if errors.Is(err, repository.ErrUnimplemented) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatalf("WatchSongs: %v", err)
+	}
+	defer watcher.Close(ctx)
+
+	results, err := repo.AddSongs(ctx, []*repository.File{{Name: "Watched Song", Uri: "gs://test-tensorbeat-songs/watched.mp3"}})
+	if err != nil || results[0].Err != nil {
+		t.Fatalf("AddSongs: %v %v", results, err)
+	}
+	id := results[0].ID
+	if _, err := repo.AddTags(ctx, id, map[string]string{"genre": "rock"}, nil, 0); err != nil {
+		t.Fatalf("AddTags: %v", err)
+	}
+	if _, err := repo.UpdateSong(ctx, &repository.File{ID: id, Name: "Renamed Song"}, []string{"name"}); err != nil {
+		t.Fatalf("UpdateSong: %v", err)
+	}
+	if _, err := repo.DeleteSongsByIDs(ctx, []string{id}); err != nil {
+		t.Fatalf("DeleteSongsByIDs: %v", err)
+	}
+
+	want := []repository.SongEventType{
+		repository.SongInserted,
+		repository.SongTagsChanged,
+		repository.SongUpdated,
+		repository.SongDeleted,
+	}
+	events := make([]*repository.SongEvent, len(want))
+	for i := range want {
+		events[i], err = watcher.Next(ctx)
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		if events[i].Type != want[i] || events[i].ID != id || events[i].ResumeToken == "" {
+			t.Errorf("event %v = %+v, want type %v for %v", i, events[i], want[i], id)
+		}
+	}
+	if song := events[1].Song; song == nil || song.Tags["genre"] != "rock" {
+		t.Errorf("tags changed event has song %+v, want the tagged song", song)
+	}
+	if song := events[2].Song; song == nil || song.Name != "Renamed Song" {
+		t.Errorf("updated event has song %+v, want the renamed song", song)
+	}
+
+	// Resuming replays the changes after the token
+	resumed, err := repo.WatchSongs(ctx, events[1].ResumeToken)
+	if err != nil {
+		t.Fatalf("WatchSongs: %v", err)
+	}
+	defer resumed.Close(ctx)
+	for i := 2; i < len(want); i++ {
+		event, err := resumed.Next(ctx)
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		if event.Type != want[i] || event.ID != id {
+			t.Errorf("resumed event %v = %+v, want type %v", i, event, want[i])
+		}
+	}
+
+	if _, err := repo.WatchSongs(ctx, "not a token"); err == nil {
+		t.Error("expected an error for a bad resume token")
+	}
+}
+
+func testPurgeDeletedSongs(t *testing.T, repo repository.Repository) {
+	songs := seed(t, repo)
+	ctx := context.Background()
+
+	pop := songs["Pop Song"]
+	if _, err := repo.DeleteSongsByIDs(ctx, []string{pop.ID, songs["Jazz Song"].ID}); err != nil {
+		t.Fatalf("DeleteSongsByIDs: %v", err)
+	}
+
+	// Songs deleted within the retention window are kept
+	purged, err := repo.PurgeDeletedSongs(ctx, time.Now().Add(-time.Hour))
+	if err != nil || purged != 0 {
+		t.Errorf("PurgeDeletedSongs within retention = %v, %v, want 0", purged, err)
+	}
+	if results, err := repo.AddSongs(ctx, []*repository.File{pop}); err != nil || results[0].Err == nil {
+		t.Errorf("re-adding a deleted song before the purge = %v, %v, want a duplicate error", results, err)
+	}
+
+	purged, err = repo.PurgeDeletedSongs(ctx, time.Now().Add(time.Minute))
+	if err != nil || purged != 2 {
+		t.Errorf("PurgeDeletedSongs = %v, %v, want 2", purged, err)
+	}
+
+	// Once purged the ID is free again
+	results, err := repo.AddSongs(ctx, []*repository.File{pop})
+	if err != nil || results[0].Err != nil {
+		t.Fatalf("re-adding a purged song = %v, %v", results, err)
+	}
+	all, _, _, err := repo.GetAllSongs(ctx, repository.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetAllSongs: %v", err)
+	}
+	assertNames(t, all, []string{"Rock Song", "Sad Rock Song", "Untagged Song", "Pop Song"})
+}
+
+func testTagSchemas(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	genre := &repository.TagSchema{
+		Key:           "genre",
+		AllowedValues: []string{"rock", "pop"},
+		Required:      true,
+		Description:   "Main genre of the song",
+	}
+	bpm := &repository.TagSchema{Key: "bpm", Type: proto.TagType_INT}
+	for _, schema := range []*repository.TagSchema{genre, bpm} {
+		if err := repo.PutTagSchema(ctx, schema); err != nil {
+			t.Fatalf("PutTagSchema(%v): %v", schema.Key, err)
+		}
+	}
+
+	// Replacing a schema keeps a single one per key
+	genre.AllowedValues = append(genre.AllowedValues, "jazz")
+	if err := repo.PutTagSchema(ctx, genre); err != nil {
+		t.Fatalf("PutTagSchema: %v", err)
+	}
+	if err := repo.PutTagSchema(ctx, &repository.TagSchema{Key: "Genre"}); err != repository.ErrTagSchemaConflict {
+		t.Errorf("PutTagSchema with a key in another case = %v, want ErrTagSchemaConflict", err)
+	}
+	if err := repo.PutTagSchema(ctx, &repository.TagSchema{Key: "live", Type: proto.TagType_BOOL, AllowedValues: []string{"yes"}}); err == nil {
+		t.Error("PutTagSchema with allowed values on a BOOL tag succeeded")
+	}
+
+	schemas, err := repo.GetTagSchemas(ctx)
+	if err != nil {
+		t.Fatalf("GetTagSchemas: %v", err)
+	}
+	if len(schemas) != 2 || schemas[0].Key != "bpm" || schemas[1].Key != "genre" {
+		t.Fatalf("got schemas %+v, want bpm and genre", schemas)
+	}
+	if got := schemas[1]; got.Type != proto.TagType_STRING || len(got.AllowedValues) != 3 || !got.Required || got.Description != genre.Description {
+		t.Errorf("got schema %+v, want %+v", got, genre)
+	}
+
+	if deleted, err := repo.DeleteTagSchema(ctx, "BPM"); err != nil || deleted {
+		t.Errorf("DeleteTagSchema(BPM) = %v, %v, want false", deleted, err)
+	}
+	if deleted, err := repo.DeleteTagSchema(ctx, "bpm"); err != nil || !deleted {
+		t.Errorf("DeleteTagSchema(bpm) = %v, %v, want true", deleted, err)
+	}
+	schemas, err = repo.GetTagSchemas(ctx)
+	if err != nil {
+		t.Fatalf("GetTagSchemas: %v", err)
+	}
+	if len(schemas) != 1 || schemas[0].Key != "genre" {
+		t.Errorf("got schemas %+v, want genre", schemas)
+	}
+}
+
+func getSong(t *testing.T, repo repository.Repository, id string) *repository.File {
+	t.Helper()
+
+	songs, _, _, err := repo.GetSongsByIDs(context.Background(), []string{id}, repository.ListOptions{})
+	if err != nil {
+		t.Fatalf("GetSongsByIDs: %v", err)
+	}
+	if len(songs) != 1 {
+		t.Fatalf("got %v songs for ID %v, want 1", len(songs), id)
+	}
+	return songs[0]
+}
+
+func assertNames(t *testing.T, songs []*repository.File, want []string) {
+	t.Helper()
+
+	got := make([]string, len(songs))
+	for i, song := range songs {
+		got[i] = song.Name
+	}
+	sort.Strings(got)
+	sorted := append([]string{}, want...)
+	sort.Strings(sorted)
+
+	if len(got) != len(sorted) {
+		t.Errorf("got songs %v, want %v", got, sorted)
+		return
+	}
+	for i := range got {
+		if got[i] != sorted[i] {
+			t.Errorf("got songs %v, want %v", got, sorted)
+			return
+		}
+	}
+}
+
+func equalTags(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func equalTypedTags(a, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		bv, ok := b[k]
+		if !ok {
+			return false
+		}
+		if _, sameType := bv.(float64); sameType != isFloat(v) {
+			return false
+		}
+		if c, comparable := query.Compare(v, bv); !comparable || c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func isFloat(v interface{}) bool {
+	_, ok := v.(float64)
+	return ok
+}
+
+func copyFiles(files []*repository.File) []*repository.File {
+	copies := make([]*repository.File, len(files))
+	for i, file := range files {
+		copied := *file
+		copies[i] = &copied
+	}
+	return copies
+}
@@ -0,0 +1,31 @@
+package repository
+
+import "fmt"
+
+const revisionField = "revision"
+
+// ErrRevisionMismatch is wrapped by the errors of writes expecting another
+// revision than the song has.
+var ErrRevisionMismatch = newError(ErrConflict, "song", "song revision doesn't match")
+
+// RevisionMismatchError is returned by writes expecting another revision
+// than the current revision of the song.
+type RevisionMismatchError struct {
+	ID       string
+	Expected int64
+	Current  int64
+}
+
+func (e *RevisionMismatchError) Error() string {
+	return fmt.Sprintf("song %v has revision %v, not %v", e.ID, e.Current, e.Expected)
+}
+
+func (e *RevisionMismatchError) Unwrap() error {
+	return ErrRevisionMismatch
+}
+
+// Stale reports whether the song was changed since the expected revision,
+// otherwise the expected revision never existed.
+func (e *RevisionMismatchError) Stale() bool {
+	return e.Expected < e.Current
+}
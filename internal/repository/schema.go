@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TensorBeat/Datalake/pkg/proto"
+)
+
+// TagSchema declares the type and values of a tag key. Keys are unique
+// ignoring case.
+type TagSchema struct {
+	Key           string
+	Type          proto.TagType
+	AllowedValues []string
+	Required      bool
+	Description   string
+}
+
+// ErrTagSchemaConflict is returned when putting a schema whose key only
+// differs in case from the key of an existing schema.
+var ErrTagSchemaConflict = newError(ErrConflict, "tag schema", "a tag schema with the same key in a different case already exists")
+
+// TagViolation is a tag that doesn't follow its schema. Field is the path of
+// the tag, tags.<key> for string tags and typed_tags.<key> otherwise.
+type TagViolation struct {
+	Field       string
+	Description string
+}
+
+// ValidateTagSchema checks that a schema can be stored.
+func ValidateTagSchema(schema *TagSchema) error {
+	switch {
+	case schema.Key == "":
+		return errors.New("key can't be empty")
+	case strings.HasPrefix(schema.Key, "$") || strings.Contains(schema.Key, "."):
+		return fmt.Errorf("key %q can't start with $ or contain a dot", schema.Key)
+	case len(schema.AllowedValues) > 0 && schema.Type != proto.TagType_STRING:
+		return fmt.Errorf("allowed values are only supported for %v tags", proto.TagType_STRING)
+	}
+	if _, ok := proto.TagType_name[int32(schema.Type)]; !ok {
+		return fmt.Errorf("unknown tag type %v", schema.Type)
+	}
+
+	seen := make(map[string]bool, len(schema.AllowedValues))
+	for _, val := range schema.AllowedValues {
+		if seen[val] {
+			return fmt.Errorf("allowed value %q is listed twice", val)
+		}
+		seen[val] = true
+	}
+	return nil
+}
+
+// TagSchemas validates tags against a set of schemas.
+type TagSchemas struct {
+	byKey map[string]*TagSchema
+	// byFoldedKey finds the schema of keys written in another case
+	byFoldedKey map[string]*TagSchema
+}
+
+func NewTagSchemas(schemas []*TagSchema) *TagSchemas {
+	s := &TagSchemas{
+		byKey:       make(map[string]*TagSchema, len(schemas)),
+		byFoldedKey: make(map[string]*TagSchema, len(schemas)),
+	}
+	for _, schema := range schemas {
+		s.byKey[schema.Key] = schema
+		s.byFoldedKey[strings.ToLower(schema.Key)] = schema
+	}
+	return s
+}
+
+// ValidateTags checks tags being written to a song. When complete is set the
+// tags are all the tags of the song, so missing required tags are violations
+// too. Keys without a schema are accepted unless they only differ in case
+// from a declared key.
+func (s *TagSchemas) ValidateTags(tags map[string]string, typedTags map[string]interface{}, complete bool) []*TagViolation {
+	violations := make([]*TagViolation, 0)
+
+	for key, val := range tags {
+		if violation := s.validateTag(TagsPath+"."+key, key, val); violation != nil {
+			violations = append(violations, violation)
+		}
+	}
+	for key, val := range typedTags {
+		if violation := s.validateTag("typed_tags."+key, key, val); violation != nil {
+			violations = append(violations, violation)
+		}
+	}
+
+	if complete {
+		for key, schema := range s.byKey {
+			_, ok := tags[key]
+			_, typed := typedTags[key]
+			if schema.Required && !ok && !typed {
+				violations = append(violations, &TagViolation{
+					Field:       TagsPath + "." + key,
+					Description: "tag is required",
+				})
+			}
+		}
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].Field < violations[j].Field
+	})
+	return violations
+}
+
+// ValidateRemoval checks that none of the keys are required tags.
+func (s *TagSchemas) ValidateRemoval(keys []string) []*TagViolation {
+	violations := make([]*TagViolation, 0)
+	for _, key := range keys {
+		if schema, ok := s.byKey[key]; ok && schema.Required {
+			violations = append(violations, &TagViolation{
+				Field:       TagsPath + "." + key,
+				Description: "tag is required and can't be removed",
+			})
+		}
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].Field < violations[j].Field
+	})
+	return violations
+}
+
+func (s *TagSchemas) validateTag(field string, key string, val interface{}) *TagViolation {
+	schema, ok := s.byKey[key]
+	if !ok {
+		if schema, ok := s.byFoldedKey[strings.ToLower(key)]; ok {
+			return &TagViolation{Field: field, Description: fmt.Sprintf("unknown tag, did you mean %q?", schema.Key)}
+		}
+		return nil
+	}
+
+	var valid bool
+	switch schema.Type {
+	case proto.TagType_STRING:
+		_, valid = val.(string)
+	case proto.TagType_INT:
+		_, valid = val.(int64)
+	case proto.TagType_FLOAT:
+		switch val.(type) {
+		case int64, float64:
+			valid = true
+		}
+	case proto.TagType_BOOL:
+		_, valid = val.(bool)
+	case proto.TagType_TIMESTAMP:
+		_, valid = val.(time.Time)
+	}
+	if !valid {
+		return &TagViolation{Field: field, Description: fmt.Sprintf("tag must be a %v", schema.Type)}
+	}
+
+	if len(schema.AllowedValues) > 0 {
+		for _, allowed := range schema.AllowedValues {
+			if val == allowed {
+				return nil
+			}
+		}
+		return &TagViolation{
+			Field:       field,
+			Description: fmt.Sprintf("%q isn't one of the allowed values %v", val, strings.Join(schema.AllowedValues, ", ")),
+		}
+	}
+	return nil
+}
+
+func copyTagSchema(schema *TagSchema) *TagSchema {
+	copied := *schema
+	copied.AllowedValues = append([]string(nil), schema.AllowedValues...)
+	return &copied
+}
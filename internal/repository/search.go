@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	searchIndexName = "search"
+	// nameSearchWeight makes words in the name count more than in tags
+	nameSearchWeight = 3
+)
+
+// SearchResult is a song found by SearchSongs and its relevance.
+type SearchResult struct {
+	Song  *File
+	Score float64
+}
+
+// ValidateSearchTags checks the keys of the tags the search index covers.
+func ValidateSearchTags(tagKeys []string) error {
+	for _, key := range tagKeys {
+		if err := ValidateTagKey(key); err != nil {
+			return fmt.Errorf("invalid search tag: %v", err)
+		}
+	}
+	return nil
+}
+
+// searchText is the parsed text of a search in mongo's $text syntax.
+type searchText struct {
+	terms    []string
+	phrases  []string
+	excluded []string
+}
+
+func parseSearchText(text string) *searchText {
+	search := &searchText{}
+
+	for {
+		start := strings.IndexByte(text, '"')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(text[start+1:], '"')
+		if end < 0 {
+			// Mongo treats an unterminated phrase as one too
+			end = len(text) - start - 1
+		}
+		if phrase := strings.ToLower(strings.TrimSpace(text[start+1 : start+1+end])); phrase != "" {
+			search.phrases = append(search.phrases, phrase)
+			search.terms = append(search.terms, searchWords(phrase)...)
+		}
+		text = text[:start] + " " + text[minInt(len(text), start+end+2):]
+	}
+
+	for _, field := range strings.Fields(text) {
+		if strings.HasPrefix(field, "-") {
+			search.excluded = append(search.excluded, searchWords(field[1:])...)
+			continue
+		}
+		search.terms = append(search.terms, searchWords(field)...)
+	}
+	return search
+}
+
+// searchWords splits text into lower case words.
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// score rates how well the fields of a song match the search, 0 means they
+// don't match. Like mongo a word counts more in short fields.
+func (s *searchText) score(fields []string, weights []float64) float64 {
+	for _, phrase := range s.phrases {
+		found := false
+		for _, field := range fields {
+			if strings.Contains(strings.ToLower(field), phrase) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0
+		}
+	}
+
+	var score float64
+	for i, field := range fields {
+		words := searchWords(field)
+		counts := make(map[string]int, len(words))
+		for _, word := range words {
+			counts[word]++
+		}
+
+		for _, word := range s.excluded {
+			if counts[word] > 0 {
+				return 0
+			}
+		}
+		matched := 0
+		for _, term := range s.terms {
+			matched += counts[term]
+		}
+		if matched > 0 {
+			score += weights[i] * float64(matched) / float64(len(words))
+		}
+	}
+	return score
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TensorBeat/Datalake/internal/query"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	SortByName = "name"
+	// SortByCreatedTime orders songs by their IDs, which start with the time
+	// the song was added
+	SortByCreatedTime = "created_time"
+)
+
+// SortOrder orders songs by Field, one of SortByName, SortByCreatedTime or
+// tags.<key>. Songs with the same value are ordered by ID, the zero SortOrder
+// orders by ID only.
+type SortOrder struct {
+	Field      string
+	Descending bool
+}
+
+// ListOptions selects the page of songs returned by list and query methods
+// and the fields read, see ValidateReadPaths.
+type ListOptions struct {
+	PageToken string
+	PageSize  int64
+	Sort      SortOrder
+	Fields    []string
+}
+
+func (o SortOrder) String() string {
+	if o.Descending {
+		return o.Field + " desc"
+	}
+	return o.Field
+}
+
+// ValidateSortOrder checks that songs can be sorted by order.
+func ValidateSortOrder(order SortOrder) error {
+	switch {
+	case order.Field == "" && order.Descending:
+		return fmt.Errorf("descending needs a field to sort by")
+	case order.Field == "", order.Field == SortByName, order.Field == SortByCreatedTime:
+		return nil
+	case strings.HasPrefix(order.Field, TagsPrefix):
+		// Dots would sort by a nested path in mongo
+		if err := ValidateTagKey(strings.TrimPrefix(order.Field, TagsPrefix)); err != nil {
+			return fmt.Errorf("invalid tag to sort by: %v", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("can't sort by %q, expected %v, %v or %v<key>", order.Field, SortByName, SortByCreatedTime, TagsPrefix)
+}
+
+// sortedByID reports whether the order only depends on the IDs.
+func (o SortOrder) sortedByID() bool {
+	return o.Field == "" || o.Field == SortByCreatedTime
+}
+
+// Values sort in the type order mongo uses for the types tags can have,
+// missing values come first.
+const (
+	nullRank = iota
+	numberRank
+	stringRank
+	boolRank
+	dateRank
+)
+
+var rankTypes = map[int]string{
+	numberRank: "number",
+	stringRank: "string",
+	boolRank:   "bool",
+	dateRank:   "date",
+}
+
+func sortRank(val interface{}) int {
+	switch val.(type) {
+	case int32, int64, float64:
+		return numberRank
+	case string:
+		return stringRank
+	case bool:
+		return boolRank
+	case time.Time, primitive.DateTime:
+		return dateRank
+	}
+	return nullRank
+}
+
+// normalizeSortValue converts values decoded from a page token to the types
+// of File.
+func normalizeSortValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case int32:
+		return int64(v)
+	case primitive.DateTime:
+		return v.Time().UTC()
+	}
+	return val
+}
+
+// compareSortValues orders values like mongo does.
+func compareSortValues(a, b interface{}) int {
+	a, b = normalizeSortValue(a), normalizeSortValue(b)
+	rankA, rankB := sortRank(a), sortRank(b)
+	switch {
+	case rankA < rankB:
+		return -1
+	case rankA > rankB:
+		return 1
+	case rankA == nullRank:
+		return 0
+	}
+	c, _ := query.Compare(a, b)
+	return c
+}
+
+// sortValue is the value file is sorted by, nil when it is missing. Mongo
+// doesn't store empty names so they sort as missing too.
+func sortValue(file *File, order SortOrder) interface{} {
+	switch {
+	case order.Field == SortByName:
+		if file.Name == "" {
+			return nil
+		}
+		return file.Name
+	case strings.HasPrefix(order.Field, TagsPrefix):
+		return file.AllTags()[strings.TrimPrefix(order.Field, TagsPrefix)]
+	}
+	return nil
+}
+
+// compareSorted orders two songs given their sort values.
+func compareSorted(order SortOrder, a interface{}, aID string, b interface{}, bID string) int {
+	// ObjectID hex strings sort the same way as the IDs themselves
+	if order.sortedByID() {
+		c := strings.Compare(aID, bID)
+		if order.Descending {
+			return -c
+		}
+		return c
+	}
+
+	c := compareSortValues(a, b)
+	if order.Descending {
+		c = -c
+	}
+	if c != 0 {
+		return c
+	}
+	return strings.Compare(aID, bID)
+}
+
+// mongoSort is the sort document for order.
+func mongoSort(order SortOrder) bson.D {
+	direction := 1
+	if order.Descending {
+		direction = -1
+	}
+	if order.sortedByID() {
+		return bson.D{{Key: "_id", Value: direction}}
+	}
+	return bson.D{{Key: order.Field, Value: direction}, {Key: "_id", Value: 1}}
+}
+
+// mongoAfterCursor matches the songs that come after cursor in order.
+func mongoAfterCursor(order SortOrder, cursor *pageCursor) bson.M {
+	if order.sortedByID() {
+		op := "$gt"
+		if order.Descending {
+			op = "$lt"
+		}
+		return bson.M{"_id": bson.M{op: cursor.ID}}
+	}
+
+	after := append([]bson.M{
+		{order.Field: cursor.Value, "_id": bson.M{"$gt": cursor.ID}},
+	}, mongoAfterValue(order.Field, cursor.Value, order.Descending)...)
+
+	return bson.M{"$or": after}
+}
+
+// mongoAfterValue matches the values of field that sort after val. Comparison
+// operators only match values of the same type, so the values of the types
+// sorting after val's are matched by type.
+func mongoAfterValue(field string, val interface{}, descending bool) []bson.M {
+	rank := sortRank(val)
+	after := make([]bson.M, 0)
+
+	op := "$gt"
+	if descending {
+		op = "$lt"
+	}
+	if rank != nullRank {
+		after = append(after, bson.M{field: bson.M{op: val}})
+	}
+
+	types := make([]string, 0)
+	for r := numberRank; r <= dateRank; r++ {
+		if descending && r < rank || !descending && r > rank {
+			types = append(types, rankTypes[r])
+		}
+	}
+	if len(types) > 0 {
+		after = append(after, bson.M{field: bson.M{"$type": types}})
+	}
+	if descending && rank != nullRank {
+		// Missing values come last
+		after = append(after, bson.M{field: nil})
+	}
+
+	return after
+}
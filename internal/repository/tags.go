@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AllTags returns the string and typed tags of the file in one map.
+func (f *File) AllTags() map[string]interface{} {
+	tags := make(map[string]interface{}, len(f.Tags)+len(f.TypedTags))
+	for k, v := range f.Tags {
+		tags[k] = v
+	}
+	for k, v := range f.TypedTags {
+		tags[k] = v
+	}
+	return tags
+}
+
+// ValidateTagValue checks that val is one of the types a tag can hold.
+func ValidateTagValue(val interface{}) error {
+	switch val.(type) {
+	case string, int64, float64, bool, time.Time:
+		return nil
+	}
+	return fmt.Errorf("unsupported tag value type %T", val)
+}
+
+// ValidateTagKeys checks the keys of the tags a song is written with, keys
+// rejected by ValidateTagKey would be stored differently by each backend.
+func ValidateTagKeys(tags map[string]string, typedTags map[string]interface{}) error {
+	for key := range tags {
+		if err := ValidateTagKey(key); err != nil {
+			return err
+		}
+	}
+	for key := range typedTags {
+		if err := ValidateTagKey(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateChangedTagKeys checks the keys of the tags AddTags, RemoveTags or
+// a batch update sets or removes, at least one is required.
+func ValidateChangedTagKeys(keys []string) error {
+	if len(keys) == 0 {
+		return errors.New("at least one tag is required")
+	}
+	for _, key := range keys {
+		if err := ValidateTagKey(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateSongTagKeys checks the tag keys of every song.
+func validateSongTagKeys(songs []*File) error {
+	for i, song := range songs {
+		if err := ValidateTagKeys(song.Tags, song.TypedTags); err != nil {
+			return fmt.Errorf("songs[%v]: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// storedTagValue returns val the way mongo stores it, times only keep
+// milliseconds. The memory backend stores values the same way so both
+// match, sort and page them alike.
+func storedTagValue(val interface{}) interface{} {
+	if t, ok := val.(time.Time); ok {
+		return t.Truncate(time.Millisecond)
+	}
+	return val
+}
+
+// tagKeys returns the keys of tags in no particular order.
+func tagKeys(tags map[string]interface{}) []string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// groupKey is the key a tag value is grouped by. Like mongo, numbers of
+// different types are equal when their values are and times are equal when
+// they are the same instant. Integers a float64 can't hold exactly stay
+// integers, no float is equal to them and converting would merge them with
+// their neighbours.
+func groupKey(val interface{}) interface{} {
+	switch v := val.(type) {
+	case int:
+		return groupKey(int64(v))
+	case int32:
+		return float64(v)
+	case int64:
+		// 2^63 overflows int64, so it isn't converted back
+		if f := float64(v); f < 1<<63 && int64(f) == v {
+			return f
+		}
+		return v
+	case time.Time:
+		return v.UTC()
+	}
+	return val
+}
+
+// splitTags sorts tags read from a document into string and typed tags,
+// converting the values the bson decoder produces to the types of File.
+func splitTags(all map[string]interface{}) (map[string]string, map[string]interface{}) {
+	var tags map[string]string
+	var typedTags map[string]interface{}
+	for k, v := range all {
+		switch val := v.(type) {
+		case string:
+			if tags == nil {
+				tags = make(map[string]string)
+			}
+			tags[k] = val
+			continue
+		case int32:
+			v = int64(val)
+		case primitive.DateTime:
+			v = val.Time().UTC()
+		case time.Time:
+			v = val.UTC()
+		}
+		if typedTags == nil {
+			typedTags = make(map[string]interface{})
+		}
+		typedTags[k] = v
+	}
+	return tags, typedTags
+}
+
+// mergeTags is the inverse of splitTags, it returns nil when there are no
+// tags at all.
+func mergeTags(tags map[string]string, typedTags map[string]interface{}) map[string]interface{} {
+	if len(tags)+len(typedTags) == 0 {
+		return nil
+	}
+	all := make(map[string]interface{}, len(tags)+len(typedTags))
+	for k, v := range tags {
+		all[k] = v
+	}
+	for k, v := range typedTags {
+		all[k] = v
+	}
+	return all
+}
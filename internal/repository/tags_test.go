@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGroupKey(t *testing.T) {
+	released := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		a, b  interface{}
+		equal bool
+	}{
+		{"int and float", int64(120), 120.0, true},
+		{"int32 and int64", int32(120), int64(120), true},
+		{"int and int64", 120, int64(120), true},
+		{"other float", int64(120), 120.5, false},
+		{"large ints", int64(1 << 53), int64(1<<53 + 1), false},
+		{"large int and float", int64(1 << 60), float64(1 << 60), true},
+		{"large int and nearest float", int64(1<<53 + 1), float64(1 << 53), false},
+		{"max int64", int64(math.MaxInt64), int64(math.MaxInt64 - 1), false},
+		{"max int64 and float", int64(math.MaxInt64), float64(math.MaxInt64), false},
+		{"number and string", int64(120), "120", false},
+		{"same instant", released, released.In(time.FixedZone("CET", 3600)), true},
+		{"other instant", released, released.Add(time.Millisecond), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := groupKey(tt.a) == groupKey(tt.b); got != tt.equal {
+				t.Errorf("groupKey(%v) == groupKey(%v) is %v, want %v", tt.a, tt.b, got, tt.equal)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Update paths accepted by UpdateSong, they match the field names of File in
+// the proto.
+const (
+	namePath     = "name"
+	uriPath      = "uri"
+	mimeTypePath = "mimeType"
+	TagsPath     = "tags"
+	// TagsPrefix starts the paths of single tags, tags.<key>
+	TagsPrefix = TagsPath + "."
+)
+
+var ErrSongNotFound = newError(ErrNotFound, "song", "song not found")
+
+// ValidateUpdatePaths checks that every path can be applied by UpdateSong.
+func ValidateUpdatePaths(paths []string) error {
+	if len(paths) == 0 {
+		return errors.New("at least one update path is required")
+	}
+
+	seen := make(map[string]bool, len(paths))
+	replacesTags, setsTag := false, false
+	for _, path := range paths {
+		switch {
+		case path == namePath, path == uriPath, path == mimeTypePath:
+		case path == TagsPath:
+			replacesTags = true
+		case strings.HasPrefix(path, TagsPrefix):
+			// Dots would nest the tag in mongo
+			if err := ValidateTagKey(strings.TrimPrefix(path, TagsPrefix)); err != nil {
+				return fmt.Errorf("invalid update path %q: %v", path, err)
+			}
+			setsTag = true
+		default:
+			return fmt.Errorf("unknown update path: %q", path)
+		}
+
+		if seen[path] {
+			return fmt.Errorf("duplicate update path: %q", path)
+		}
+		seen[path] = true
+	}
+
+	if replacesTags && setsTag {
+		return fmt.Errorf("%q can't be combined with paths of single tags", TagsPath)
+	}
+
+	return nil
+}
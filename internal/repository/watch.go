@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type SongEventType int
+
+const (
+	SongInserted SongEventType = iota
+	SongUpdated
+	SongTagsChanged
+	SongDeleted
+)
+
+// SongEvent describes a single change to a song. Song is the song after the
+// change and may be nil once the song is gone.
+type SongEvent struct {
+	Type        SongEventType
+	ID          string
+	Song        *File
+	ResumeToken string
+}
+
+// SongWatcher is an open feed of song changes returned by WatchSongs.
+type SongWatcher interface {
+	// Next blocks until the next change or until ctx is done.
+	Next(ctx context.Context) (*SongEvent, error)
+	Close(ctx context.Context) error
+}
+
+var (
+	ErrInvalidResumeToken = newError(ErrInvalidArgument, "", "invalid resume token")
+	ErrResumeTokenExpired = newError(ErrOutOfRange, "", "resume token is too old, changes since then are no longer available")
+	ErrWatchUnsupported   = newError(ErrUnimplemented, "", "watching songs isn't supported by this deployment")
+)
+
+// feedSize is how many events a songFeed keeps for resuming watchers.
+const feedSize = 10000
+
+// songFeed is an in process pub/sub of song events for backends without
+// a change feed of their own. Events are numbered and the last feedSize are
+// kept so a watcher can resume from any of them.
+type songFeed struct {
+	mu      sync.RWMutex
+	events  []*SongEvent
+	first   int64 // sequence number of events[0]
+	changed chan struct{}
+}
+
+type feedPosition struct {
+	Seq int64 `bson:"seq"`
+}
+
+func newSongFeed() *songFeed {
+	return &songFeed{
+		events:  make([]*SongEvent, 0),
+		changed: make(chan struct{}),
+	}
+}
+
+func (f *songFeed) publish(eventType SongEventType, song *File) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	seq := f.first + int64(len(f.events))
+	f.events = append(f.events, &SongEvent{
+		Type:        eventType,
+		ID:          song.ID,
+		Song:        copyFile(song),
+		ResumeToken: encodeFeedPosition(seq + 1),
+	})
+	if len(f.events) > feedSize {
+		dropped := len(f.events) - feedSize
+		f.events = append([]*SongEvent{}, f.events[dropped:]...)
+		f.first += int64(dropped)
+	}
+
+	// Wake up every watcher waiting for the next event
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+// watch returns a watcher starting after resumeToken, or at the next event
+// for the empty token.
+func (f *songFeed) watch(resumeToken string) (*feedWatcher, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	next := f.first + int64(len(f.events))
+	if resumeToken != "" {
+		seq, err := decodeFeedPosition(resumeToken)
+		if err != nil {
+			return nil, err
+		}
+		if seq < f.first {
+			return nil, ErrResumeTokenExpired
+		}
+		if seq > next {
+			return nil, ErrInvalidResumeToken
+		}
+		next = seq
+	}
+
+	return &feedWatcher{feed: f, next: next}, nil
+}
+
+type feedWatcher struct {
+	feed *songFeed
+	next int64
+}
+
+func (w *feedWatcher) Next(ctx context.Context) (*SongEvent, error) {
+	for {
+		w.feed.mu.RLock()
+		first, events, changed := w.feed.first, w.feed.events, w.feed.changed
+		w.feed.mu.RUnlock()
+
+		if w.next < first {
+			return nil, ErrResumeTokenExpired
+		}
+		if i := w.next - first; i < int64(len(events)) {
+			w.next++
+			event := *events[i]
+			event.Song = copyFile(event.Song)
+			return &event, nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (w *feedWatcher) Close(ctx context.Context) error {
+	return nil
+}
+
+func encodeFeedPosition(seq int64) string {
+	raw, err := bson.Marshal(feedPosition{Seq: seq})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeFeedPosition(token string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, ErrInvalidResumeToken
+	}
+	position := &feedPosition{}
+	if err := bson.Unmarshal(raw, position); err != nil || position.Seq < 0 {
+		return 0, ErrInvalidResumeToken
+	}
+	return position.Seq, nil
+}
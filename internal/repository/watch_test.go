@@ -0,0 +1,27 @@
+package repository
+
+import "testing"
+
+func TestUpdateEventType(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []string
+		want   SongEventType
+	}{
+		{"tag", []string{"tags.genre", revisionField}, SongTagsChanged},
+		{"tags replaced", []string{TagsPath, revisionField}, SongTagsChanged},
+		{"tags without revision", []string{"tags.genre"}, SongTagsChanged},
+		{"name and tags", []string{"name", "tags.genre", revisionField}, SongUpdated},
+		{"only revision", []string{revisionField}, SongUpdated},
+		{"nothing", nil, SongUpdated},
+		{"deleted", []string{deletedAtField, revisionField}, SongDeleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := updateEventType(tt.fields); got != tt.want {
+				t.Errorf("updateEventType(%v) = %v, want %v", tt.fields, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"os"
+	"strings"
+)
+
+const defaultSearchTags = "artist,album,genre"
+
+// SearchTags returns the tag keys of the SEARCH_TAGS variable, which
+// defaults to artist, album and genre.
+func SearchTags() []string {
+	value, ok := os.LookupEnv("SEARCH_TAGS")
+	if !ok {
+		value = defaultSearchTags
+	}
+
+	keys := make([]string, 0)
+	for _, key := range strings.Split(value, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -7,6 +7,9 @@
 package proto
 
 import (
+	duration "github.com/golang/protobuf/ptypes/duration"
+	code "google.golang.org/genproto/googleapis/rpc/code"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -69,6 +72,291 @@ func (Filter) EnumDescriptor() ([]byte, []int) {
 	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{0}
 }
 
+type TagMigrationState int32
+
+const (
+	// Dry runs, they aren't stored
+	TagMigrationState_PLANNED      TagMigrationState = 0
+	TagMigrationState_RUNNING      TagMigrationState = 1
+	TagMigrationState_COMPLETED    TagMigrationState = 2
+	TagMigrationState_ROLLING_BACK TagMigrationState = 3
+	TagMigrationState_ROLLED_BACK  TagMigrationState = 4
+)
+
+// Enum value maps for TagMigrationState.
+var (
+	TagMigrationState_name = map[int32]string{
+		0: "PLANNED",
+		1: "RUNNING",
+		2: "COMPLETED",
+		3: "ROLLING_BACK",
+		4: "ROLLED_BACK",
+	}
+	TagMigrationState_value = map[string]int32{
+		"PLANNED":      0,
+		"RUNNING":      1,
+		"COMPLETED":    2,
+		"ROLLING_BACK": 3,
+		"ROLLED_BACK":  4,
+	}
+)
+
+func (x TagMigrationState) Enum() *TagMigrationState {
+	p := new(TagMigrationState)
+	*p = x
+	return p
+}
+
+func (x TagMigrationState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TagMigrationState) Descriptor() protoreflect.EnumDescriptor {
+	return file_tensorbeat_datalake_proto_enumTypes[1].Descriptor()
+}
+
+func (TagMigrationState) Type() protoreflect.EnumType {
+	return &file_tensorbeat_datalake_proto_enumTypes[1]
+}
+
+func (x TagMigrationState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TagMigrationState.Descriptor instead.
+func (TagMigrationState) EnumDescriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{1}
+}
+
+type SongEventType int32
+
+const (
+	SongEventType_INSERTED     SongEventType = 0
+	SongEventType_UPDATED      SongEventType = 1
+	SongEventType_TAGS_CHANGED SongEventType = 2
+	SongEventType_DELETED      SongEventType = 3
+)
+
+// Enum value maps for SongEventType.
+var (
+	SongEventType_name = map[int32]string{
+		0: "INSERTED",
+		1: "UPDATED",
+		2: "TAGS_CHANGED",
+		3: "DELETED",
+	}
+	SongEventType_value = map[string]int32{
+		"INSERTED":     0,
+		"UPDATED":      1,
+		"TAGS_CHANGED": 2,
+		"DELETED":      3,
+	}
+)
+
+func (x SongEventType) Enum() *SongEventType {
+	p := new(SongEventType)
+	*p = x
+	return p
+}
+
+func (x SongEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SongEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_tensorbeat_datalake_proto_enumTypes[2].Descriptor()
+}
+
+func (SongEventType) Type() protoreflect.EnumType {
+	return &file_tensorbeat_datalake_proto_enumTypes[2]
+}
+
+func (x SongEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SongEventType.Descriptor instead.
+func (SongEventType) EnumDescriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{2}
+}
+
+type TagType int32
+
+const (
+	TagType_STRING TagType = 0
+	TagType_INT    TagType = 1
+	// Accepts int values too
+	TagType_FLOAT     TagType = 2
+	TagType_BOOL      TagType = 3
+	TagType_TIMESTAMP TagType = 4
+)
+
+// Enum value maps for TagType.
+var (
+	TagType_name = map[int32]string{
+		0: "STRING",
+		1: "INT",
+		2: "FLOAT",
+		3: "BOOL",
+		4: "TIMESTAMP",
+	}
+	TagType_value = map[string]int32{
+		"STRING":    0,
+		"INT":       1,
+		"FLOAT":     2,
+		"BOOL":      3,
+		"TIMESTAMP": 4,
+	}
+)
+
+func (x TagType) Enum() *TagType {
+	p := new(TagType)
+	*p = x
+	return p
+}
+
+func (x TagType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TagType) Descriptor() protoreflect.EnumDescriptor {
+	return file_tensorbeat_datalake_proto_enumTypes[3].Descriptor()
+}
+
+func (TagType) Type() protoreflect.EnumType {
+	return &file_tensorbeat_datalake_proto_enumTypes[3]
+}
+
+func (x TagType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TagType.Descriptor instead.
+func (TagType) EnumDescriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{3}
+}
+
+type MatchMode int32
+
+const (
+	MatchMode_EXACT MatchMode = 0
+	// The tag starts with value
+	MatchMode_PREFIX MatchMode = 1
+	// The tag equals value ignoring case
+	MatchMode_CASE_INSENSITIVE MatchMode = 2
+	// The tag matches the regular expression in value anywhere, anchor it with
+	// ^ to use indexes. The RE2 syntax is used, without multi-line mode, and
+	// at most 4 repetitions, none of them nested or over an alternation.
+	// $ only matches at the very end of the tag
+	MatchMode_REGEX MatchMode = 3
+	// The tag is one of values
+	MatchMode_IN MatchMode = 4
+)
+
+// Enum value maps for MatchMode.
+var (
+	MatchMode_name = map[int32]string{
+		0: "EXACT",
+		1: "PREFIX",
+		2: "CASE_INSENSITIVE",
+		3: "REGEX",
+		4: "IN",
+	}
+	MatchMode_value = map[string]int32{
+		"EXACT":            0,
+		"PREFIX":           1,
+		"CASE_INSENSITIVE": 2,
+		"REGEX":            3,
+		"IN":               4,
+	}
+)
+
+func (x MatchMode) Enum() *MatchMode {
+	p := new(MatchMode)
+	*p = x
+	return p
+}
+
+func (x MatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_tensorbeat_datalake_proto_enumTypes[4].Descriptor()
+}
+
+func (MatchMode) Type() protoreflect.EnumType {
+	return &file_tensorbeat_datalake_proto_enumTypes[4]
+}
+
+func (x MatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchMode.Descriptor instead.
+func (MatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{4}
+}
+
+// Order of the songs of a list, songs with the same value are ordered by ID.
+// Without a field songs are ordered by ID. Tags with values of different types
+// are ordered by type: missing, numbers, strings, booleans then timestamps
+type SortOrder struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name, created_time or tags.<key>
+	Field      string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Descending bool   `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
+}
+
+func (x *SortOrder) Reset() {
+	*x = SortOrder{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SortOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortOrder) ProtoMessage() {}
+
+func (x *SortOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortOrder.ProtoReflect.Descriptor instead.
+func (*SortOrder) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SortOrder) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SortOrder) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
 type GetSongsByTagsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -89,16 +377,29 @@ type GetSongsByTagsRequest struct {
 	// Using an * for the value will return any song with that tag set.
 	// Using a specific value for the tag will return only songs with that exact combination of Key/Value
 	//
-	Tags      map[string]string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Filter    Filter            `protobuf:"varint,2,opt,name=filter,proto3,enum=tensorbeat.datalake.Filter" json:"filter,omitempty"`
-	PageToken *int64            `protobuf:"varint,3,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
-	PageSize  *int64            `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	Tags   map[string]string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Filter Filter            `protobuf:"varint,2,opt,name=filter,proto3,enum=tensorbeat.datalake.Filter" json:"filter,omitempty"`
+	// Unset or 0 means 100 per page, larger pages are capped at 1000
+	PageSize *int64 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	// Opaque token from a previous response, empty for the first page
+	PageToken string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Tags matched with a match mode, combined with the tags using the filter.
+	// A key can't be in both maps
+	Matches map[string]*TagMatch `protobuf:"bytes,6,rep,name=matches,proto3" json:"matches,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Sort    *SortOrder           `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty"`
+	//
+	// Fields of the songs to return, every field when empty:
+	// - id, name, uri, mimeType
+	// - tags        all of the tags
+	// - tags.<key>  a single tag
+	// The id is always returned
+	ReadMask *field_mask.FieldMask `protobuf:"bytes,8,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
 }
 
 func (x *GetSongsByTagsRequest) Reset() {
 	*x = GetSongsByTagsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tensorbeat_datalake_proto_msgTypes[0]
+		mi := &file_tensorbeat_datalake_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -111,7 +412,7 @@ func (x *GetSongsByTagsRequest) String() string {
 func (*GetSongsByTagsRequest) ProtoMessage() {}
 
 func (x *GetSongsByTagsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tensorbeat_datalake_proto_msgTypes[0]
+	mi := &file_tensorbeat_datalake_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -124,7 +425,7 @@ func (x *GetSongsByTagsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSongsByTagsRequest.ProtoReflect.Descriptor instead.
 func (*GetSongsByTagsRequest) Descriptor() ([]byte, []int) {
-	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{0}
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *GetSongsByTagsRequest) GetTags() map[string]string {
@@ -141,13 +442,6 @@ func (x *GetSongsByTagsRequest) GetFilter() Filter {
 	return Filter_ANY
 }
 
-func (x *GetSongsByTagsRequest) GetPageToken() int64 {
-	if x != nil && x.PageToken != nil {
-		return *x.PageToken
-	}
-	return 0
-}
-
 func (x *GetSongsByTagsRequest) GetPageSize() int64 {
 	if x != nil && x.PageSize != nil {
 		return *x.PageSize
@@ -155,20 +449,113 @@ func (x *GetSongsByTagsRequest) GetPageSize() int64 {
 	return 0
 }
 
+func (x *GetSongsByTagsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetSongsByTagsRequest) GetMatches() map[string]*TagMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *GetSongsByTagsRequest) GetSort() *SortOrder {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *GetSongsByTagsRequest) GetReadMask() *field_mask.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type TagMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode MatchMode `protobuf:"varint,1,opt,name=mode,proto3,enum=tensorbeat.datalake.MatchMode" json:"mode,omitempty"`
+	// Value to match for every mode but IN
+	Value  string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Values []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *TagMatch) Reset() {
+	*x = TagMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TagMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagMatch) ProtoMessage() {}
+
+func (x *TagMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagMatch.ProtoReflect.Descriptor instead.
+func (*TagMatch) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TagMatch) GetMode() MatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return MatchMode_EXACT
+}
+
+func (x *TagMatch) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TagMatch) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
 type GetSongsByTagsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Songs         []*File `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
-	NextPageToken int64   `protobuf:"varint,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	TotalSize     int64   `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	Songs     []*File `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+	TotalSize int64   `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	// Pass as page_token to get the next page, empty when there are no more songs
+	NextPageToken string `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 }
 
 func (x *GetSongsByTagsResponse) Reset() {
 	*x = GetSongsByTagsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tensorbeat_datalake_proto_msgTypes[1]
+		mi := &file_tensorbeat_datalake_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -181,7 +568,7 @@ func (x *GetSongsByTagsResponse) String() string {
 func (*GetSongsByTagsResponse) ProtoMessage() {}
 
 func (x *GetSongsByTagsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tensorbeat_datalake_proto_msgTypes[1]
+	mi := &file_tensorbeat_datalake_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -194,7 +581,7 @@ func (x *GetSongsByTagsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSongsByTagsResponse.ProtoReflect.Descriptor instead.
 func (*GetSongsByTagsResponse) Descriptor() ([]byte, []int) {
-	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{1}
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetSongsByTagsResponse) GetSongs() []*File {
@@ -204,18 +591,18 @@ func (x *GetSongsByTagsResponse) GetSongs() []*File {
 	return nil
 }
 
-func (x *GetSongsByTagsResponse) GetNextPageToken() int64 {
+func (x *GetSongsByTagsResponse) GetTotalSize() int64 {
 	if x != nil {
-		return x.NextPageToken
+		return x.TotalSize
 	}
 	return 0
 }
 
-func (x *GetSongsByTagsResponse) GetTotalSize() int64 {
+func (x *GetSongsByTagsResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.TotalSize
+		return x.NextPageToken
 	}
-	return 0
+	return ""
 }
 
 type AddSongsRequest struct {
@@ -223,13 +610,26 @@ type AddSongsRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	//
+	// Songs are added in order, adding stops at the first failure.
+	// URIs are unique, a song whose uri another song has fails with ALREADY_EXISTS.
+	// Deleted songs keep their uri until they are purged
 	Songs []*AddFile `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+	// Merge the tags of a song whose uri another song has into that song instead
+	// of failing, its other fields are kept. ids has the ID of that song
+	Upsert bool `protobuf:"varint,2,opt,name=upsert,proto3" json:"upsert,omitempty"`
+	//
+	// Retries of a request with the same key get the response of the first one
+	// for 24 hours instead of running it again. The key can also be sent as the
+	// idempotency-key metadata, a key reused for another request fails with
+	// INVALID_ARGUMENT and one whose request still runs with ABORTED
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 }
 
 func (x *AddSongsRequest) Reset() {
 	*x = AddSongsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tensorbeat_datalake_proto_msgTypes[2]
+		mi := &file_tensorbeat_datalake_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -242,7 +642,7 @@ func (x *AddSongsRequest) String() string {
 func (*AddSongsRequest) ProtoMessage() {}
 
 func (x *AddSongsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tensorbeat_datalake_proto_msgTypes[2]
+	mi := &file_tensorbeat_datalake_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -255,7 +655,7 @@ func (x *AddSongsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddSongsRequest.ProtoReflect.Descriptor instead.
 func (*AddSongsRequest) Descriptor() ([]byte, []int) {
-	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{2}
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *AddSongsRequest) GetSongs() []*AddFile {
@@ -265,18 +665,36 @@ func (x *AddSongsRequest) GetSongs() []*AddFile {
 	return nil
 }
 
+func (x *AddSongsRequest) GetUpsert() bool {
+	if x != nil {
+		return x.Upsert
+	}
+	return false
+}
+
+func (x *AddSongsRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type AddSongsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// False when any of the songs wasn't added, see failures
 	Successful bool `protobuf:"varint,1,opt,name=successful,proto3" json:"successful,omitempty"`
+	// IDs of the added songs in the order they were sent, empty for failures
+	Ids      []string           `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`
+	Failures []*AddSongsFailure `protobuf:"bytes,3,rep,name=failures,proto3" json:"failures,omitempty"`
 }
 
 func (x *AddSongsResponse) Reset() {
 	*x = AddSongsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tensorbeat_datalake_proto_msgTypes[3]
+		mi := &file_tensorbeat_datalake_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -289,7 +707,7 @@ func (x *AddSongsResponse) String() string {
 func (*AddSongsResponse) ProtoMessage() {}
 
 func (x *AddSongsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tensorbeat_datalake_proto_msgTypes[3]
+	mi := &file_tensorbeat_datalake_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -302,7 +720,7 @@ func (x *AddSongsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddSongsResponse.ProtoReflect.Descriptor instead.
 func (*AddSongsResponse) Descriptor() ([]byte, []int) {
-	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{3}
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *AddSongsResponse) GetSuccessful() bool {
@@ -312,6 +730,85 @@ func (x *AddSongsResponse) GetSuccessful() bool {
 	return false
 }
 
+func (x *AddSongsResponse) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *AddSongsResponse) GetFailures() []*AddSongsFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type AddSongsFailure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Position of the song in AddSongsRequest.songs
+	Index int64  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// ALREADY_EXISTS when the uri is taken, ABORTED for songs after a failure
+	Code code.Code `protobuf:"varint,3,opt,name=code,proto3,enum=google.rpc.Code" json:"code,omitempty"`
+}
+
+func (x *AddSongsFailure) Reset() {
+	*x = AddSongsFailure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddSongsFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSongsFailure) ProtoMessage() {}
+
+func (x *AddSongsFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSongsFailure.ProtoReflect.Descriptor instead.
+func (*AddSongsFailure) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AddSongsFailure) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *AddSongsFailure) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AddSongsFailure) GetCode() code.Code {
+	if x != nil {
+		return x.Code
+	}
+	return code.Code_OK
+}
+
 type AddTagsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -319,12 +816,19 @@ type AddTagsRequest struct {
 
 	Id   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Tags map[string]string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Tags with a type other than string, a key can't be in both maps
+	TypedTags map[string]*TagValue `protobuf:"bytes,3,rep,name=typed_tags,json=typedTags,proto3" json:"typed_tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// See AddSongsRequest.idempotency_key
+	IdempotencyKey string `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// Fails with ABORTED when the song changed since this revision, 0 skips
+	// the check
+	ExpectedRevision int64 `protobuf:"varint,5,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
 }
 
 func (x *AddTagsRequest) Reset() {
 	*x = AddTagsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tensorbeat_datalake_proto_msgTypes[4]
+		mi := &file_tensorbeat_datalake_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -337,7 +841,7 @@ func (x *AddTagsRequest) String() string {
 func (*AddTagsRequest) ProtoMessage() {}
 
 func (x *AddTagsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tensorbeat_datalake_proto_msgTypes[4]
+	mi := &file_tensorbeat_datalake_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,7 +854,7 @@ func (x *AddTagsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddTagsRequest.ProtoReflect.Descriptor instead.
 func (*AddTagsRequest) Descriptor() ([]byte, []int) {
-	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{4}
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *AddTagsRequest) GetId() string {
@@ -367,18 +871,42 @@ func (x *AddTagsRequest) GetTags() map[string]string {
 	return nil
 }
 
+func (x *AddTagsRequest) GetTypedTags() map[string]*TagValue {
+	if x != nil {
+		return x.TypedTags
+	}
+	return nil
+}
+
+func (x *AddTagsRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *AddTagsRequest) GetExpectedRevision() int64 {
+	if x != nil {
+		return x.ExpectedRevision
+	}
+	return 0
+}
+
 type AddTagsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// Always true, failures are returned as an error status
 	Successful bool `protobuf:"varint,1,opt,name=successful,proto3" json:"successful,omitempty"`
+	// Revision of the song after the change
+	Revision int64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
 }
 
 func (x *AddTagsResponse) Reset() {
 	*x = AddTagsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tensorbeat_datalake_proto_msgTypes[5]
+		mi := &file_tensorbeat_datalake_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -391,7 +919,7 @@ func (x *AddTagsResponse) String() string {
 func (*AddTagsResponse) ProtoMessage() {}
 
 func (x *AddTagsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tensorbeat_datalake_proto_msgTypes[5]
+	mi := &file_tensorbeat_datalake_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -404,7 +932,7 @@ func (x *AddTagsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddTagsResponse.ProtoReflect.Descriptor instead.
 func (*AddTagsResponse) Descriptor() ([]byte, []int) {
-	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{5}
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *AddTagsResponse) GetSuccessful() bool {
@@ -414,6 +942,13 @@ func (x *AddTagsResponse) GetSuccessful() bool {
 	return false
 }
 
+func (x *AddTagsResponse) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
 type RemoveTagsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -421,12 +956,16 @@ type RemoveTagsRequest struct {
 
 	Id   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Tags map[string]string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// See AddSongsRequest.idempotency_key
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// See AddTagsRequest.expected_revision
+	ExpectedRevision int64 `protobuf:"varint,4,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
 }
 
 func (x *RemoveTagsRequest) Reset() {
 	*x = RemoveTagsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tensorbeat_datalake_proto_msgTypes[6]
+		mi := &file_tensorbeat_datalake_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -439,7 +978,7 @@ func (x *RemoveTagsRequest) String() string {
 func (*RemoveTagsRequest) ProtoMessage() {}
 
 func (x *RemoveTagsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tensorbeat_datalake_proto_msgTypes[6]
+	mi := &file_tensorbeat_datalake_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -452,7 +991,7 @@ func (x *RemoveTagsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveTagsRequest.ProtoReflect.Descriptor instead.
 func (*RemoveTagsRequest) Descriptor() ([]byte, []int) {
-	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{6}
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RemoveTagsRequest) GetId() string {
@@ -469,18 +1008,35 @@ func (x *RemoveTagsRequest) GetTags() map[string]string {
 	return nil
 }
 
+func (x *RemoveTagsRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *RemoveTagsRequest) GetExpectedRevision() int64 {
+	if x != nil {
+		return x.ExpectedRevision
+	}
+	return 0
+}
+
 type RemoveTagsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// Always true, failures are returned as an error status
 	Successful bool `protobuf:"varint,1,opt,name=successful,proto3" json:"successful,omitempty"`
+	// Revision of the song after the change
+	Revision int64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
 }
 
 func (x *RemoveTagsResponse) Reset() {
 	*x = RemoveTagsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tensorbeat_datalake_proto_msgTypes[7]
+		mi := &file_tensorbeat_datalake_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -493,7 +1049,7 @@ func (x *RemoveTagsResponse) String() string {
 func (*RemoveTagsResponse) ProtoMessage() {}
 
 func (x *RemoveTagsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tensorbeat_datalake_proto_msgTypes[7]
+	mi := &file_tensorbeat_datalake_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -506,7 +1062,7 @@ func (x *RemoveTagsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveTagsResponse.ProtoReflect.Descriptor instead.
 func (*RemoveTagsResponse) Descriptor() ([]byte, []int) {
-	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{7}
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *RemoveTagsResponse) GetSuccessful() bool {
@@ -516,32 +1072,41 @@ func (x *RemoveTagsResponse) GetSuccessful() bool {
 	return false
 }
 
-type GetAllSongsRequest struct {
+func (x *RemoveTagsResponse) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+// Selects songs by ID or with a query, exactly one of them must be set
+type SongSelector struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PageToken *int64 `protobuf:"varint,1,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
-	PageSize  *int64 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// Same syntax as QuerySongsRequest.query
+	Query string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
 }
 
-func (x *GetAllSongsRequest) Reset() {
-	*x = GetAllSongsRequest{}
+func (x *SongSelector) Reset() {
+	*x = SongSelector{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tensorbeat_datalake_proto_msgTypes[8]
+		mi := &file_tensorbeat_datalake_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetAllSongsRequest) String() string {
+func (x *SongSelector) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllSongsRequest) ProtoMessage() {}
+func (*SongSelector) ProtoMessage() {}
 
-func (x *GetAllSongsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tensorbeat_datalake_proto_msgTypes[8]
+func (x *SongSelector) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -552,52 +1117,54 @@ func (x *GetAllSongsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllSongsRequest.ProtoReflect.Descriptor instead.
-func (*GetAllSongsRequest) Descriptor() ([]byte, []int) {
-	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use SongSelector.ProtoReflect.Descriptor instead.
+func (*SongSelector) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetAllSongsRequest) GetPageToken() int64 {
-	if x != nil && x.PageToken != nil {
-		return *x.PageToken
+func (x *SongSelector) GetIds() []string {
+	if x != nil {
+		return x.Ids
 	}
-	return 0
+	return nil
 }
 
-func (x *GetAllSongsRequest) GetPageSize() int64 {
-	if x != nil && x.PageSize != nil {
-		return *x.PageSize
+func (x *SongSelector) GetQuery() string {
+	if x != nil {
+		return x.Query
 	}
-	return 0
+	return ""
 }
 
-type GetAllSongsResponse struct {
+type BatchAddTagsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Songs         []*File `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
-	NextPageToken int64   `protobuf:"varint,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	TotalSize     int64   `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	Songs     *SongSelector        `protobuf:"bytes,1,opt,name=songs,proto3" json:"songs,omitempty"`
+	Tags      map[string]string    `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	TypedTags map[string]*TagValue `protobuf:"bytes,3,rep,name=typed_tags,json=typedTags,proto3" json:"typed_tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Only counts the selected songs without changing them
+	DryRun bool `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 }
 
-func (x *GetAllSongsResponse) Reset() {
-	*x = GetAllSongsResponse{}
+func (x *BatchAddTagsRequest) Reset() {
+	*x = BatchAddTagsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tensorbeat_datalake_proto_msgTypes[9]
+		mi := &file_tensorbeat_datalake_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetAllSongsResponse) String() string {
+func (x *BatchAddTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllSongsResponse) ProtoMessage() {}
+func (*BatchAddTagsRequest) ProtoMessage() {}
 
-func (x *GetAllSongsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tensorbeat_datalake_proto_msgTypes[9]
+func (x *BatchAddTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -608,59 +1175,67 @@ func (x *GetAllSongsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllSongsResponse.ProtoReflect.Descriptor instead.
-func (*GetAllSongsResponse) Descriptor() ([]byte, []int) {
-	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use BatchAddTagsRequest.ProtoReflect.Descriptor instead.
+func (*BatchAddTagsRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GetAllSongsResponse) GetSongs() []*File {
+func (x *BatchAddTagsRequest) GetSongs() *SongSelector {
 	if x != nil {
 		return x.Songs
 	}
 	return nil
 }
 
-func (x *GetAllSongsResponse) GetNextPageToken() int64 {
+func (x *BatchAddTagsRequest) GetTags() map[string]string {
 	if x != nil {
-		return x.NextPageToken
+		return x.Tags
 	}
-	return 0
+	return nil
 }
 
-func (x *GetAllSongsResponse) GetTotalSize() int64 {
+func (x *BatchAddTagsRequest) GetTypedTags() map[string]*TagValue {
 	if x != nil {
-		return x.TotalSize
+		return x.TypedTags
 	}
-	return 0
+	return nil
 }
 
-type GetSongsByIDsRequest struct {
+func (x *BatchAddTagsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type BatchRemoveTagsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ids       []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
-	PageToken *int64   `protobuf:"varint,2,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
-	PageSize  *int64   `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	Songs *SongSelector `protobuf:"bytes,1,opt,name=songs,proto3" json:"songs,omitempty"`
+	Keys  []string      `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	// Only counts the selected songs without changing them
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 }
 
-func (x *GetSongsByIDsRequest) Reset() {
-	*x = GetSongsByIDsRequest{}
+func (x *BatchRemoveTagsRequest) Reset() {
+	*x = BatchRemoveTagsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tensorbeat_datalake_proto_msgTypes[10]
+		mi := &file_tensorbeat_datalake_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetSongsByIDsRequest) String() string {
+func (x *BatchRemoveTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSongsByIDsRequest) ProtoMessage() {}
+func (*BatchRemoveTagsRequest) ProtoMessage() {}
 
-func (x *GetSongsByIDsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tensorbeat_datalake_proto_msgTypes[10]
+func (x *BatchRemoveTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -671,59 +1246,60 @@ func (x *GetSongsByIDsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSongsByIDsRequest.ProtoReflect.Descriptor instead.
-func (*GetSongsByIDsRequest) Descriptor() ([]byte, []int) {
-	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use BatchRemoveTagsRequest.ProtoReflect.Descriptor instead.
+func (*BatchRemoveTagsRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GetSongsByIDsRequest) GetIds() []string {
+func (x *BatchRemoveTagsRequest) GetSongs() *SongSelector {
 	if x != nil {
-		return x.Ids
+		return x.Songs
 	}
 	return nil
 }
 
-func (x *GetSongsByIDsRequest) GetPageToken() int64 {
-	if x != nil && x.PageToken != nil {
-		return *x.PageToken
+func (x *BatchRemoveTagsRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
 	}
-	return 0
+	return nil
 }
 
-func (x *GetSongsByIDsRequest) GetPageSize() int64 {
-	if x != nil && x.PageSize != nil {
-		return *x.PageSize
+func (x *BatchRemoveTagsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
 	}
-	return 0
+	return false
 }
 
-type GetSongsByIDsResponse struct {
+type BatchAddTagsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Songs         []*File `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
-	NextPageToken int64   `protobuf:"varint,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	TotalSize     int64   `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	MatchedCount int64 `protobuf:"varint,1,opt,name=matched_count,json=matchedCount,proto3" json:"matched_count,omitempty"`
+	// Songs that already had the tags are matched but not modified, always 0
+	// for a dry run
+	ModifiedCount int64 `protobuf:"varint,2,opt,name=modified_count,json=modifiedCount,proto3" json:"modified_count,omitempty"`
 }
 
-func (x *GetSongsByIDsResponse) Reset() {
-	*x = GetSongsByIDsResponse{}
+func (x *BatchAddTagsResponse) Reset() {
+	*x = BatchAddTagsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tensorbeat_datalake_proto_msgTypes[11]
+		mi := &file_tensorbeat_datalake_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetSongsByIDsResponse) String() string {
+func (x *BatchAddTagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSongsByIDsResponse) ProtoMessage() {}
+func (*BatchAddTagsResponse) ProtoMessage() {}
 
-func (x *GetSongsByIDsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tensorbeat_datalake_proto_msgTypes[11]
+func (x *BatchAddTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -734,240 +1310,3623 @@ func (x *GetSongsByIDsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSongsByIDsResponse.ProtoReflect.Descriptor instead.
-func (*GetSongsByIDsResponse) Descriptor() ([]byte, []int) {
-	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{11}
-}
-
-func (x *GetSongsByIDsResponse) GetSongs() []*File {
-	if x != nil {
-		return x.Songs
-	}
-	return nil
+// Deprecated: Use BatchAddTagsResponse.ProtoReflect.Descriptor instead.
+func (*BatchAddTagsResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetSongsByIDsResponse) GetNextPageToken() int64 {
+func (x *BatchAddTagsResponse) GetMatchedCount() int64 {
 	if x != nil {
-		return x.NextPageToken
+		return x.MatchedCount
 	}
 	return 0
 }
 
-func (x *GetSongsByIDsResponse) GetTotalSize() int64 {
+func (x *BatchAddTagsResponse) GetModifiedCount() int64 {
 	if x != nil {
-		return x.TotalSize
+		return x.ModifiedCount
 	}
 	return 0
 }
 
-var File_tensorbeat_datalake_proto protoreflect.FileDescriptor
+type BatchRemoveTagsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_tensorbeat_datalake_proto_rawDesc = []byte{
-	0x0a, 0x19, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2f, 0x64, 0x61, 0x74,
-	0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x13, 0x74, 0x65, 0x6e,
-	0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65,
-	0x1a, 0x17, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2f, 0x63, 0x6f, 0x6d,
-	0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb2, 0x02, 0x0a, 0x15, 0x47, 0x65,
-	0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42, 0x79, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x48, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x34, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64,
-	0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73,
-	0x42, 0x79, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x61,
-	0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x33, 0x0a,
-	0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e,
-	0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c,
-	0x61, 0x6b, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x12, 0x22, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73,
-	0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x08, 0x70, 0x61, 0x67,
-	0x65, 0x53, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x8e,
-	0x01, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42, 0x79, 0x54, 0x61, 0x67,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x6f, 0x6e,
-	0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f,
-	0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c,
-	0x65, 0x52, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74,
-	0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x22,
-	0x43, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x63,
-	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x41, 0x64, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x73,
-	0x6f, 0x6e, 0x67, 0x73, 0x22, 0x32, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x63, 0x63,
-	0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x75,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x22, 0x9c, 0x01, 0x0a, 0x0e, 0x41, 0x64, 0x64,
-	0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x41, 0x0a, 0x04, 0x74,
-	0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x74, 0x65, 0x6e, 0x73,
-	0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e,
-	0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54,
-	0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x1a, 0x37,
-	0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x31, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x54, 0x61,
-	0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
-	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x22, 0xa2, 0x01, 0x0a, 0x11, 0x52,
-	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x44, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30,
-	0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61,
-	0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
-	0x34, 0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x66, 0x75, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x75, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x66, 0x75, 0x6c, 0x22, 0x77, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53,
-	0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0a, 0x70,
-	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x48,
-	0x00, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x12,
-	0x20, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x48, 0x01, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x88, 0x01,
-	0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x8b,
-	0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65,
-	0x61, 0x74, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05,
-	0x73, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61,
-	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d,
-	0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a,
-	0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x8b, 0x01, 0x0a,
-	0x14, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f,
-	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x09, 0x70,
-	0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x70,
-	0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01,
-	0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a,
-	0x0b, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x0c, 0x0a, 0x0a,
-	0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x8d, 0x01, 0x0a, 0x15, 0x47,
-	0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74,
-	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x73, 0x6f,
-	0x6e, 0x67, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65,
-	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6e, 0x65,
-	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x74,
-	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x2a, 0x24, 0x0a, 0x06, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4e, 0x59, 0x10, 0x00, 0x12, 0x07, 0x0a,
-	0x03, 0x41, 0x4c, 0x4c, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x02,
-	0x32, 0xd4, 0x04, 0x0a, 0x0f, 0x44, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x60, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x6f,
-	0x6e, 0x67, 0x73, 0x12, 0x27, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74,
-	0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c,
-	0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x74,
-	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61,
-	0x6b, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x66, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e,
-	0x67, 0x73, 0x42, 0x79, 0x49, 0x44, 0x73, 0x12, 0x29, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72,
-	0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x47, 0x65,
-	0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67,
-	0x73, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69,
-	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42, 0x79, 0x54, 0x61, 0x67, 0x73,
-	0x12, 0x2a, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61,
-	0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42,
-	0x79, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x74,
-	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61,
-	0x6b, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42, 0x79, 0x54, 0x61, 0x67,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x08, 0x41, 0x64, 0x64,
-	0x53, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x24, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65,
-	0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x53,
-	0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x74, 0x65,
-	0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b,
-	0x65, 0x2e, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x54, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x12, 0x23, 0x2e,
-	0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c,
-	0x61, 0x6b, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x24, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x0a, 0x52, 0x65, 0x6d, 0x6f,
-	0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x12, 0x26, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62,
-	0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x52, 0x65, 0x6d,
-	0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27,
-	0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61,
-	0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0f, 0x5a, 0x0d, 0x2e, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	MatchedCount int64 `protobuf:"varint,1,opt,name=matched_count,json=matchedCount,proto3" json:"matched_count,omitempty"`
+	// Songs without the tags are matched but not modified, always 0 for a
+	// dry run
+	ModifiedCount int64 `protobuf:"varint,2,opt,name=modified_count,json=modifiedCount,proto3" json:"modified_count,omitempty"`
 }
 
-var (
-	file_tensorbeat_datalake_proto_rawDescOnce sync.Once
-	file_tensorbeat_datalake_proto_rawDescData = file_tensorbeat_datalake_proto_rawDesc
-)
-
-func file_tensorbeat_datalake_proto_rawDescGZIP() []byte {
-	file_tensorbeat_datalake_proto_rawDescOnce.Do(func() {
-		file_tensorbeat_datalake_proto_rawDescData = protoimpl.X.CompressGZIP(file_tensorbeat_datalake_proto_rawDescData)
-	})
-	return file_tensorbeat_datalake_proto_rawDescData
+func (x *BatchRemoveTagsResponse) Reset() {
+	*x = BatchRemoveTagsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-var file_tensorbeat_datalake_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_tensorbeat_datalake_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
-var file_tensorbeat_datalake_proto_goTypes = []interface{}{
-	(Filter)(0),                    // 0: tensorbeat.datalake.Filter
-	(*GetSongsByTagsRequest)(nil),  // 1: tensorbeat.datalake.GetSongsByTagsRequest
-	(*GetSongsByTagsResponse)(nil), // 2: tensorbeat.datalake.GetSongsByTagsResponse
-	(*AddSongsRequest)(nil),        // 3: tensorbeat.datalake.AddSongsRequest
-	(*AddSongsResponse)(nil),       // 4: tensorbeat.datalake.AddSongsResponse
-	(*AddTagsRequest)(nil),         // 5: tensorbeat.datalake.AddTagsRequest
-	(*AddTagsResponse)(nil),        // 6: tensorbeat.datalake.AddTagsResponse
-	(*RemoveTagsRequest)(nil),      // 7: tensorbeat.datalake.RemoveTagsRequest
-	(*RemoveTagsResponse)(nil),     // 8: tensorbeat.datalake.RemoveTagsResponse
-	(*GetAllSongsRequest)(nil),     // 9: tensorbeat.datalake.GetAllSongsRequest
-	(*GetAllSongsResponse)(nil),    // 10: tensorbeat.datalake.GetAllSongsResponse
-	(*GetSongsByIDsRequest)(nil),   // 11: tensorbeat.datalake.GetSongsByIDsRequest
-	(*GetSongsByIDsResponse)(nil),  // 12: tensorbeat.datalake.GetSongsByIDsResponse
-	nil,                            // 13: tensorbeat.datalake.GetSongsByTagsRequest.TagsEntry
-	nil,                            // 14: tensorbeat.datalake.AddTagsRequest.TagsEntry
-	nil,                            // 15: tensorbeat.datalake.RemoveTagsRequest.TagsEntry
-	(*File)(nil),                   // 16: tensorbeat.common.File
-	(*AddFile)(nil),                // 17: tensorbeat.common.AddFile
+func (x *BatchRemoveTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-var file_tensorbeat_datalake_proto_depIdxs = []int32{
-	13, // 0: tensorbeat.datalake.GetSongsByTagsRequest.tags:type_name -> tensorbeat.datalake.GetSongsByTagsRequest.TagsEntry
-	0,  // 1: tensorbeat.datalake.GetSongsByTagsRequest.filter:type_name -> tensorbeat.datalake.Filter
-	16, // 2: tensorbeat.datalake.GetSongsByTagsResponse.songs:type_name -> tensorbeat.common.File
-	17, // 3: tensorbeat.datalake.AddSongsRequest.songs:type_name -> tensorbeat.common.AddFile
-	14, // 4: tensorbeat.datalake.AddTagsRequest.tags:type_name -> tensorbeat.datalake.AddTagsRequest.TagsEntry
-	15, // 5: tensorbeat.datalake.RemoveTagsRequest.tags:type_name -> tensorbeat.datalake.RemoveTagsRequest.TagsEntry
-	16, // 6: tensorbeat.datalake.GetAllSongsResponse.songs:type_name -> tensorbeat.common.File
-	16, // 7: tensorbeat.datalake.GetSongsByIDsResponse.songs:type_name -> tensorbeat.common.File
-	9,  // 8: tensorbeat.datalake.DatalakeService.GetAllSongs:input_type -> tensorbeat.datalake.GetAllSongsRequest
-	11, // 9: tensorbeat.datalake.DatalakeService.GetSongsByIDs:input_type -> tensorbeat.datalake.GetSongsByIDsRequest
-	1,  // 10: tensorbeat.datalake.DatalakeService.GetSongsByTags:input_type -> tensorbeat.datalake.GetSongsByTagsRequest
-	3,  // 11: tensorbeat.datalake.DatalakeService.AddSongs:input_type -> tensorbeat.datalake.AddSongsRequest
-	5,  // 12: tensorbeat.datalake.DatalakeService.AddTags:input_type -> tensorbeat.datalake.AddTagsRequest
-	7,  // 13: tensorbeat.datalake.DatalakeService.RemoveTags:input_type -> tensorbeat.datalake.RemoveTagsRequest
-	10, // 14: tensorbeat.datalake.DatalakeService.GetAllSongs:output_type -> tensorbeat.datalake.GetAllSongsResponse
-	12, // 15: tensorbeat.datalake.DatalakeService.GetSongsByIDs:output_type -> tensorbeat.datalake.GetSongsByIDsResponse
-	2,  // 16: tensorbeat.datalake.DatalakeService.GetSongsByTags:output_type -> tensorbeat.datalake.GetSongsByTagsResponse
-	4,  // 17: tensorbeat.datalake.DatalakeService.AddSongs:output_type -> tensorbeat.datalake.AddSongsResponse
-	6,  // 18: tensorbeat.datalake.DatalakeService.AddTags:output_type -> tensorbeat.datalake.AddTagsResponse
-	8,  // 19: tensorbeat.datalake.DatalakeService.RemoveTags:output_type -> tensorbeat.datalake.RemoveTagsResponse
-	14, // [14:20] is the sub-list for method output_type
-	8,  // [8:14] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+
+func (*BatchRemoveTagsResponse) ProtoMessage() {}
+
+func (x *BatchRemoveTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRemoveTagsResponse.ProtoReflect.Descriptor instead.
+func (*BatchRemoveTagsResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BatchRemoveTagsResponse) GetMatchedCount() int64 {
+	if x != nil {
+		return x.MatchedCount
+	}
+	return 0
+}
+
+func (x *BatchRemoveTagsResponse) GetModifiedCount() int64 {
+	if x != nil {
+		return x.ModifiedCount
+	}
+	return 0
+}
+
+type GetAllSongsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unset or 0 means 100 per page, larger pages are capped at 1000
+	PageSize *int64 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	// Opaque token from a previous response, empty for the first page
+	PageToken string     `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Sort      *SortOrder `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Fields of the songs to return, see GetSongsByTagsRequest.read_mask
+	ReadMask *field_mask.FieldMask `protobuf:"bytes,5,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+}
+
+func (x *GetAllSongsRequest) Reset() {
+	*x = GetAllSongsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAllSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllSongsRequest) ProtoMessage() {}
+
+func (x *GetAllSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllSongsRequest.ProtoReflect.Descriptor instead.
+func (*GetAllSongsRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetAllSongsRequest) GetPageSize() int64 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *GetAllSongsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetAllSongsRequest) GetSort() *SortOrder {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *GetAllSongsRequest) GetReadMask() *field_mask.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type GetAllSongsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Songs     []*File `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+	TotalSize int64   `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	// Pass as page_token to get the next page, empty when there are no more songs
+	NextPageToken string `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *GetAllSongsResponse) Reset() {
+	*x = GetAllSongsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAllSongsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllSongsResponse) ProtoMessage() {}
+
+func (x *GetAllSongsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllSongsResponse.ProtoReflect.Descriptor instead.
+func (*GetAllSongsResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetAllSongsResponse) GetSongs() []*File {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+func (x *GetAllSongsResponse) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *GetAllSongsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetSongsByIDsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// Unset or 0 means 100 per page, larger pages are capped at 1000
+	PageSize *int64 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	// Opaque token from a previous response, empty for the first page
+	PageToken string     `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Sort      *SortOrder `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Fields of the songs to return, see GetSongsByTagsRequest.read_mask
+	ReadMask *field_mask.FieldMask `protobuf:"bytes,6,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+}
+
+func (x *GetSongsByIDsRequest) Reset() {
+	*x = GetSongsByIDsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSongsByIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSongsByIDsRequest) ProtoMessage() {}
+
+func (x *GetSongsByIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSongsByIDsRequest.ProtoReflect.Descriptor instead.
+func (*GetSongsByIDsRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetSongsByIDsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *GetSongsByIDsRequest) GetPageSize() int64 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *GetSongsByIDsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetSongsByIDsRequest) GetSort() *SortOrder {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *GetSongsByIDsRequest) GetReadMask() *field_mask.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type GetSongsByIDsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Songs     []*File `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+	TotalSize int64   `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	// Pass as page_token to get the next page, empty when there are no more songs
+	NextPageToken string `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *GetSongsByIDsResponse) Reset() {
+	*x = GetSongsByIDsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSongsByIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSongsByIDsResponse) ProtoMessage() {}
+
+func (x *GetSongsByIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSongsByIDsResponse.ProtoReflect.Descriptor instead.
+func (*GetSongsByIDsResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetSongsByIDsResponse) GetSongs() []*File {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+func (x *GetSongsByIDsResponse) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *GetSongsByIDsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type StreamSongsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Same semantics as GetSongsByTagsRequest, no tags streams every song
+	Tags   map[string]string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Filter Filter            `protobuf:"varint,2,opt,name=filter,proto3,enum=tensorbeat.datalake.Filter" json:"filter,omitempty"`
+	// resume_token of the last song received to continue a broken stream
+	ResumeToken string `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+}
+
+func (x *StreamSongsRequest) Reset() {
+	*x = StreamSongsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamSongsRequest) ProtoMessage() {}
+
+func (x *StreamSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamSongsRequest.ProtoReflect.Descriptor instead.
+func (*StreamSongsRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StreamSongsRequest) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *StreamSongsRequest) GetFilter() Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return Filter_ANY
+}
+
+func (x *StreamSongsRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type StreamSongsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Song        *File  `protobuf:"bytes,1,opt,name=song,proto3" json:"song,omitempty"`
+	ResumeToken string `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+}
+
+func (x *StreamSongsResponse) Reset() {
+	*x = StreamSongsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamSongsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamSongsResponse) ProtoMessage() {}
+
+func (x *StreamSongsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamSongsResponse.ProtoReflect.Descriptor instead.
+func (*StreamSongsResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StreamSongsResponse) GetSong() *File {
+	if x != nil {
+		return x.Song
+	}
+	return nil
+}
+
+func (x *StreamSongsResponse) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type IngestSongsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Songs []*AddFile `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+}
+
+func (x *IngestSongsRequest) Reset() {
+	*x = IngestSongsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestSongsRequest) ProtoMessage() {}
+
+func (x *IngestSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestSongsRequest.ProtoReflect.Descriptor instead.
+func (*IngestSongsRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *IngestSongsRequest) GetSongs() []*AddFile {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+type IngestSongsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// One result per song in the order they were sent
+	Results       []*IngestResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	InsertedCount int64           `protobuf:"varint,2,opt,name=inserted_count,json=insertedCount,proto3" json:"inserted_count,omitempty"`
+	FailedCount   int64           `protobuf:"varint,3,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+}
+
+func (x *IngestSongsResponse) Reset() {
+	*x = IngestSongsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestSongsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestSongsResponse) ProtoMessage() {}
+
+func (x *IngestSongsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestSongsResponse.ProtoReflect.Descriptor instead.
+func (*IngestSongsResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *IngestSongsResponse) GetResults() []*IngestResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *IngestSongsResponse) GetInsertedCount() int64 {
+	if x != nil {
+		return x.InsertedCount
+	}
+	return 0
+}
+
+func (x *IngestSongsResponse) GetFailedCount() int64 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+type IngestResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Position of the song across every request of the stream
+	Index int64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// Set when the song was added
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Why the song wasn't added, retry only the songs with an error
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// OK when the song was added, ALREADY_EXISTS when the uri is taken
+	Code code.Code `protobuf:"varint,4,opt,name=code,proto3,enum=google.rpc.Code" json:"code,omitempty"`
+}
+
+func (x *IngestResult) Reset() {
+	*x = IngestResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestResult) ProtoMessage() {}
+
+func (x *IngestResult) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestResult.ProtoReflect.Descriptor instead.
+func (*IngestResult) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *IngestResult) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *IngestResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *IngestResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *IngestResult) GetCode() code.Code {
+	if x != nil {
+		return x.Code
+	}
+	return code.Code_OK
+}
+
+type DeleteSongsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Either the IDs of the songs to delete
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// or tags matching the songs to delete, same semantics as GetSongsByTagsRequest
+	Tags   map[string]string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Filter Filter            `protobuf:"varint,3,opt,name=filter,proto3,enum=tensorbeat.datalake.Filter" json:"filter,omitempty"`
+}
+
+func (x *DeleteSongsRequest) Reset() {
+	*x = DeleteSongsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSongsRequest) ProtoMessage() {}
+
+func (x *DeleteSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSongsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSongsRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteSongsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *DeleteSongsRequest) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *DeleteSongsRequest) GetFilter() Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return Filter_ANY
+}
+
+type DeleteSongsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeletedCount int64 `protobuf:"varint,1,opt,name=deleted_count,json=deletedCount,proto3" json:"deleted_count,omitempty"`
+}
+
+func (x *DeleteSongsResponse) Reset() {
+	*x = DeleteSongsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSongsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSongsResponse) ProtoMessage() {}
+
+func (x *DeleteSongsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSongsResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSongsResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteSongsResponse) GetDeletedCount() int64 {
+	if x != nil {
+		return x.DeletedCount
+	}
+	return 0
+}
+
+type PurgeDeletedSongsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// How long deleted songs are kept before being purged, defaults to 30 days
+	Retention *duration.Duration `protobuf:"bytes,1,opt,name=retention,proto3" json:"retention,omitempty"`
+}
+
+func (x *PurgeDeletedSongsRequest) Reset() {
+	*x = PurgeDeletedSongsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PurgeDeletedSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedSongsRequest) ProtoMessage() {}
+
+func (x *PurgeDeletedSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedSongsRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedSongsRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PurgeDeletedSongsRequest) GetRetention() *duration.Duration {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+type PurgeDeletedSongsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PurgedCount int64 `protobuf:"varint,1,opt,name=purged_count,json=purgedCount,proto3" json:"purged_count,omitempty"`
+}
+
+func (x *PurgeDeletedSongsResponse) Reset() {
+	*x = PurgeDeletedSongsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PurgeDeletedSongsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedSongsResponse) ProtoMessage() {}
+
+func (x *PurgeDeletedSongsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedSongsResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedSongsResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PurgeDeletedSongsResponse) GetPurgedCount() int64 {
+	if x != nil {
+		return x.PurgedCount
+	}
+	return 0
+}
+
+type UpdateSongsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Songs to update identified by id, only the fields in update_mask are
+	// read. A revision other than 0 is checked like
+	// AddTagsRequest.expected_revision. Every song is checked before any is
+	// updated, but songs are updated one at a time, so when one is deleted or
+	// changed while the request runs the songs before it stay updated.
+	Songs []*File `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+	//
+	// Fields to update on every song:
+	// - name, uri, mimeType
+	// - tags        replaces all of the tags
+	// - tags.<key>  sets a single tag, or removes it when the key is missing from tags
+	UpdateMask *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+}
+
+func (x *UpdateSongsRequest) Reset() {
+	*x = UpdateSongsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSongsRequest) ProtoMessage() {}
+
+func (x *UpdateSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSongsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSongsRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpdateSongsRequest) GetSongs() []*File {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+func (x *UpdateSongsRequest) GetUpdateMask() *field_mask.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateSongsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The updated songs in the order they were sent
+	Songs []*File `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+}
+
+func (x *UpdateSongsResponse) Reset() {
+	*x = UpdateSongsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSongsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSongsResponse) ProtoMessage() {}
+
+func (x *UpdateSongsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSongsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSongsResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpdateSongsResponse) GetSongs() []*File {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+type WatchSongsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// resume_token of the last event received to continue without missing
+	// changes, empty to only get changes from now on
+	ResumeToken string `protobuf:"bytes,1,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+}
+
+func (x *WatchSongsRequest) Reset() {
+	*x = WatchSongsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSongsRequest) ProtoMessage() {}
+
+func (x *WatchSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSongsRequest.ProtoReflect.Descriptor instead.
+func (*WatchSongsRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *WatchSongsRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type SongEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type SongEventType `protobuf:"varint,1,opt,name=type,proto3,enum=tensorbeat.datalake.SongEventType" json:"type,omitempty"`
+	Id   string        `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// The song after the change, not set once the song is gone
+	Song        *File  `protobuf:"bytes,3,opt,name=song,proto3" json:"song,omitempty"`
+	ResumeToken string `protobuf:"bytes,4,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+}
+
+func (x *SongEvent) Reset() {
+	*x = SongEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SongEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SongEvent) ProtoMessage() {}
+
+func (x *SongEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SongEvent.ProtoReflect.Descriptor instead.
+func (*SongEvent) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SongEvent) GetType() SongEventType {
+	if x != nil {
+		return x.Type
+	}
+	return SongEventType_INSERTED
+}
+
+func (x *SongEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SongEvent) GetSong() *File {
+	if x != nil {
+		return x.Song
+	}
+	return nil
+}
+
+func (x *SongEvent) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type QuerySongsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	// Tag predicates combined with AND, OR, NOT and parentheses
+	// EX:
+	// genre=rock AND (mood=happy OR mood=energetic) AND NOT explicit=*
+	//
+	// - key=value   the tag is set to value
+	// - key!=value  the tag isn't set to value or isn't set at all
+	// - key=*       the tag is set to any value
+	// - key<value, key<=value, key>value, key>=value
+	// the tag compares to value, numbers, strings, booleans and
+	// timestamps only compare with values of the same kind
+	// - key BETWEEN low AND high
+	// low <= tag <= high
+	//
+	// Unquoted integers, floats, true/false and RFC 3339 timestamps are typed and
+	// compared with typed tags, key=120 also matches the string tag "120".
+	// Values with spaces or special characters must be double quoted, quoted
+	// values are always strings and "*" matches a literal *
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Unset or 0 means 100 per page, larger pages are capped at 1000
+	PageSize *int64 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	// Opaque token from a previous response, empty for the first page
+	PageToken string     `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Sort      *SortOrder `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+}
+
+func (x *QuerySongsRequest) Reset() {
+	*x = QuerySongsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuerySongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuerySongsRequest) ProtoMessage() {}
+
+func (x *QuerySongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuerySongsRequest.ProtoReflect.Descriptor instead.
+func (*QuerySongsRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *QuerySongsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *QuerySongsRequest) GetPageSize() int64 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *QuerySongsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *QuerySongsRequest) GetSort() *SortOrder {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+type QuerySongsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Songs     []*File `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+	TotalSize int64   `protobuf:"varint,2,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	// Pass as page_token to get the next page, empty when there are no more songs
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *QuerySongsResponse) Reset() {
+	*x = QuerySongsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuerySongsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuerySongsResponse) ProtoMessage() {}
+
+func (x *QuerySongsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuerySongsResponse.ProtoReflect.Descriptor instead.
+func (*QuerySongsResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *QuerySongsResponse) GetSongs() []*File {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+func (x *QuerySongsResponse) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *QuerySongsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type TagSchema struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Keys are unique ignoring case, writing a key that only differs in case
+	// from a declared one is rejected
+	Key  string  `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Type TagType `protobuf:"varint,2,opt,name=type,proto3,enum=tensorbeat.datalake.TagType" json:"type,omitempty"`
+	// Values a STRING tag may have, any value when empty
+	AllowedValues []string `protobuf:"bytes,3,rep,name=allowed_values,json=allowedValues,proto3" json:"allowed_values,omitempty"`
+	// Songs must be added with the tag and it can't be removed
+	Required    bool   `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (x *TagSchema) Reset() {
+	*x = TagSchema{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TagSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagSchema) ProtoMessage() {}
+
+func (x *TagSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagSchema.ProtoReflect.Descriptor instead.
+func (*TagSchema) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TagSchema) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TagSchema) GetType() TagType {
+	if x != nil {
+		return x.Type
+	}
+	return TagType_STRING
+}
+
+func (x *TagSchema) GetAllowedValues() []string {
+	if x != nil {
+		return x.AllowedValues
+	}
+	return nil
+}
+
+func (x *TagSchema) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *TagSchema) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type PutTagSchemaRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Schema *TagSchema `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+}
+
+func (x *PutTagSchemaRequest) Reset() {
+	*x = PutTagSchemaRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutTagSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutTagSchemaRequest) ProtoMessage() {}
+
+func (x *PutTagSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutTagSchemaRequest.ProtoReflect.Descriptor instead.
+func (*PutTagSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PutTagSchemaRequest) GetSchema() *TagSchema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+type PutTagSchemaResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Schema *TagSchema `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+}
+
+func (x *PutTagSchemaResponse) Reset() {
+	*x = PutTagSchemaResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutTagSchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutTagSchemaResponse) ProtoMessage() {}
+
+func (x *PutTagSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutTagSchemaResponse.ProtoReflect.Descriptor instead.
+func (*PutTagSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PutTagSchemaResponse) GetSchema() *TagSchema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+type ListTagSchemasRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListTagSchemasRequest) Reset() {
+	*x = ListTagSchemasRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTagSchemasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagSchemasRequest) ProtoMessage() {}
+
+func (x *ListTagSchemasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagSchemasRequest.ProtoReflect.Descriptor instead.
+func (*ListTagSchemasRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{38}
+}
+
+type ListTagSchemasResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Schemas []*TagSchema `protobuf:"bytes,1,rep,name=schemas,proto3" json:"schemas,omitempty"`
+}
+
+func (x *ListTagSchemasResponse) Reset() {
+	*x = ListTagSchemasResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTagSchemasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagSchemasResponse) ProtoMessage() {}
+
+func (x *ListTagSchemasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagSchemasResponse.ProtoReflect.Descriptor instead.
+func (*ListTagSchemasResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListTagSchemasResponse) GetSchemas() []*TagSchema {
+	if x != nil {
+		return x.Schemas
+	}
+	return nil
+}
+
+type DeleteTagSchemaRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *DeleteTagSchemaRequest) Reset() {
+	*x = DeleteTagSchemaRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTagSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTagSchemaRequest) ProtoMessage() {}
+
+func (x *DeleteTagSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTagSchemaRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTagSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeleteTagSchemaRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type DeleteTagSchemaResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deleted bool `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+}
+
+func (x *DeleteTagSchemaResponse) Reset() {
+	*x = DeleteTagSchemaResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTagSchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTagSchemaResponse) ProtoMessage() {}
+
+func (x *DeleteTagSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTagSchemaResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTagSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DeleteTagSchemaResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+type SearchSongsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	//
+	// Words to look for in song names and the tags configured as searchable,
+	// songs with any of the words match
+	// EX:
+	// midnight city -remix "m83"
+	//
+	// - "a phrase"  only songs containing the phrase match
+	// - -word       songs containing word don't match
+	// Matching ignores case but words aren't stemmed
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// Unset or 0 means 100 per page, larger pages are capped at 1000
+	PageSize *int64 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	// Opaque token from a previous response, empty for the first page
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *SearchSongsRequest) Reset() {
+	*x = SearchSongsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSongsRequest) ProtoMessage() {}
+
+func (x *SearchSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSongsRequest.ProtoReflect.Descriptor instead.
+func (*SearchSongsRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SearchSongsRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SearchSongsRequest) GetPageSize() int64 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchSongsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type SearchSongsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results   []*SearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	TotalSize int64           `protobuf:"varint,2,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	// Pass as page_token to get the next page, empty when there are no more songs
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *SearchSongsResponse) Reset() {
+	*x = SearchSongsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchSongsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSongsResponse) ProtoMessage() {}
+
+func (x *SearchSongsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSongsResponse.ProtoReflect.Descriptor instead.
+func (*SearchSongsResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SearchSongsResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchSongsResponse) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *SearchSongsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Song *File `protobuf:"bytes,1,opt,name=song,proto3" json:"song,omitempty"`
+	// Relevance of the song, higher is better. Scores are only comparable
+	// within the same search
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SearchResult) GetSong() *File {
+	if x != nil {
+		return x.Song
+	}
+	return nil
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetTagFacetsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Tag keys to count the values of, up to 20
+	Keys []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	// Only songs matching the tags are counted, same semantics as
+	// GetSongsByTagsRequest. No tags counts every song
+	Tags   map[string]string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Filter Filter            `protobuf:"varint,3,opt,name=filter,proto3,enum=tensorbeat.datalake.Filter" json:"filter,omitempty"`
+	// Values returned per key, defaults to 10 and can be up to 1000
+	Limit int64 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *GetTagFacetsRequest) Reset() {
+	*x = GetTagFacetsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTagFacetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTagFacetsRequest) ProtoMessage() {}
+
+func (x *GetTagFacetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTagFacetsRequest.ProtoReflect.Descriptor instead.
+func (*GetTagFacetsRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetTagFacetsRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *GetTagFacetsRequest) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GetTagFacetsRequest) GetFilter() Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return Filter_ANY
+}
+
+func (x *GetTagFacetsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetTagFacetsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// One facet per key in the order they were sent
+	Facets []*TagFacet `protobuf:"bytes,1,rep,name=facets,proto3" json:"facets,omitempty"`
+}
+
+func (x *GetTagFacetsResponse) Reset() {
+	*x = GetTagFacetsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTagFacetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTagFacetsResponse) ProtoMessage() {}
+
+func (x *GetTagFacetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTagFacetsResponse.ProtoReflect.Descriptor instead.
+func (*GetTagFacetsResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetTagFacetsResponse) GetFacets() []*TagFacet {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+type TagFacet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Most frequent values first
+	Values []*FacetValue `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	// Songs without the tag
+	MissingCount int64 `protobuf:"varint,3,opt,name=missing_count,json=missingCount,proto3" json:"missing_count,omitempty"`
+	// Songs whose value was left out by the limit
+	OtherCount int64 `protobuf:"varint,4,opt,name=other_count,json=otherCount,proto3" json:"other_count,omitempty"`
+}
+
+func (x *TagFacet) Reset() {
+	*x = TagFacet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TagFacet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagFacet) ProtoMessage() {}
+
+func (x *TagFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagFacet.ProtoReflect.Descriptor instead.
+func (*TagFacet) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *TagFacet) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TagFacet) GetValues() []*FacetValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *TagFacet) GetMissingCount() int64 {
+	if x != nil {
+		return x.MissingCount
+	}
+	return 0
+}
+
+func (x *TagFacet) GetOtherCount() int64 {
+	if x != nil {
+		return x.OtherCount
+	}
+	return 0
+}
+
+type FacetValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value *TagValue `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count int64     `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *FacetValue) Reset() {
+	*x = FacetValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FacetValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetValue) ProtoMessage() {}
+
+func (x *FacetValue) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetValue.ProtoReflect.Descriptor instead.
+func (*FacetValue) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *FacetValue) GetValue() *TagValue {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *FacetValue) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ListTagKeysRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unset or 0 means 100 per page, larger pages are capped at 1000
+	PageSize *int64 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	// Opaque token from a previous response, empty for the first page
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListTagKeysRequest) Reset() {
+	*x = ListTagKeysRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTagKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagKeysRequest) ProtoMessage() {}
+
+func (x *ListTagKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListTagKeysRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListTagKeysRequest) GetPageSize() int64 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTagKeysRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTagKeysResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Keys in alphabetical order
+	Keys []*TagKeyCount `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	// Pass as page_token to get the next page, empty when there are no more keys
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListTagKeysResponse) Reset() {
+	*x = ListTagKeysResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTagKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagKeysResponse) ProtoMessage() {}
+
+func (x *ListTagKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListTagKeysResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListTagKeysResponse) GetKeys() []*TagKeyCount {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *ListTagKeysResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type TagKeyCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Songs with the tag
+	Count int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *TagKeyCount) Reset() {
+	*x = TagKeyCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TagKeyCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagKeyCount) ProtoMessage() {}
+
+func (x *TagKeyCount) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagKeyCount.ProtoReflect.Descriptor instead.
+func (*TagKeyCount) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *TagKeyCount) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TagKeyCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ListTagValuesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Unset or 0 means 100 per page, larger pages are capped at 1000
+	PageSize *int64 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	// Opaque token from a previous response, empty for the first page
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListTagValuesRequest) Reset() {
+	*x = ListTagValuesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTagValuesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagValuesRequest) ProtoMessage() {}
+
+func (x *ListTagValuesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagValuesRequest.ProtoReflect.Descriptor instead.
+func (*ListTagValuesRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListTagValuesRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ListTagValuesRequest) GetPageSize() int64 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTagValuesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTagValuesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Values in the order of SortOrder, count is the number of songs with the value
+	Values []*FacetValue `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	// Pass as page_token to get the next page, empty when there are no more values
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListTagValuesResponse) Reset() {
+	*x = ListTagValuesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTagValuesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagValuesResponse) ProtoMessage() {}
+
+func (x *ListTagValuesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagValuesResponse.ProtoReflect.Descriptor instead.
+func (*ListTagValuesResponse) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListTagValuesResponse) GetValues() []*FacetValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *ListTagValuesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type MigrateTagsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Renames key, songs already having new_key are left alone and counted as
+	// conflicts
+	NewKey string `protobuf:"bytes,2,opt,name=new_key,json=newKey,proto3" json:"new_key,omitempty"`
+	// Remaps string values of key from the map keys to the map values, new
+	// values can't be remapped themselves. Only one of new_key and values can
+	// be set.
+	Values map[string]string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Only counts the songs the migration matches
+	DryRun bool `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Resumes a migration that stopped before completing, the other fields
+	// are ignored
+	MigrationId string `protobuf:"bytes,5,opt,name=migration_id,json=migrationId,proto3" json:"migration_id,omitempty"`
+	// Songs migrated per batch, defaults to 500
+	BatchSize *int64 `protobuf:"varint,6,opt,name=batch_size,json=batchSize,proto3,oneof" json:"batch_size,omitempty"`
+}
+
+func (x *MigrateTagsRequest) Reset() {
+	*x = MigrateTagsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MigrateTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateTagsRequest) ProtoMessage() {}
+
+func (x *MigrateTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateTagsRequest.ProtoReflect.Descriptor instead.
+func (*MigrateTagsRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *MigrateTagsRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *MigrateTagsRequest) GetNewKey() string {
+	if x != nil {
+		return x.NewKey
+	}
+	return ""
+}
+
+func (x *MigrateTagsRequest) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *MigrateTagsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *MigrateTagsRequest) GetMigrationId() string {
+	if x != nil {
+		return x.MigrationId
+	}
+	return ""
+}
+
+func (x *MigrateTagsRequest) GetBatchSize() int64 {
+	if x != nil && x.BatchSize != nil {
+		return *x.BatchSize
+	}
+	return 0
+}
+
+type RollBackTagMigrationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MigrationId string `protobuf:"bytes,1,opt,name=migration_id,json=migrationId,proto3" json:"migration_id,omitempty"`
+	// Songs rolled back per batch, defaults to 500
+	BatchSize *int64 `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3,oneof" json:"batch_size,omitempty"`
+}
+
+func (x *RollBackTagMigrationRequest) Reset() {
+	*x = RollBackTagMigrationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RollBackTagMigrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollBackTagMigrationRequest) ProtoMessage() {}
+
+func (x *RollBackTagMigrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollBackTagMigrationRequest.ProtoReflect.Descriptor instead.
+func (*RollBackTagMigrationRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RollBackTagMigrationRequest) GetMigrationId() string {
+	if x != nil {
+		return x.MigrationId
+	}
+	return ""
+}
+
+func (x *RollBackTagMigrationRequest) GetBatchSize() int64 {
+	if x != nil && x.BatchSize != nil {
+		return *x.BatchSize
+	}
+	return 0
+}
+
+type GetTagMigrationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MigrationId string `protobuf:"bytes,1,opt,name=migration_id,json=migrationId,proto3" json:"migration_id,omitempty"`
+}
+
+func (x *GetTagMigrationRequest) Reset() {
+	*x = GetTagMigrationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTagMigrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTagMigrationRequest) ProtoMessage() {}
+
+func (x *GetTagMigrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTagMigrationRequest.ProtoReflect.Descriptor instead.
+func (*GetTagMigrationRequest) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetTagMigrationRequest) GetMigrationId() string {
+	if x != nil {
+		return x.MigrationId
+	}
+	return ""
+}
+
+type TagMigration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Empty for dry runs
+	Id            string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key           string            `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	NewKey        string            `protobuf:"bytes,3,opt,name=new_key,json=newKey,proto3" json:"new_key,omitempty"`
+	Values        map[string]string `protobuf:"bytes,4,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	State         TagMigrationState `protobuf:"varint,5,opt,name=state,proto3,enum=tensorbeat.datalake.TagMigrationState" json:"state,omitempty"`
+	MatchedCount  int64             `protobuf:"varint,6,opt,name=matched_count,json=matchedCount,proto3" json:"matched_count,omitempty"`
+	ModifiedCount int64             `protobuf:"varint,7,opt,name=modified_count,json=modifiedCount,proto3" json:"modified_count,omitempty"`
+	// Songs left alone because they already had new_key
+	ConflictCount   int64 `protobuf:"varint,8,opt,name=conflict_count,json=conflictCount,proto3" json:"conflict_count,omitempty"`
+	RolledBackCount int64 `protobuf:"varint,9,opt,name=rolled_back_count,json=rolledBackCount,proto3" json:"rolled_back_count,omitempty"`
+	// Songs left alone by the rollback because their tags changed since
+	RollbackConflictCount int64 `protobuf:"varint,10,opt,name=rollback_conflict_count,json=rollbackConflictCount,proto3" json:"rollback_conflict_count,omitempty"`
+}
+
+func (x *TagMigration) Reset() {
+	*x = TagMigration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tensorbeat_datalake_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TagMigration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagMigration) ProtoMessage() {}
+
+func (x *TagMigration) ProtoReflect() protoreflect.Message {
+	mi := &file_tensorbeat_datalake_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagMigration.ProtoReflect.Descriptor instead.
+func (*TagMigration) Descriptor() ([]byte, []int) {
+	return file_tensorbeat_datalake_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *TagMigration) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TagMigration) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TagMigration) GetNewKey() string {
+	if x != nil {
+		return x.NewKey
+	}
+	return ""
+}
+
+func (x *TagMigration) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *TagMigration) GetState() TagMigrationState {
+	if x != nil {
+		return x.State
+	}
+	return TagMigrationState_PLANNED
+}
+
+func (x *TagMigration) GetMatchedCount() int64 {
+	if x != nil {
+		return x.MatchedCount
+	}
+	return 0
+}
+
+func (x *TagMigration) GetModifiedCount() int64 {
+	if x != nil {
+		return x.ModifiedCount
+	}
+	return 0
+}
+
+func (x *TagMigration) GetConflictCount() int64 {
+	if x != nil {
+		return x.ConflictCount
+	}
+	return 0
+}
+
+func (x *TagMigration) GetRolledBackCount() int64 {
+	if x != nil {
+		return x.RolledBackCount
+	}
+	return 0
+}
+
+func (x *TagMigration) GetRollbackConflictCount() int64 {
+	if x != nil {
+		return x.RollbackConflictCount
+	}
+	return 0
+}
+
+var File_tensorbeat_datalake_proto protoreflect.FileDescriptor
+
+var file_tensorbeat_datalake_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2f, 0x64, 0x61, 0x74,
+	0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x13, 0x74, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65,
+	0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x15, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x63,
+	0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x74, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x62, 0x65, 0x61, 0x74, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0x41, 0x0a, 0x09, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0xbf, 0x04, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e,
+	0x67, 0x73, 0x42, 0x79, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x48, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e,
+	0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c,
+	0x61, 0x6b, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42, 0x79, 0x54, 0x61,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x74, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x20,
+	0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x48, 0x00, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01,
+	0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x51, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x37, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42,
+	0x79, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x12, 0x32, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x37, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x6d,
+	0x61, 0x73, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x08, 0x72, 0x65, 0x61, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x1a,
+	0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x59, 0x0a, 0x0c, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e,
+	0x54, 0x61, 0x67, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x22, 0x6c, 0x0a, 0x08, 0x54, 0x61, 0x67, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x32, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1e, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x94, 0x01, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e,
+	0x67, 0x73, 0x42, 0x79, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2d, 0x0a, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x26,
+	0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x84, 0x01, 0x0a,
+	0x0f, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x30, 0x0a, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x41, 0x64, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x73, 0x6f, 0x6e,
+	0x67, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x73, 0x65, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x75, 0x70, 0x73, 0x65, 0x72, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64,
+	0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x4b, 0x65, 0x79, 0x22, 0x86, 0x01, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x40, 0x0a, 0x08, 0x66, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x74,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61,
+	0x6b, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x46, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x22, 0x63, 0x0a, 0x0f,
+	0x41, 0x64, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x24, 0x0a, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x22, 0xa0, 0x03, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x41, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x51, 0x0a, 0x0a, 0x74, 0x79, 0x70, 0x65, 0x64,
+	0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x74, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b,
+	0x65, 0x2e, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x54, 0x79, 0x70, 0x65, 0x64, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x09, 0x74, 0x79, 0x70, 0x65, 0x64, 0x54, 0x61, 0x67, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64,
+	0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x4b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f,
+	0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x59, 0x0a, 0x0e, 0x54, 0x79, 0x70,
+	0x65, 0x64, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x31, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2e, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x4d, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x66, 0x75, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0xf8, 0x01, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x44, 0x0a, 0x04, 0x74, 0x61, 0x67,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12,
+	0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x50,
+	0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66,
+	0x75, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x66, 0x75, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x36, 0x0a, 0x0c, 0x53, 0x6f, 0x6e, 0x67, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69,
+	0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0x9b, 0x03, 0x0a, 0x13, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x37, 0x0a, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x53, 0x6f, 0x6e, 0x67, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x52, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x46, 0x0a, 0x04, 0x74, 0x61, 0x67,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x74, 0x61, 0x67,
+	0x73, 0x12, 0x56, 0x0a, 0x0a, 0x74, 0x79, 0x70, 0x65, 0x64, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65,
+	0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x54, 0x79, 0x70, 0x65, 0x64, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09,
+	0x74, 0x79, 0x70, 0x65, 0x64, 0x54, 0x61, 0x67, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79,
+	0x5f, 0x72, 0x75, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52,
+	0x75, 0x6e, 0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x59, 0x0a, 0x0e, 0x54,
+	0x79, 0x70, 0x65, 0x64, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x7e, 0x0a, 0x16, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x37, 0x0a, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x53, 0x6f, 0x6e, 0x67, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x52, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x17, 0x0a,
+	0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x22, 0x62, 0x0a, 0x14, 0x42, 0x61, 0x74, 0x63, 0x68, 0x41,
+	0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x6f, 0x64,
+	0x69, 0x66, 0x69, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x65, 0x0a, 0x17, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x6f,
+	0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0xd6, 0x01, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x6f, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x08, 0x70,
+	0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x32, 0x0a, 0x04, 0x73, 0x6f, 0x72,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x53, 0x6f,
+	0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x37, 0x0a,
+	0x09, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x08, 0x72, 0x65,
+	0x61, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x22, 0x91, 0x01, 0x0a, 0x13, 0x47,
+	0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x73, 0x6f, 0x6e, 0x67,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50,
+	0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0xea,
+	0x01, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42, 0x79, 0x49, 0x44, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x08,
+	0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x32, 0x0a, 0x04, 0x73, 0x6f,
+	0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x53,
+	0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x37,
+	0x0a, 0x09, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x08, 0x72,
+	0x65, 0x61, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x93, 0x01, 0x0a, 0x15,
+	0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61,
+	0x74, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x73,
+	0x6f, 0x6e, 0x67, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65,
+	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4a, 0x04, 0x08, 0x02, 0x10,
+	0x03, 0x22, 0xec, 0x01, 0x0a, 0x12, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x6f, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x45, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62,
+	0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12,
+	0x33, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1b, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75,
+	0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x65, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x73, 0x6f, 0x6e, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65,
+	0x61, 0x74, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04,
+	0x73, 0x6f, 0x6e, 0x67, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75,
+	0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x46, 0x0a, 0x12, 0x49, 0x6e, 0x67, 0x65, 0x73,
+	0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a,
+	0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2e, 0x41, 0x64, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x22,
+	0x9c, 0x01, 0x0a, 0x13, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x49,
+	0x6e, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x69, 0x6e,
+	0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x66,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x70,
+	0x0a, 0x0c, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x24, 0x0a, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x22, 0xdb, 0x01, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x45, 0x0a, 0x04, 0x74, 0x61, 0x67,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x12, 0x33, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1b, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3a,
+	0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x53, 0x0a, 0x18, 0x50, 0x75,
+	0x72, 0x67, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x09, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x3e, 0x0a, 0x19, 0x50, 0x75, 0x72, 0x67, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x53,
+	0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x70, 0x75, 0x72, 0x67, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x70, 0x75, 0x72, 0x67, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0x80, 0x01, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65,
+	0x61, 0x74, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05,
+	0x73, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f,
+	0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61,
+	0x73, 0x6b, 0x22, 0x44, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x6f, 0x6e,
+	0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x22, 0x36, 0x0a, 0x11, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a,
+	0x0c, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x22, 0xa3, 0x01, 0x0a, 0x09, 0x53, 0x6f, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x36,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x74,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61,
+	0x6b, 0x65, 0x2e, 0x53, 0x6f, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2b, 0x0a, 0x04, 0x73, 0x6f, 0x6e, 0x67, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61,
+	0x74, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x73,
+	0x6f, 0x6e, 0x67, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xac, 0x01, 0x0a, 0x11, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x12, 0x20, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x32, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x8a, 0x01, 0x0a, 0x12, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53,
+	0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x05,
+	0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65,
+	0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x22, 0xb4, 0x01, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x30, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1c, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x54, 0x61, 0x67, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x4d, 0x0a, 0x13, 0x50, 0x75, 0x74,
+	0x54, 0x61, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x36, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x54, 0x61, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x22, 0x4e, 0x0a, 0x14, 0x50, 0x75, 0x74, 0x54,
+	0x61, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x36, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x54, 0x61, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x22, 0x17, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x61, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x52, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x53, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61,
+	0x6b, 0x65, 0x2e, 0x54, 0x61, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x07, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x73, 0x22, 0x2a, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54,
+	0x61, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x22, 0x33, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x67, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x22, 0x77, 0x0a, 0x12, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x12, 0x20, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x88,
+	0x01, 0x01, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22,
+	0x99, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65,
+	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x51, 0x0a, 0x0c, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2b, 0x0a, 0x04, 0x73,
+	0x6f, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x69,
+	0x6c, 0x65, 0x52, 0x04, 0x73, 0x6f, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0xf5,
+	0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x46, 0x61, 0x63, 0x65, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x46, 0x0a, 0x04, 0x74, 0x61,
+	0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x47,
+	0x65, 0x74, 0x54, 0x61, 0x67, 0x46, 0x61, 0x63, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x74, 0x61,
+	0x67, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
+	0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x1a, 0x37, 0x0a,
+	0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4d, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67,
+	0x46, 0x61, 0x63, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35,
+	0x0a, 0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x54, 0x61, 0x67, 0x46, 0x61, 0x63, 0x65, 0x74, 0x52, 0x06, 0x66,
+	0x61, 0x63, 0x65, 0x74, 0x73, 0x22, 0x9b, 0x01, 0x0a, 0x08, 0x54, 0x61, 0x67, 0x46, 0x61, 0x63,
+	0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x37, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61,
+	0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x46, 0x61, 0x63, 0x65, 0x74,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x23, 0x0a,
+	0x0d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x55, 0x0a, 0x0a, 0x46, 0x61, 0x63, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x63, 0x0a, 0x12, 0x4c, 0x69,
+	0x73, 0x74, 0x54, 0x61, 0x67, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x20, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x88,
+	0x01, 0x01, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22,
+	0x73, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61,
+	0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x54, 0x61, 0x67, 0x4b, 0x65,
+	0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x26, 0x0a, 0x0f,
+	0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x35, 0x0a, 0x0b, 0x54, 0x61, 0x67, 0x4b, 0x65, 0x79, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x77, 0x0a, 0x14, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x20, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x22, 0x78, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c,
+	0x61, 0x6b, 0x65, 0x2e, 0x46, 0x61, 0x63, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xb6,
+	0x02, 0x0a, 0x12, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x65, 0x77, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x65, 0x77, 0x4b, 0x65, 0x79,
+	0x12, 0x4b, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x33, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x54, 0x61,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x17, 0x0a,
+	0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x69,
+	0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0a, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52,
+	0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x1a, 0x39, 0x0a,
+	0x0b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x73, 0x0a, 0x1b, 0x52, 0x6f, 0x6c, 0x6c, 0x42,
+	0x61, 0x63, 0x6b, 0x54, 0x61, 0x67, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x69,
+	0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0a, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52,
+	0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a,
+	0x0b, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x3b, 0x0a, 0x16,
+	0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x69,
+	0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xe0, 0x03, 0x0a, 0x0c, 0x54, 0x61,
+	0x67, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x17, 0x0a, 0x07,
+	0x6e, 0x65, 0x77, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e,
+	0x65, 0x77, 0x4b, 0x65, 0x79, 0x12, 0x45, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65,
+	0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x54, 0x61, 0x67, 0x4d,
+	0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x74, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b,
+	0x65, 0x2e, 0x54, 0x61, 0x67, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0c, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x25, 0x0a, 0x0e, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65,
+	0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69,
+	0x63, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d,
+	0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a,
+	0x11, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x64,
+	0x42, 0x61, 0x63, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x17, 0x72, 0x6f, 0x6c,
+	0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x72, 0x6f, 0x6c, 0x6c,
+	0x62, 0x61, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x1a, 0x39, 0x0a, 0x0b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x24, 0x0a, 0x06,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4e, 0x59, 0x10, 0x00, 0x12,
+	0x07, 0x0a, 0x03, 0x41, 0x4c, 0x4c, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45,
+	0x10, 0x02, 0x2a, 0x5f, 0x0a, 0x11, 0x54, 0x61, 0x67, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x4c, 0x41, 0x4e, 0x4e,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10,
+	0x01, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x02,
+	0x12, 0x10, 0x0a, 0x0c, 0x52, 0x4f, 0x4c, 0x4c, 0x49, 0x4e, 0x47, 0x5f, 0x42, 0x41, 0x43, 0x4b,
+	0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x4f, 0x4c, 0x4c, 0x45, 0x44, 0x5f, 0x42, 0x41, 0x43,
+	0x4b, 0x10, 0x04, 0x2a, 0x49, 0x0a, 0x0d, 0x53, 0x6f, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x49, 0x4e, 0x53, 0x45, 0x52, 0x54, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12,
+	0x10, 0x0a, 0x0c, 0x54, 0x41, 0x47, 0x53, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10,
+	0x02, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x42,
+	0x0a, 0x07, 0x54, 0x61, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52,
+	0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x09,
+	0x0a, 0x05, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x4f,
+	0x4c, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50,
+	0x10, 0x04, 0x2a, 0x4b, 0x0a, 0x09, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x09, 0x0a, 0x05, 0x45, 0x58, 0x41, 0x43, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x52,
+	0x45, 0x46, 0x49, 0x58, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x41, 0x53, 0x45, 0x5f, 0x49,
+	0x4e, 0x53, 0x45, 0x4e, 0x53, 0x49, 0x54, 0x49, 0x56, 0x45, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05,
+	0x52, 0x45, 0x47, 0x45, 0x58, 0x10, 0x03, 0x12, 0x06, 0x0a, 0x02, 0x49, 0x4e, 0x10, 0x04, 0x32,
+	0xdc, 0x13, 0x0a, 0x0f, 0x44, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x60, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x6f, 0x6e,
+	0x67, 0x73, 0x12, 0x27, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53,
+	0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x74, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b,
+	0x65, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x66, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67,
+	0x73, 0x42, 0x79, 0x49, 0x44, 0x73, 0x12, 0x29, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62,
+	0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2a, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73,
+	0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a,
+	0x0e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42, 0x79, 0x54, 0x61, 0x67, 0x73, 0x12,
+	0x2a, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42, 0x79,
+	0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x74, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b,
+	0x65, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x42, 0x79, 0x54, 0x61, 0x67, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x26, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62,
+	0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27,
+	0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x0b, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x27, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62,
+	0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x28, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x6f, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x0c, 0x47, 0x65, 0x74,
+	0x54, 0x61, 0x67, 0x46, 0x61, 0x63, 0x65, 0x74, 0x73, 0x12, 0x28, 0x2e, 0x74, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e,
+	0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x46, 0x61, 0x63, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67,
+	0x46, 0x61, 0x63, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60,
+	0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x27, 0x2e,
+	0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c,
+	0x61, 0x6b, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x4b, 0x65, 0x79, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62,
+	0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x54, 0x61, 0x67, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x66, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x12, 0x29, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x74,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61,
+	0x6b, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x08, 0x41, 0x64, 0x64, 0x53,
+	0x6f, 0x6e, 0x67, 0x73, 0x12, 0x24, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61,
+	0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x53, 0x6f,
+	0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x74, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65,
+	0x2e, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x54, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x12, 0x23, 0x2e, 0x74,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61,
+	0x6b, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x24, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x0a, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x54, 0x61, 0x67, 0x73, 0x12, 0x26, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65,
+	0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e,
+	0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c,
+	0x61, 0x6b, 0x65, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x0c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x41,
+	0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x12, 0x28, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62,
+	0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x41, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x29, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x41, 0x64, 0x64, 0x54,
+	0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6c, 0x0a, 0x0f, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x12, 0x2b,
+	0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x74, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b,
+	0x65, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x62, 0x0a, 0x0b, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x27, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x28, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x6f,
+	0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x62, 0x0a,
+	0x0b, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x27, 0x2e, 0x74,
+	0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61,
+	0x6b, 0x65, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65,
+	0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x49, 0x6e, 0x67, 0x65,
+	0x73, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28,
+	0x01, 0x12, 0x60, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x73,
+	0x12, 0x27, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x6e,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x74, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a, 0x11, 0x50, 0x75, 0x72, 0x67, 0x65, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x2d, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x50,
+	0x75, 0x72, 0x67, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x50, 0x75,
+	0x72, 0x67, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x27, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62,
+	0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x28, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x0a, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x26, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x53, 0x6f, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30,
+	0x01, 0x12, 0x63, 0x0a, 0x0c, 0x50, 0x75, 0x74, 0x54, 0x61, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x12, 0x28, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x50, 0x75, 0x74, 0x54, 0x61, 0x67, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x74, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b,
+	0x65, 0x2e, 0x50, 0x75, 0x74, 0x54, 0x61, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61,
+	0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x12, 0x2a, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61,
+	0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54,
+	0x61, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x6c, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x67, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x12, 0x2b, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61,
+	0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x54, 0x61, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2c, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61,
+	0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x5b, 0x0a, 0x0b, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x73, 0x12, 0x27,
+	0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x54, 0x61,
+	0x67, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x30, 0x01, 0x12, 0x6d, 0x0a, 0x14,
+	0x52, 0x6f, 0x6c, 0x6c, 0x42, 0x61, 0x63, 0x6b, 0x54, 0x61, 0x67, 0x4d, 0x69, 0x67, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61,
+	0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x6c, 0x42,
+	0x61, 0x63, 0x6b, 0x54, 0x61, 0x67, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62,
+	0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x54, 0x61, 0x67,
+	0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x30, 0x01, 0x12, 0x61, 0x0a, 0x0f, 0x47,
+	0x65, 0x74, 0x54, 0x61, 0x67, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b,
+	0x2e, 0x74, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x6c, 0x61, 0x6b, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x67, 0x4d, 0x69, 0x67, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x74, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6c, 0x61, 0x6b,
+	0x65, 0x2e, 0x54, 0x61, 0x67, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0f,
+	0x5a, 0x0d, 0x2e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_tensorbeat_datalake_proto_rawDescOnce sync.Once
+	file_tensorbeat_datalake_proto_rawDescData = file_tensorbeat_datalake_proto_rawDesc
+)
+
+func file_tensorbeat_datalake_proto_rawDescGZIP() []byte {
+	file_tensorbeat_datalake_proto_rawDescOnce.Do(func() {
+		file_tensorbeat_datalake_proto_rawDescData = protoimpl.X.CompressGZIP(file_tensorbeat_datalake_proto_rawDescData)
+	})
+	return file_tensorbeat_datalake_proto_rawDescData
+}
+
+var file_tensorbeat_datalake_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_tensorbeat_datalake_proto_msgTypes = make([]protoimpl.MessageInfo, 70)
+var file_tensorbeat_datalake_proto_goTypes = []interface{}{
+	(Filter)(0),                         // 0: tensorbeat.datalake.Filter
+	(TagMigrationState)(0),              // 1: tensorbeat.datalake.TagMigrationState
+	(SongEventType)(0),                  // 2: tensorbeat.datalake.SongEventType
+	(TagType)(0),                        // 3: tensorbeat.datalake.TagType
+	(MatchMode)(0),                      // 4: tensorbeat.datalake.MatchMode
+	(*SortOrder)(nil),                   // 5: tensorbeat.datalake.SortOrder
+	(*GetSongsByTagsRequest)(nil),       // 6: tensorbeat.datalake.GetSongsByTagsRequest
+	(*TagMatch)(nil),                    // 7: tensorbeat.datalake.TagMatch
+	(*GetSongsByTagsResponse)(nil),      // 8: tensorbeat.datalake.GetSongsByTagsResponse
+	(*AddSongsRequest)(nil),             // 9: tensorbeat.datalake.AddSongsRequest
+	(*AddSongsResponse)(nil),            // 10: tensorbeat.datalake.AddSongsResponse
+	(*AddSongsFailure)(nil),             // 11: tensorbeat.datalake.AddSongsFailure
+	(*AddTagsRequest)(nil),              // 12: tensorbeat.datalake.AddTagsRequest
+	(*AddTagsResponse)(nil),             // 13: tensorbeat.datalake.AddTagsResponse
+	(*RemoveTagsRequest)(nil),           // 14: tensorbeat.datalake.RemoveTagsRequest
+	(*RemoveTagsResponse)(nil),          // 15: tensorbeat.datalake.RemoveTagsResponse
+	(*SongSelector)(nil),                // 16: tensorbeat.datalake.SongSelector
+	(*BatchAddTagsRequest)(nil),         // 17: tensorbeat.datalake.BatchAddTagsRequest
+	(*BatchRemoveTagsRequest)(nil),      // 18: tensorbeat.datalake.BatchRemoveTagsRequest
+	(*BatchAddTagsResponse)(nil),        // 19: tensorbeat.datalake.BatchAddTagsResponse
+	(*BatchRemoveTagsResponse)(nil),     // 20: tensorbeat.datalake.BatchRemoveTagsResponse
+	(*GetAllSongsRequest)(nil),          // 21: tensorbeat.datalake.GetAllSongsRequest
+	(*GetAllSongsResponse)(nil),         // 22: tensorbeat.datalake.GetAllSongsResponse
+	(*GetSongsByIDsRequest)(nil),        // 23: tensorbeat.datalake.GetSongsByIDsRequest
+	(*GetSongsByIDsResponse)(nil),       // 24: tensorbeat.datalake.GetSongsByIDsResponse
+	(*StreamSongsRequest)(nil),          // 25: tensorbeat.datalake.StreamSongsRequest
+	(*StreamSongsResponse)(nil),         // 26: tensorbeat.datalake.StreamSongsResponse
+	(*IngestSongsRequest)(nil),          // 27: tensorbeat.datalake.IngestSongsRequest
+	(*IngestSongsResponse)(nil),         // 28: tensorbeat.datalake.IngestSongsResponse
+	(*IngestResult)(nil),                // 29: tensorbeat.datalake.IngestResult
+	(*DeleteSongsRequest)(nil),          // 30: tensorbeat.datalake.DeleteSongsRequest
+	(*DeleteSongsResponse)(nil),         // 31: tensorbeat.datalake.DeleteSongsResponse
+	(*PurgeDeletedSongsRequest)(nil),    // 32: tensorbeat.datalake.PurgeDeletedSongsRequest
+	(*PurgeDeletedSongsResponse)(nil),   // 33: tensorbeat.datalake.PurgeDeletedSongsResponse
+	(*UpdateSongsRequest)(nil),          // 34: tensorbeat.datalake.UpdateSongsRequest
+	(*UpdateSongsResponse)(nil),         // 35: tensorbeat.datalake.UpdateSongsResponse
+	(*WatchSongsRequest)(nil),           // 36: tensorbeat.datalake.WatchSongsRequest
+	(*SongEvent)(nil),                   // 37: tensorbeat.datalake.SongEvent
+	(*QuerySongsRequest)(nil),           // 38: tensorbeat.datalake.QuerySongsRequest
+	(*QuerySongsResponse)(nil),          // 39: tensorbeat.datalake.QuerySongsResponse
+	(*TagSchema)(nil),                   // 40: tensorbeat.datalake.TagSchema
+	(*PutTagSchemaRequest)(nil),         // 41: tensorbeat.datalake.PutTagSchemaRequest
+	(*PutTagSchemaResponse)(nil),        // 42: tensorbeat.datalake.PutTagSchemaResponse
+	(*ListTagSchemasRequest)(nil),       // 43: tensorbeat.datalake.ListTagSchemasRequest
+	(*ListTagSchemasResponse)(nil),      // 44: tensorbeat.datalake.ListTagSchemasResponse
+	(*DeleteTagSchemaRequest)(nil),      // 45: tensorbeat.datalake.DeleteTagSchemaRequest
+	(*DeleteTagSchemaResponse)(nil),     // 46: tensorbeat.datalake.DeleteTagSchemaResponse
+	(*SearchSongsRequest)(nil),          // 47: tensorbeat.datalake.SearchSongsRequest
+	(*SearchSongsResponse)(nil),         // 48: tensorbeat.datalake.SearchSongsResponse
+	(*SearchResult)(nil),                // 49: tensorbeat.datalake.SearchResult
+	(*GetTagFacetsRequest)(nil),         // 50: tensorbeat.datalake.GetTagFacetsRequest
+	(*GetTagFacetsResponse)(nil),        // 51: tensorbeat.datalake.GetTagFacetsResponse
+	(*TagFacet)(nil),                    // 52: tensorbeat.datalake.TagFacet
+	(*FacetValue)(nil),                  // 53: tensorbeat.datalake.FacetValue
+	(*ListTagKeysRequest)(nil),          // 54: tensorbeat.datalake.ListTagKeysRequest
+	(*ListTagKeysResponse)(nil),         // 55: tensorbeat.datalake.ListTagKeysResponse
+	(*TagKeyCount)(nil),                 // 56: tensorbeat.datalake.TagKeyCount
+	(*ListTagValuesRequest)(nil),        // 57: tensorbeat.datalake.ListTagValuesRequest
+	(*ListTagValuesResponse)(nil),       // 58: tensorbeat.datalake.ListTagValuesResponse
+	(*MigrateTagsRequest)(nil),          // 59: tensorbeat.datalake.MigrateTagsRequest
+	(*RollBackTagMigrationRequest)(nil), // 60: tensorbeat.datalake.RollBackTagMigrationRequest
+	(*GetTagMigrationRequest)(nil),      // 61: tensorbeat.datalake.GetTagMigrationRequest
+	(*TagMigration)(nil),                // 62: tensorbeat.datalake.TagMigration
+	nil,                                 // 63: tensorbeat.datalake.GetSongsByTagsRequest.TagsEntry
+	nil,                                 // 64: tensorbeat.datalake.GetSongsByTagsRequest.MatchesEntry
+	nil,                                 // 65: tensorbeat.datalake.AddTagsRequest.TagsEntry
+	nil,                                 // 66: tensorbeat.datalake.AddTagsRequest.TypedTagsEntry
+	nil,                                 // 67: tensorbeat.datalake.RemoveTagsRequest.TagsEntry
+	nil,                                 // 68: tensorbeat.datalake.BatchAddTagsRequest.TagsEntry
+	nil,                                 // 69: tensorbeat.datalake.BatchAddTagsRequest.TypedTagsEntry
+	nil,                                 // 70: tensorbeat.datalake.StreamSongsRequest.TagsEntry
+	nil,                                 // 71: tensorbeat.datalake.DeleteSongsRequest.TagsEntry
+	nil,                                 // 72: tensorbeat.datalake.GetTagFacetsRequest.TagsEntry
+	nil,                                 // 73: tensorbeat.datalake.MigrateTagsRequest.ValuesEntry
+	nil,                                 // 74: tensorbeat.datalake.TagMigration.ValuesEntry
+	(*field_mask.FieldMask)(nil),        // 75: google.protobuf.FieldMask
+	(*File)(nil),                        // 76: tensorbeat.common.File
+	(*AddFile)(nil),                     // 77: tensorbeat.common.AddFile
+	(code.Code)(0),                      // 78: google.rpc.Code
+	(*duration.Duration)(nil),           // 79: google.protobuf.Duration
+	(*TagValue)(nil),                    // 80: tensorbeat.common.TagValue
+}
+var file_tensorbeat_datalake_proto_depIdxs = []int32{
+	63, // 0: tensorbeat.datalake.GetSongsByTagsRequest.tags:type_name -> tensorbeat.datalake.GetSongsByTagsRequest.TagsEntry
+	0,  // 1: tensorbeat.datalake.GetSongsByTagsRequest.filter:type_name -> tensorbeat.datalake.Filter
+	64, // 2: tensorbeat.datalake.GetSongsByTagsRequest.matches:type_name -> tensorbeat.datalake.GetSongsByTagsRequest.MatchesEntry
+	5,  // 3: tensorbeat.datalake.GetSongsByTagsRequest.sort:type_name -> tensorbeat.datalake.SortOrder
+	75, // 4: tensorbeat.datalake.GetSongsByTagsRequest.read_mask:type_name -> google.protobuf.FieldMask
+	4,  // 5: tensorbeat.datalake.TagMatch.mode:type_name -> tensorbeat.datalake.MatchMode
+	76, // 6: tensorbeat.datalake.GetSongsByTagsResponse.songs:type_name -> tensorbeat.common.File
+	77, // 7: tensorbeat.datalake.AddSongsRequest.songs:type_name -> tensorbeat.common.AddFile
+	11, // 8: tensorbeat.datalake.AddSongsResponse.failures:type_name -> tensorbeat.datalake.AddSongsFailure
+	78, // 9: tensorbeat.datalake.AddSongsFailure.code:type_name -> google.rpc.Code
+	65, // 10: tensorbeat.datalake.AddTagsRequest.tags:type_name -> tensorbeat.datalake.AddTagsRequest.TagsEntry
+	66, // 11: tensorbeat.datalake.AddTagsRequest.typed_tags:type_name -> tensorbeat.datalake.AddTagsRequest.TypedTagsEntry
+	67, // 12: tensorbeat.datalake.RemoveTagsRequest.tags:type_name -> tensorbeat.datalake.RemoveTagsRequest.TagsEntry
+	16, // 13: tensorbeat.datalake.BatchAddTagsRequest.songs:type_name -> tensorbeat.datalake.SongSelector
+	68, // 14: tensorbeat.datalake.BatchAddTagsRequest.tags:type_name -> tensorbeat.datalake.BatchAddTagsRequest.TagsEntry
+	69, // 15: tensorbeat.datalake.BatchAddTagsRequest.typed_tags:type_name -> tensorbeat.datalake.BatchAddTagsRequest.TypedTagsEntry
+	16, // 16: tensorbeat.datalake.BatchRemoveTagsRequest.songs:type_name -> tensorbeat.datalake.SongSelector
+	5,  // 17: tensorbeat.datalake.GetAllSongsRequest.sort:type_name -> tensorbeat.datalake.SortOrder
+	75, // 18: tensorbeat.datalake.GetAllSongsRequest.read_mask:type_name -> google.protobuf.FieldMask
+	76, // 19: tensorbeat.datalake.GetAllSongsResponse.songs:type_name -> tensorbeat.common.File
+	5,  // 20: tensorbeat.datalake.GetSongsByIDsRequest.sort:type_name -> tensorbeat.datalake.SortOrder
+	75, // 21: tensorbeat.datalake.GetSongsByIDsRequest.read_mask:type_name -> google.protobuf.FieldMask
+	76, // 22: tensorbeat.datalake.GetSongsByIDsResponse.songs:type_name -> tensorbeat.common.File
+	70, // 23: tensorbeat.datalake.StreamSongsRequest.tags:type_name -> tensorbeat.datalake.StreamSongsRequest.TagsEntry
+	0,  // 24: tensorbeat.datalake.StreamSongsRequest.filter:type_name -> tensorbeat.datalake.Filter
+	76, // 25: tensorbeat.datalake.StreamSongsResponse.song:type_name -> tensorbeat.common.File
+	77, // 26: tensorbeat.datalake.IngestSongsRequest.songs:type_name -> tensorbeat.common.AddFile
+	29, // 27: tensorbeat.datalake.IngestSongsResponse.results:type_name -> tensorbeat.datalake.IngestResult
+	78, // 28: tensorbeat.datalake.IngestResult.code:type_name -> google.rpc.Code
+	71, // 29: tensorbeat.datalake.DeleteSongsRequest.tags:type_name -> tensorbeat.datalake.DeleteSongsRequest.TagsEntry
+	0,  // 30: tensorbeat.datalake.DeleteSongsRequest.filter:type_name -> tensorbeat.datalake.Filter
+	79, // 31: tensorbeat.datalake.PurgeDeletedSongsRequest.retention:type_name -> google.protobuf.Duration
+	76, // 32: tensorbeat.datalake.UpdateSongsRequest.songs:type_name -> tensorbeat.common.File
+	75, // 33: tensorbeat.datalake.UpdateSongsRequest.update_mask:type_name -> google.protobuf.FieldMask
+	76, // 34: tensorbeat.datalake.UpdateSongsResponse.songs:type_name -> tensorbeat.common.File
+	2,  // 35: tensorbeat.datalake.SongEvent.type:type_name -> tensorbeat.datalake.SongEventType
+	76, // 36: tensorbeat.datalake.SongEvent.song:type_name -> tensorbeat.common.File
+	5,  // 37: tensorbeat.datalake.QuerySongsRequest.sort:type_name -> tensorbeat.datalake.SortOrder
+	76, // 38: tensorbeat.datalake.QuerySongsResponse.songs:type_name -> tensorbeat.common.File
+	3,  // 39: tensorbeat.datalake.TagSchema.type:type_name -> tensorbeat.datalake.TagType
+	40, // 40: tensorbeat.datalake.PutTagSchemaRequest.schema:type_name -> tensorbeat.datalake.TagSchema
+	40, // 41: tensorbeat.datalake.PutTagSchemaResponse.schema:type_name -> tensorbeat.datalake.TagSchema
+	40, // 42: tensorbeat.datalake.ListTagSchemasResponse.schemas:type_name -> tensorbeat.datalake.TagSchema
+	49, // 43: tensorbeat.datalake.SearchSongsResponse.results:type_name -> tensorbeat.datalake.SearchResult
+	76, // 44: tensorbeat.datalake.SearchResult.song:type_name -> tensorbeat.common.File
+	72, // 45: tensorbeat.datalake.GetTagFacetsRequest.tags:type_name -> tensorbeat.datalake.GetTagFacetsRequest.TagsEntry
+	0,  // 46: tensorbeat.datalake.GetTagFacetsRequest.filter:type_name -> tensorbeat.datalake.Filter
+	52, // 47: tensorbeat.datalake.GetTagFacetsResponse.facets:type_name -> tensorbeat.datalake.TagFacet
+	53, // 48: tensorbeat.datalake.TagFacet.values:type_name -> tensorbeat.datalake.FacetValue
+	80, // 49: tensorbeat.datalake.FacetValue.value:type_name -> tensorbeat.common.TagValue
+	56, // 50: tensorbeat.datalake.ListTagKeysResponse.keys:type_name -> tensorbeat.datalake.TagKeyCount
+	53, // 51: tensorbeat.datalake.ListTagValuesResponse.values:type_name -> tensorbeat.datalake.FacetValue
+	73, // 52: tensorbeat.datalake.MigrateTagsRequest.values:type_name -> tensorbeat.datalake.MigrateTagsRequest.ValuesEntry
+	74, // 53: tensorbeat.datalake.TagMigration.values:type_name -> tensorbeat.datalake.TagMigration.ValuesEntry
+	1,  // 54: tensorbeat.datalake.TagMigration.state:type_name -> tensorbeat.datalake.TagMigrationState
+	7,  // 55: tensorbeat.datalake.GetSongsByTagsRequest.MatchesEntry.value:type_name -> tensorbeat.datalake.TagMatch
+	80, // 56: tensorbeat.datalake.AddTagsRequest.TypedTagsEntry.value:type_name -> tensorbeat.common.TagValue
+	80, // 57: tensorbeat.datalake.BatchAddTagsRequest.TypedTagsEntry.value:type_name -> tensorbeat.common.TagValue
+	21, // 58: tensorbeat.datalake.DatalakeService.GetAllSongs:input_type -> tensorbeat.datalake.GetAllSongsRequest
+	23, // 59: tensorbeat.datalake.DatalakeService.GetSongsByIDs:input_type -> tensorbeat.datalake.GetSongsByIDsRequest
+	6,  // 60: tensorbeat.datalake.DatalakeService.GetSongsByTags:input_type -> tensorbeat.datalake.GetSongsByTagsRequest
+	38, // 61: tensorbeat.datalake.DatalakeService.QuerySongs:input_type -> tensorbeat.datalake.QuerySongsRequest
+	47, // 62: tensorbeat.datalake.DatalakeService.SearchSongs:input_type -> tensorbeat.datalake.SearchSongsRequest
+	50, // 63: tensorbeat.datalake.DatalakeService.GetTagFacets:input_type -> tensorbeat.datalake.GetTagFacetsRequest
+	54, // 64: tensorbeat.datalake.DatalakeService.ListTagKeys:input_type -> tensorbeat.datalake.ListTagKeysRequest
+	57, // 65: tensorbeat.datalake.DatalakeService.ListTagValues:input_type -> tensorbeat.datalake.ListTagValuesRequest
+	9,  // 66: tensorbeat.datalake.DatalakeService.AddSongs:input_type -> tensorbeat.datalake.AddSongsRequest
+	12, // 67: tensorbeat.datalake.DatalakeService.AddTags:input_type -> tensorbeat.datalake.AddTagsRequest
+	14, // 68: tensorbeat.datalake.DatalakeService.RemoveTags:input_type -> tensorbeat.datalake.RemoveTagsRequest
+	17, // 69: tensorbeat.datalake.DatalakeService.BatchAddTags:input_type -> tensorbeat.datalake.BatchAddTagsRequest
+	18, // 70: tensorbeat.datalake.DatalakeService.BatchRemoveTags:input_type -> tensorbeat.datalake.BatchRemoveTagsRequest
+	25, // 71: tensorbeat.datalake.DatalakeService.StreamSongs:input_type -> tensorbeat.datalake.StreamSongsRequest
+	27, // 72: tensorbeat.datalake.DatalakeService.IngestSongs:input_type -> tensorbeat.datalake.IngestSongsRequest
+	30, // 73: tensorbeat.datalake.DatalakeService.DeleteSongs:input_type -> tensorbeat.datalake.DeleteSongsRequest
+	32, // 74: tensorbeat.datalake.DatalakeService.PurgeDeletedSongs:input_type -> tensorbeat.datalake.PurgeDeletedSongsRequest
+	34, // 75: tensorbeat.datalake.DatalakeService.UpdateSongs:input_type -> tensorbeat.datalake.UpdateSongsRequest
+	36, // 76: tensorbeat.datalake.DatalakeService.WatchSongs:input_type -> tensorbeat.datalake.WatchSongsRequest
+	41, // 77: tensorbeat.datalake.DatalakeService.PutTagSchema:input_type -> tensorbeat.datalake.PutTagSchemaRequest
+	43, // 78: tensorbeat.datalake.DatalakeService.ListTagSchemas:input_type -> tensorbeat.datalake.ListTagSchemasRequest
+	45, // 79: tensorbeat.datalake.DatalakeService.DeleteTagSchema:input_type -> tensorbeat.datalake.DeleteTagSchemaRequest
+	59, // 80: tensorbeat.datalake.DatalakeService.MigrateTags:input_type -> tensorbeat.datalake.MigrateTagsRequest
+	60, // 81: tensorbeat.datalake.DatalakeService.RollBackTagMigration:input_type -> tensorbeat.datalake.RollBackTagMigrationRequest
+	61, // 82: tensorbeat.datalake.DatalakeService.GetTagMigration:input_type -> tensorbeat.datalake.GetTagMigrationRequest
+	22, // 83: tensorbeat.datalake.DatalakeService.GetAllSongs:output_type -> tensorbeat.datalake.GetAllSongsResponse
+	24, // 84: tensorbeat.datalake.DatalakeService.GetSongsByIDs:output_type -> tensorbeat.datalake.GetSongsByIDsResponse
+	8,  // 85: tensorbeat.datalake.DatalakeService.GetSongsByTags:output_type -> tensorbeat.datalake.GetSongsByTagsResponse
+	39, // 86: tensorbeat.datalake.DatalakeService.QuerySongs:output_type -> tensorbeat.datalake.QuerySongsResponse
+	48, // 87: tensorbeat.datalake.DatalakeService.SearchSongs:output_type -> tensorbeat.datalake.SearchSongsResponse
+	51, // 88: tensorbeat.datalake.DatalakeService.GetTagFacets:output_type -> tensorbeat.datalake.GetTagFacetsResponse
+	55, // 89: tensorbeat.datalake.DatalakeService.ListTagKeys:output_type -> tensorbeat.datalake.ListTagKeysResponse
+	58, // 90: tensorbeat.datalake.DatalakeService.ListTagValues:output_type -> tensorbeat.datalake.ListTagValuesResponse
+	10, // 91: tensorbeat.datalake.DatalakeService.AddSongs:output_type -> tensorbeat.datalake.AddSongsResponse
+	13, // 92: tensorbeat.datalake.DatalakeService.AddTags:output_type -> tensorbeat.datalake.AddTagsResponse
+	15, // 93: tensorbeat.datalake.DatalakeService.RemoveTags:output_type -> tensorbeat.datalake.RemoveTagsResponse
+	19, // 94: tensorbeat.datalake.DatalakeService.BatchAddTags:output_type -> tensorbeat.datalake.BatchAddTagsResponse
+	20, // 95: tensorbeat.datalake.DatalakeService.BatchRemoveTags:output_type -> tensorbeat.datalake.BatchRemoveTagsResponse
+	26, // 96: tensorbeat.datalake.DatalakeService.StreamSongs:output_type -> tensorbeat.datalake.StreamSongsResponse
+	28, // 97: tensorbeat.datalake.DatalakeService.IngestSongs:output_type -> tensorbeat.datalake.IngestSongsResponse
+	31, // 98: tensorbeat.datalake.DatalakeService.DeleteSongs:output_type -> tensorbeat.datalake.DeleteSongsResponse
+	33, // 99: tensorbeat.datalake.DatalakeService.PurgeDeletedSongs:output_type -> tensorbeat.datalake.PurgeDeletedSongsResponse
+	35, // 100: tensorbeat.datalake.DatalakeService.UpdateSongs:output_type -> tensorbeat.datalake.UpdateSongsResponse
+	37, // 101: tensorbeat.datalake.DatalakeService.WatchSongs:output_type -> tensorbeat.datalake.SongEvent
+	42, // 102: tensorbeat.datalake.DatalakeService.PutTagSchema:output_type -> tensorbeat.datalake.PutTagSchemaResponse
+	44, // 103: tensorbeat.datalake.DatalakeService.ListTagSchemas:output_type -> tensorbeat.datalake.ListTagSchemasResponse
+	46, // 104: tensorbeat.datalake.DatalakeService.DeleteTagSchema:output_type -> tensorbeat.datalake.DeleteTagSchemaResponse
+	62, // 105: tensorbeat.datalake.DatalakeService.MigrateTags:output_type -> tensorbeat.datalake.TagMigration
+	62, // 106: tensorbeat.datalake.DatalakeService.RollBackTagMigration:output_type -> tensorbeat.datalake.TagMigration
+	62, // 107: tensorbeat.datalake.DatalakeService.GetTagMigration:output_type -> tensorbeat.datalake.TagMigration
+	83, // [83:108] is the sub-list for method output_type
+	58, // [58:83] is the sub-list for method input_type
+	58, // [58:58] is the sub-list for extension type_name
+	58, // [58:58] is the sub-list for extension extendee
+	0,  // [0:58] is the sub-list for field type_name
 }
 
 func init() { file_tensorbeat_datalake_proto_init() }
@@ -978,6 +4937,18 @@ func file_tensorbeat_datalake_proto_init() {
 	file_tensorbeat_common_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_tensorbeat_datalake_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SortOrder); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetSongsByTagsRequest); i {
 			case 0:
 				return &v.state
@@ -989,8 +4960,272 @@ func file_tensorbeat_datalake_proto_init() {
 				return nil
 			}
 		}
-		file_tensorbeat_datalake_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetSongsByTagsResponse); i {
+		file_tensorbeat_datalake_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TagMatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSongsByTagsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddSongsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddSongsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddSongsFailure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddTagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddTagsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveTagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveTagsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SongSelector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchAddTagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchRemoveTagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchAddTagsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchRemoveTagsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAllSongsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAllSongsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSongsByIDsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSongsByIDsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamSongsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamSongsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestSongsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestSongsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1001,8 +5236,8 @@ func file_tensorbeat_datalake_proto_init() {
 				return nil
 			}
 		}
-		file_tensorbeat_datalake_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddSongsRequest); i {
+		file_tensorbeat_datalake_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSongsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1013,8 +5248,8 @@ func file_tensorbeat_datalake_proto_init() {
 				return nil
 			}
 		}
-		file_tensorbeat_datalake_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddSongsResponse); i {
+		file_tensorbeat_datalake_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSongsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1025,8 +5260,8 @@ func file_tensorbeat_datalake_proto_init() {
 				return nil
 			}
 		}
-		file_tensorbeat_datalake_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddTagsRequest); i {
+		file_tensorbeat_datalake_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PurgeDeletedSongsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1037,8 +5272,8 @@ func file_tensorbeat_datalake_proto_init() {
 				return nil
 			}
 		}
-		file_tensorbeat_datalake_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddTagsResponse); i {
+		file_tensorbeat_datalake_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PurgeDeletedSongsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1049,8 +5284,8 @@ func file_tensorbeat_datalake_proto_init() {
 				return nil
 			}
 		}
-		file_tensorbeat_datalake_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveTagsRequest); i {
+		file_tensorbeat_datalake_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateSongsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1061,8 +5296,8 @@ func file_tensorbeat_datalake_proto_init() {
 				return nil
 			}
 		}
-		file_tensorbeat_datalake_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveTagsResponse); i {
+		file_tensorbeat_datalake_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateSongsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1073,8 +5308,8 @@ func file_tensorbeat_datalake_proto_init() {
 				return nil
 			}
 		}
-		file_tensorbeat_datalake_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetAllSongsRequest); i {
+		file_tensorbeat_datalake_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchSongsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1085,8 +5320,8 @@ func file_tensorbeat_datalake_proto_init() {
 				return nil
 			}
 		}
-		file_tensorbeat_datalake_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetAllSongsResponse); i {
+		file_tensorbeat_datalake_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SongEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1097,8 +5332,8 @@ func file_tensorbeat_datalake_proto_init() {
 				return nil
 			}
 		}
-		file_tensorbeat_datalake_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetSongsByIDsRequest); i {
+		file_tensorbeat_datalake_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuerySongsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1109,8 +5344,284 @@ func file_tensorbeat_datalake_proto_init() {
 				return nil
 			}
 		}
-		file_tensorbeat_datalake_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetSongsByIDsResponse); i {
+		file_tensorbeat_datalake_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuerySongsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TagSchema); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PutTagSchemaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PutTagSchemaResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTagSchemasRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTagSchemasResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTagSchemaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTagSchemaResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchSongsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchSongsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTagFacetsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTagFacetsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TagFacet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FacetValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTagKeysRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTagKeysResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TagKeyCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTagValuesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTagValuesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MigrateTagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RollBackTagMigrationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTagMigrationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tensorbeat_datalake_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TagMigration); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1122,16 +5633,22 @@ func file_tensorbeat_datalake_proto_init() {
 			}
 		}
 	}
-	file_tensorbeat_datalake_proto_msgTypes[0].OneofWrappers = []interface{}{}
-	file_tensorbeat_datalake_proto_msgTypes[8].OneofWrappers = []interface{}{}
-	file_tensorbeat_datalake_proto_msgTypes[10].OneofWrappers = []interface{}{}
+	file_tensorbeat_datalake_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	file_tensorbeat_datalake_proto_msgTypes[16].OneofWrappers = []interface{}{}
+	file_tensorbeat_datalake_proto_msgTypes[18].OneofWrappers = []interface{}{}
+	file_tensorbeat_datalake_proto_msgTypes[33].OneofWrappers = []interface{}{}
+	file_tensorbeat_datalake_proto_msgTypes[42].OneofWrappers = []interface{}{}
+	file_tensorbeat_datalake_proto_msgTypes[49].OneofWrappers = []interface{}{}
+	file_tensorbeat_datalake_proto_msgTypes[52].OneofWrappers = []interface{}{}
+	file_tensorbeat_datalake_proto_msgTypes[54].OneofWrappers = []interface{}{}
+	file_tensorbeat_datalake_proto_msgTypes[55].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_tensorbeat_datalake_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   15,
+			NumEnums:      5,
+			NumMessages:   70,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,9 +20,46 @@ type DatalakeServiceClient interface {
 	GetAllSongs(ctx context.Context, in *GetAllSongsRequest, opts ...grpc.CallOption) (*GetAllSongsResponse, error)
 	GetSongsByIDs(ctx context.Context, in *GetSongsByIDsRequest, opts ...grpc.CallOption) (*GetSongsByIDsResponse, error)
 	GetSongsByTags(ctx context.Context, in *GetSongsByTagsRequest, opts ...grpc.CallOption) (*GetSongsByTagsResponse, error)
+	// Finds songs with a boolean tag query
+	QuerySongs(ctx context.Context, in *QuerySongsRequest, opts ...grpc.CallOption) (*QuerySongsResponse, error)
+	// Finds songs by words in their name or searchable tags, best matches first
+	SearchSongs(ctx context.Context, in *SearchSongsRequest, opts ...grpc.CallOption) (*SearchSongsResponse, error)
+	// Counts the songs per value of tags
+	GetTagFacets(ctx context.Context, in *GetTagFacetsRequest, opts ...grpc.CallOption) (*GetTagFacetsResponse, error)
+	// Lists the tag keys used by songs, the counts may be up to a minute old
+	ListTagKeys(ctx context.Context, in *ListTagKeysRequest, opts ...grpc.CallOption) (*ListTagKeysResponse, error)
+	// Lists the distinct values of a tag key
+	ListTagValues(ctx context.Context, in *ListTagValuesRequest, opts ...grpc.CallOption) (*ListTagValuesResponse, error)
 	AddSongs(ctx context.Context, in *AddSongsRequest, opts ...grpc.CallOption) (*AddSongsResponse, error)
 	AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*AddTagsResponse, error)
 	RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*RemoveTagsResponse, error)
+	// Add or remove tags on many songs at once, selected by ID or with a query
+	BatchAddTags(ctx context.Context, in *BatchAddTagsRequest, opts ...grpc.CallOption) (*BatchAddTagsResponse, error)
+	BatchRemoveTags(ctx context.Context, in *BatchRemoveTagsRequest, opts ...grpc.CallOption) (*BatchRemoveTagsResponse, error)
+	// Streams every song matching the tags, use it instead of paging through
+	// GetAllSongs to export the catalog
+	StreamSongs(ctx context.Context, in *StreamSongsRequest, opts ...grpc.CallOption) (DatalakeService_StreamSongsClient, error)
+	// Adds songs sent in chunks, one song failing doesn't stop the others
+	IngestSongs(ctx context.Context, opts ...grpc.CallOption) (DatalakeService_IngestSongsClient, error)
+	// Marks songs as deleted, they are hidden from every read until purged
+	DeleteSongs(ctx context.Context, in *DeleteSongsRequest, opts ...grpc.CallOption) (*DeleteSongsResponse, error)
+	// Permanently removes songs that were deleted longer ago than the retention
+	PurgeDeletedSongs(ctx context.Context, in *PurgeDeletedSongsRequest, opts ...grpc.CallOption) (*PurgeDeletedSongsResponse, error)
+	// Changes the fields of existing songs listed in the update mask
+	UpdateSongs(ctx context.Context, in *UpdateSongsRequest, opts ...grpc.CallOption) (*UpdateSongsResponse, error)
+	// Streams changes to songs as they happen
+	WatchSongs(ctx context.Context, in *WatchSongsRequest, opts ...grpc.CallOption) (DatalakeService_WatchSongsClient, error)
+	// Creates or replaces the schema of a tag key, songs written afterwards
+	// must follow it
+	PutTagSchema(ctx context.Context, in *PutTagSchemaRequest, opts ...grpc.CallOption) (*PutTagSchemaResponse, error)
+	ListTagSchemas(ctx context.Context, in *ListTagSchemasRequest, opts ...grpc.CallOption) (*ListTagSchemasResponse, error)
+	DeleteTagSchema(ctx context.Context, in *DeleteTagSchemaRequest, opts ...grpc.CallOption) (*DeleteTagSchemaResponse, error)
+	// Renames a tag key or remaps tag values of every song in batches,
+	// streaming the progress after each batch
+	MigrateTags(ctx context.Context, in *MigrateTagsRequest, opts ...grpc.CallOption) (DatalakeService_MigrateTagsClient, error)
+	// Restores the tags a migration changed, streaming the progress
+	RollBackTagMigration(ctx context.Context, in *RollBackTagMigrationRequest, opts ...grpc.CallOption) (DatalakeService_RollBackTagMigrationClient, error)
+	GetTagMigration(ctx context.Context, in *GetTagMigrationRequest, opts ...grpc.CallOption) (*TagMigration, error)
 }
 
 type datalakeServiceClient struct {
@@ -60,6 +97,51 @@ func (c *datalakeServiceClient) GetSongsByTags(ctx context.Context, in *GetSongs
 	return out, nil
 }
 
+func (c *datalakeServiceClient) QuerySongs(ctx context.Context, in *QuerySongsRequest, opts ...grpc.CallOption) (*QuerySongsResponse, error) {
+	out := new(QuerySongsResponse)
+	err := c.cc.Invoke(ctx, "/tensorbeat.datalake.DatalakeService/QuerySongs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datalakeServiceClient) SearchSongs(ctx context.Context, in *SearchSongsRequest, opts ...grpc.CallOption) (*SearchSongsResponse, error) {
+	out := new(SearchSongsResponse)
+	err := c.cc.Invoke(ctx, "/tensorbeat.datalake.DatalakeService/SearchSongs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datalakeServiceClient) GetTagFacets(ctx context.Context, in *GetTagFacetsRequest, opts ...grpc.CallOption) (*GetTagFacetsResponse, error) {
+	out := new(GetTagFacetsResponse)
+	err := c.cc.Invoke(ctx, "/tensorbeat.datalake.DatalakeService/GetTagFacets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datalakeServiceClient) ListTagKeys(ctx context.Context, in *ListTagKeysRequest, opts ...grpc.CallOption) (*ListTagKeysResponse, error) {
+	out := new(ListTagKeysResponse)
+	err := c.cc.Invoke(ctx, "/tensorbeat.datalake.DatalakeService/ListTagKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datalakeServiceClient) ListTagValues(ctx context.Context, in *ListTagValuesRequest, opts ...grpc.CallOption) (*ListTagValuesResponse, error) {
+	out := new(ListTagValuesResponse)
+	err := c.cc.Invoke(ctx, "/tensorbeat.datalake.DatalakeService/ListTagValues", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *datalakeServiceClient) AddSongs(ctx context.Context, in *AddSongsRequest, opts ...grpc.CallOption) (*AddSongsResponse, error) {
 	out := new(AddSongsResponse)
 	err := c.cc.Invoke(ctx, "/tensorbeat.datalake.DatalakeService/AddSongs", in, out, opts...)
@@ -87,6 +169,249 @@ func (c *datalakeServiceClient) RemoveTags(ctx context.Context, in *RemoveTagsRe
 	return out, nil
 }
 
+func (c *datalakeServiceClient) BatchAddTags(ctx context.Context, in *BatchAddTagsRequest, opts ...grpc.CallOption) (*BatchAddTagsResponse, error) {
+	out := new(BatchAddTagsResponse)
+	err := c.cc.Invoke(ctx, "/tensorbeat.datalake.DatalakeService/BatchAddTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datalakeServiceClient) BatchRemoveTags(ctx context.Context, in *BatchRemoveTagsRequest, opts ...grpc.CallOption) (*BatchRemoveTagsResponse, error) {
+	out := new(BatchRemoveTagsResponse)
+	err := c.cc.Invoke(ctx, "/tensorbeat.datalake.DatalakeService/BatchRemoveTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datalakeServiceClient) StreamSongs(ctx context.Context, in *StreamSongsRequest, opts ...grpc.CallOption) (DatalakeService_StreamSongsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DatalakeService_serviceDesc.Streams[0], "/tensorbeat.datalake.DatalakeService/StreamSongs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &datalakeServiceStreamSongsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DatalakeService_StreamSongsClient interface {
+	Recv() (*StreamSongsResponse, error)
+	grpc.ClientStream
+}
+
+type datalakeServiceStreamSongsClient struct {
+	grpc.ClientStream
+}
+
+func (x *datalakeServiceStreamSongsClient) Recv() (*StreamSongsResponse, error) {
+	m := new(StreamSongsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *datalakeServiceClient) IngestSongs(ctx context.Context, opts ...grpc.CallOption) (DatalakeService_IngestSongsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DatalakeService_serviceDesc.Streams[1], "/tensorbeat.datalake.DatalakeService/IngestSongs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &datalakeServiceIngestSongsClient{stream}
+	return x, nil
+}
+
+type DatalakeService_IngestSongsClient interface {
+	Send(*IngestSongsRequest) error
+	CloseAndRecv() (*IngestSongsResponse, error)
+	grpc.ClientStream
+}
+
+type datalakeServiceIngestSongsClient struct {
+	grpc.ClientStream
+}
+
+func (x *datalakeServiceIngestSongsClient) Send(m *IngestSongsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *datalakeServiceIngestSongsClient) CloseAndRecv() (*IngestSongsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(IngestSongsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *datalakeServiceClient) DeleteSongs(ctx context.Context, in *DeleteSongsRequest, opts ...grpc.CallOption) (*DeleteSongsResponse, error) {
+	out := new(DeleteSongsResponse)
+	err := c.cc.Invoke(ctx, "/tensorbeat.datalake.DatalakeService/DeleteSongs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datalakeServiceClient) PurgeDeletedSongs(ctx context.Context, in *PurgeDeletedSongsRequest, opts ...grpc.CallOption) (*PurgeDeletedSongsResponse, error) {
+	out := new(PurgeDeletedSongsResponse)
+	err := c.cc.Invoke(ctx, "/tensorbeat.datalake.DatalakeService/PurgeDeletedSongs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datalakeServiceClient) UpdateSongs(ctx context.Context, in *UpdateSongsRequest, opts ...grpc.CallOption) (*UpdateSongsResponse, error) {
+	out := new(UpdateSongsResponse)
+	err := c.cc.Invoke(ctx, "/tensorbeat.datalake.DatalakeService/UpdateSongs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datalakeServiceClient) WatchSongs(ctx context.Context, in *WatchSongsRequest, opts ...grpc.CallOption) (DatalakeService_WatchSongsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DatalakeService_serviceDesc.Streams[2], "/tensorbeat.datalake.DatalakeService/WatchSongs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &datalakeServiceWatchSongsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DatalakeService_WatchSongsClient interface {
+	Recv() (*SongEvent, error)
+	grpc.ClientStream
+}
+
+type datalakeServiceWatchSongsClient struct {
+	grpc.ClientStream
+}
+
+func (x *datalakeServiceWatchSongsClient) Recv() (*SongEvent, error) {
+	m := new(SongEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *datalakeServiceClient) PutTagSchema(ctx context.Context, in *PutTagSchemaRequest, opts ...grpc.CallOption) (*PutTagSchemaResponse, error) {
+	out := new(PutTagSchemaResponse)
+	err := c.cc.Invoke(ctx, "/tensorbeat.datalake.DatalakeService/PutTagSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datalakeServiceClient) ListTagSchemas(ctx context.Context, in *ListTagSchemasRequest, opts ...grpc.CallOption) (*ListTagSchemasResponse, error) {
+	out := new(ListTagSchemasResponse)
+	err := c.cc.Invoke(ctx, "/tensorbeat.datalake.DatalakeService/ListTagSchemas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datalakeServiceClient) DeleteTagSchema(ctx context.Context, in *DeleteTagSchemaRequest, opts ...grpc.CallOption) (*DeleteTagSchemaResponse, error) {
+	out := new(DeleteTagSchemaResponse)
+	err := c.cc.Invoke(ctx, "/tensorbeat.datalake.DatalakeService/DeleteTagSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *datalakeServiceClient) MigrateTags(ctx context.Context, in *MigrateTagsRequest, opts ...grpc.CallOption) (DatalakeService_MigrateTagsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DatalakeService_serviceDesc.Streams[3], "/tensorbeat.datalake.DatalakeService/MigrateTags", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &datalakeServiceMigrateTagsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DatalakeService_MigrateTagsClient interface {
+	Recv() (*TagMigration, error)
+	grpc.ClientStream
+}
+
+type datalakeServiceMigrateTagsClient struct {
+	grpc.ClientStream
+}
+
+func (x *datalakeServiceMigrateTagsClient) Recv() (*TagMigration, error) {
+	m := new(TagMigration)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *datalakeServiceClient) RollBackTagMigration(ctx context.Context, in *RollBackTagMigrationRequest, opts ...grpc.CallOption) (DatalakeService_RollBackTagMigrationClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DatalakeService_serviceDesc.Streams[4], "/tensorbeat.datalake.DatalakeService/RollBackTagMigration", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &datalakeServiceRollBackTagMigrationClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DatalakeService_RollBackTagMigrationClient interface {
+	Recv() (*TagMigration, error)
+	grpc.ClientStream
+}
+
+type datalakeServiceRollBackTagMigrationClient struct {
+	grpc.ClientStream
+}
+
+func (x *datalakeServiceRollBackTagMigrationClient) Recv() (*TagMigration, error) {
+	m := new(TagMigration)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *datalakeServiceClient) GetTagMigration(ctx context.Context, in *GetTagMigrationRequest, opts ...grpc.CallOption) (*TagMigration, error) {
+	out := new(TagMigration)
+	err := c.cc.Invoke(ctx, "/tensorbeat.datalake.DatalakeService/GetTagMigration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DatalakeServiceServer is the server API for DatalakeService service.
 // All implementations must embed UnimplementedDatalakeServiceServer
 // for forward compatibility
@@ -94,9 +419,46 @@ type DatalakeServiceServer interface {
 	GetAllSongs(context.Context, *GetAllSongsRequest) (*GetAllSongsResponse, error)
 	GetSongsByIDs(context.Context, *GetSongsByIDsRequest) (*GetSongsByIDsResponse, error)
 	GetSongsByTags(context.Context, *GetSongsByTagsRequest) (*GetSongsByTagsResponse, error)
+	// Finds songs with a boolean tag query
+	QuerySongs(context.Context, *QuerySongsRequest) (*QuerySongsResponse, error)
+	// Finds songs by words in their name or searchable tags, best matches first
+	SearchSongs(context.Context, *SearchSongsRequest) (*SearchSongsResponse, error)
+	// Counts the songs per value of tags
+	GetTagFacets(context.Context, *GetTagFacetsRequest) (*GetTagFacetsResponse, error)
+	// Lists the tag keys used by songs, the counts may be up to a minute old
+	ListTagKeys(context.Context, *ListTagKeysRequest) (*ListTagKeysResponse, error)
+	// Lists the distinct values of a tag key
+	ListTagValues(context.Context, *ListTagValuesRequest) (*ListTagValuesResponse, error)
 	AddSongs(context.Context, *AddSongsRequest) (*AddSongsResponse, error)
 	AddTags(context.Context, *AddTagsRequest) (*AddTagsResponse, error)
 	RemoveTags(context.Context, *RemoveTagsRequest) (*RemoveTagsResponse, error)
+	// Add or remove tags on many songs at once, selected by ID or with a query
+	BatchAddTags(context.Context, *BatchAddTagsRequest) (*BatchAddTagsResponse, error)
+	BatchRemoveTags(context.Context, *BatchRemoveTagsRequest) (*BatchRemoveTagsResponse, error)
+	// Streams every song matching the tags, use it instead of paging through
+	// GetAllSongs to export the catalog
+	StreamSongs(*StreamSongsRequest, DatalakeService_StreamSongsServer) error
+	// Adds songs sent in chunks, one song failing doesn't stop the others
+	IngestSongs(DatalakeService_IngestSongsServer) error
+	// Marks songs as deleted, they are hidden from every read until purged
+	DeleteSongs(context.Context, *DeleteSongsRequest) (*DeleteSongsResponse, error)
+	// Permanently removes songs that were deleted longer ago than the retention
+	PurgeDeletedSongs(context.Context, *PurgeDeletedSongsRequest) (*PurgeDeletedSongsResponse, error)
+	// Changes the fields of existing songs listed in the update mask
+	UpdateSongs(context.Context, *UpdateSongsRequest) (*UpdateSongsResponse, error)
+	// Streams changes to songs as they happen
+	WatchSongs(*WatchSongsRequest, DatalakeService_WatchSongsServer) error
+	// Creates or replaces the schema of a tag key, songs written afterwards
+	// must follow it
+	PutTagSchema(context.Context, *PutTagSchemaRequest) (*PutTagSchemaResponse, error)
+	ListTagSchemas(context.Context, *ListTagSchemasRequest) (*ListTagSchemasResponse, error)
+	DeleteTagSchema(context.Context, *DeleteTagSchemaRequest) (*DeleteTagSchemaResponse, error)
+	// Renames a tag key or remaps tag values of every song in batches,
+	// streaming the progress after each batch
+	MigrateTags(*MigrateTagsRequest, DatalakeService_MigrateTagsServer) error
+	// Restores the tags a migration changed, streaming the progress
+	RollBackTagMigration(*RollBackTagMigrationRequest, DatalakeService_RollBackTagMigrationServer) error
+	GetTagMigration(context.Context, *GetTagMigrationRequest) (*TagMigration, error)
 	mustEmbedUnimplementedDatalakeServiceServer()
 }
 
@@ -113,6 +475,21 @@ func (UnimplementedDatalakeServiceServer) GetSongsByIDs(context.Context, *GetSon
 func (UnimplementedDatalakeServiceServer) GetSongsByTags(context.Context, *GetSongsByTagsRequest) (*GetSongsByTagsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSongsByTags not implemented")
 }
+func (UnimplementedDatalakeServiceServer) QuerySongs(context.Context, *QuerySongsRequest) (*QuerySongsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuerySongs not implemented")
+}
+func (UnimplementedDatalakeServiceServer) SearchSongs(context.Context, *SearchSongsRequest) (*SearchSongsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchSongs not implemented")
+}
+func (UnimplementedDatalakeServiceServer) GetTagFacets(context.Context, *GetTagFacetsRequest) (*GetTagFacetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTagFacets not implemented")
+}
+func (UnimplementedDatalakeServiceServer) ListTagKeys(context.Context, *ListTagKeysRequest) (*ListTagKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTagKeys not implemented")
+}
+func (UnimplementedDatalakeServiceServer) ListTagValues(context.Context, *ListTagValuesRequest) (*ListTagValuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTagValues not implemented")
+}
 func (UnimplementedDatalakeServiceServer) AddSongs(context.Context, *AddSongsRequest) (*AddSongsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddSongs not implemented")
 }
@@ -122,6 +499,48 @@ func (UnimplementedDatalakeServiceServer) AddTags(context.Context, *AddTagsReque
 func (UnimplementedDatalakeServiceServer) RemoveTags(context.Context, *RemoveTagsRequest) (*RemoveTagsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveTags not implemented")
 }
+func (UnimplementedDatalakeServiceServer) BatchAddTags(context.Context, *BatchAddTagsRequest) (*BatchAddTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchAddTags not implemented")
+}
+func (UnimplementedDatalakeServiceServer) BatchRemoveTags(context.Context, *BatchRemoveTagsRequest) (*BatchRemoveTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchRemoveTags not implemented")
+}
+func (UnimplementedDatalakeServiceServer) StreamSongs(*StreamSongsRequest, DatalakeService_StreamSongsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSongs not implemented")
+}
+func (UnimplementedDatalakeServiceServer) IngestSongs(DatalakeService_IngestSongsServer) error {
+	return status.Errorf(codes.Unimplemented, "method IngestSongs not implemented")
+}
+func (UnimplementedDatalakeServiceServer) DeleteSongs(context.Context, *DeleteSongsRequest) (*DeleteSongsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSongs not implemented")
+}
+func (UnimplementedDatalakeServiceServer) PurgeDeletedSongs(context.Context, *PurgeDeletedSongsRequest) (*PurgeDeletedSongsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeletedSongs not implemented")
+}
+func (UnimplementedDatalakeServiceServer) UpdateSongs(context.Context, *UpdateSongsRequest) (*UpdateSongsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSongs not implemented")
+}
+func (UnimplementedDatalakeServiceServer) WatchSongs(*WatchSongsRequest, DatalakeService_WatchSongsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSongs not implemented")
+}
+func (UnimplementedDatalakeServiceServer) PutTagSchema(context.Context, *PutTagSchemaRequest) (*PutTagSchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutTagSchema not implemented")
+}
+func (UnimplementedDatalakeServiceServer) ListTagSchemas(context.Context, *ListTagSchemasRequest) (*ListTagSchemasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTagSchemas not implemented")
+}
+func (UnimplementedDatalakeServiceServer) DeleteTagSchema(context.Context, *DeleteTagSchemaRequest) (*DeleteTagSchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTagSchema not implemented")
+}
+func (UnimplementedDatalakeServiceServer) MigrateTags(*MigrateTagsRequest, DatalakeService_MigrateTagsServer) error {
+	return status.Errorf(codes.Unimplemented, "method MigrateTags not implemented")
+}
+func (UnimplementedDatalakeServiceServer) RollBackTagMigration(*RollBackTagMigrationRequest, DatalakeService_RollBackTagMigrationServer) error {
+	return status.Errorf(codes.Unimplemented, "method RollBackTagMigration not implemented")
+}
+func (UnimplementedDatalakeServiceServer) GetTagMigration(context.Context, *GetTagMigrationRequest) (*TagMigration, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTagMigration not implemented")
+}
 func (UnimplementedDatalakeServiceServer) mustEmbedUnimplementedDatalakeServiceServer() {}
 
 // UnsafeDatalakeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -189,6 +608,96 @@ func _DatalakeService_GetSongsByTags_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DatalakeService_QuerySongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuerySongsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatalakeServiceServer).QuerySongs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tensorbeat.datalake.DatalakeService/QuerySongs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatalakeServiceServer).QuerySongs(ctx, req.(*QuerySongsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatalakeService_SearchSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchSongsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatalakeServiceServer).SearchSongs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tensorbeat.datalake.DatalakeService/SearchSongs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatalakeServiceServer).SearchSongs(ctx, req.(*SearchSongsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatalakeService_GetTagFacets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagFacetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatalakeServiceServer).GetTagFacets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tensorbeat.datalake.DatalakeService/GetTagFacets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatalakeServiceServer).GetTagFacets(ctx, req.(*GetTagFacetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatalakeService_ListTagKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatalakeServiceServer).ListTagKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tensorbeat.datalake.DatalakeService/ListTagKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatalakeServiceServer).ListTagKeys(ctx, req.(*ListTagKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatalakeService_ListTagValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatalakeServiceServer).ListTagValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tensorbeat.datalake.DatalakeService/ListTagValues",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatalakeServiceServer).ListTagValues(ctx, req.(*ListTagValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DatalakeService_AddSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddSongsRequest)
 	if err := dec(in); err != nil {
@@ -243,6 +752,278 @@ func _DatalakeService_RemoveTags_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DatalakeService_BatchAddTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchAddTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatalakeServiceServer).BatchAddTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tensorbeat.datalake.DatalakeService/BatchAddTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatalakeServiceServer).BatchAddTags(ctx, req.(*BatchAddTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatalakeService_BatchRemoveTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRemoveTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatalakeServiceServer).BatchRemoveTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tensorbeat.datalake.DatalakeService/BatchRemoveTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatalakeServiceServer).BatchRemoveTags(ctx, req.(*BatchRemoveTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatalakeService_StreamSongs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamSongsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatalakeServiceServer).StreamSongs(m, &datalakeServiceStreamSongsServer{stream})
+}
+
+type DatalakeService_StreamSongsServer interface {
+	Send(*StreamSongsResponse) error
+	grpc.ServerStream
+}
+
+type datalakeServiceStreamSongsServer struct {
+	grpc.ServerStream
+}
+
+func (x *datalakeServiceStreamSongsServer) Send(m *StreamSongsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DatalakeService_IngestSongs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DatalakeServiceServer).IngestSongs(&datalakeServiceIngestSongsServer{stream})
+}
+
+type DatalakeService_IngestSongsServer interface {
+	SendAndClose(*IngestSongsResponse) error
+	Recv() (*IngestSongsRequest, error)
+	grpc.ServerStream
+}
+
+type datalakeServiceIngestSongsServer struct {
+	grpc.ServerStream
+}
+
+func (x *datalakeServiceIngestSongsServer) SendAndClose(m *IngestSongsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *datalakeServiceIngestSongsServer) Recv() (*IngestSongsRequest, error) {
+	m := new(IngestSongsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _DatalakeService_DeleteSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSongsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatalakeServiceServer).DeleteSongs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tensorbeat.datalake.DatalakeService/DeleteSongs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatalakeServiceServer).DeleteSongs(ctx, req.(*DeleteSongsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatalakeService_PurgeDeletedSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDeletedSongsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatalakeServiceServer).PurgeDeletedSongs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tensorbeat.datalake.DatalakeService/PurgeDeletedSongs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatalakeServiceServer).PurgeDeletedSongs(ctx, req.(*PurgeDeletedSongsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatalakeService_UpdateSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSongsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatalakeServiceServer).UpdateSongs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tensorbeat.datalake.DatalakeService/UpdateSongs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatalakeServiceServer).UpdateSongs(ctx, req.(*UpdateSongsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatalakeService_WatchSongs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSongsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatalakeServiceServer).WatchSongs(m, &datalakeServiceWatchSongsServer{stream})
+}
+
+type DatalakeService_WatchSongsServer interface {
+	Send(*SongEvent) error
+	grpc.ServerStream
+}
+
+type datalakeServiceWatchSongsServer struct {
+	grpc.ServerStream
+}
+
+func (x *datalakeServiceWatchSongsServer) Send(m *SongEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DatalakeService_PutTagSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutTagSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatalakeServiceServer).PutTagSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tensorbeat.datalake.DatalakeService/PutTagSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatalakeServiceServer).PutTagSchema(ctx, req.(*PutTagSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatalakeService_ListTagSchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagSchemasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatalakeServiceServer).ListTagSchemas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tensorbeat.datalake.DatalakeService/ListTagSchemas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatalakeServiceServer).ListTagSchemas(ctx, req.(*ListTagSchemasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatalakeService_DeleteTagSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatalakeServiceServer).DeleteTagSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tensorbeat.datalake.DatalakeService/DeleteTagSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatalakeServiceServer).DeleteTagSchema(ctx, req.(*DeleteTagSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DatalakeService_MigrateTags_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MigrateTagsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatalakeServiceServer).MigrateTags(m, &datalakeServiceMigrateTagsServer{stream})
+}
+
+type DatalakeService_MigrateTagsServer interface {
+	Send(*TagMigration) error
+	grpc.ServerStream
+}
+
+type datalakeServiceMigrateTagsServer struct {
+	grpc.ServerStream
+}
+
+func (x *datalakeServiceMigrateTagsServer) Send(m *TagMigration) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DatalakeService_RollBackTagMigration_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RollBackTagMigrationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatalakeServiceServer).RollBackTagMigration(m, &datalakeServiceRollBackTagMigrationServer{stream})
+}
+
+type DatalakeService_RollBackTagMigrationServer interface {
+	Send(*TagMigration) error
+	grpc.ServerStream
+}
+
+type datalakeServiceRollBackTagMigrationServer struct {
+	grpc.ServerStream
+}
+
+func (x *datalakeServiceRollBackTagMigrationServer) Send(m *TagMigration) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DatalakeService_GetTagMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagMigrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatalakeServiceServer).GetTagMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tensorbeat.datalake.DatalakeService/GetTagMigration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatalakeServiceServer).GetTagMigration(ctx, req.(*GetTagMigrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DatalakeService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "tensorbeat.datalake.DatalakeService",
 	HandlerType: (*DatalakeServiceServer)(nil),
@@ -259,6 +1040,26 @@ var _DatalakeService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetSongsByTags",
 			Handler:    _DatalakeService_GetSongsByTags_Handler,
 		},
+		{
+			MethodName: "QuerySongs",
+			Handler:    _DatalakeService_QuerySongs_Handler,
+		},
+		{
+			MethodName: "SearchSongs",
+			Handler:    _DatalakeService_SearchSongs_Handler,
+		},
+		{
+			MethodName: "GetTagFacets",
+			Handler:    _DatalakeService_GetTagFacets_Handler,
+		},
+		{
+			MethodName: "ListTagKeys",
+			Handler:    _DatalakeService_ListTagKeys_Handler,
+		},
+		{
+			MethodName: "ListTagValues",
+			Handler:    _DatalakeService_ListTagValues_Handler,
+		},
 		{
 			MethodName: "AddSongs",
 			Handler:    _DatalakeService_AddSongs_Handler,
@@ -271,7 +1072,69 @@ var _DatalakeService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveTags",
 			Handler:    _DatalakeService_RemoveTags_Handler,
 		},
+		{
+			MethodName: "BatchAddTags",
+			Handler:    _DatalakeService_BatchAddTags_Handler,
+		},
+		{
+			MethodName: "BatchRemoveTags",
+			Handler:    _DatalakeService_BatchRemoveTags_Handler,
+		},
+		{
+			MethodName: "DeleteSongs",
+			Handler:    _DatalakeService_DeleteSongs_Handler,
+		},
+		{
+			MethodName: "PurgeDeletedSongs",
+			Handler:    _DatalakeService_PurgeDeletedSongs_Handler,
+		},
+		{
+			MethodName: "UpdateSongs",
+			Handler:    _DatalakeService_UpdateSongs_Handler,
+		},
+		{
+			MethodName: "PutTagSchema",
+			Handler:    _DatalakeService_PutTagSchema_Handler,
+		},
+		{
+			MethodName: "ListTagSchemas",
+			Handler:    _DatalakeService_ListTagSchemas_Handler,
+		},
+		{
+			MethodName: "DeleteTagSchema",
+			Handler:    _DatalakeService_DeleteTagSchema_Handler,
+		},
+		{
+			MethodName: "GetTagMigration",
+			Handler:    _DatalakeService_GetTagMigration_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamSongs",
+			Handler:       _DatalakeService_StreamSongs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "IngestSongs",
+			Handler:       _DatalakeService_IngestSongs_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchSongs",
+			Handler:       _DatalakeService_WatchSongs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "MigrateTags",
+			Handler:       _DatalakeService_MigrateTags_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RollBackTagMigration",
+			Handler:       _DatalakeService_RollBackTagMigration_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "tensorbeat/datalake.proto",
 }